@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/typesense/typesense-go/v3/typesense/api"
+)
+
+// vocabularyNormalizer maps legacy spellings ("Bent-Over", "EZ Bar") onto vocabulary values.
+var vocabularyNormalizer = strings.NewReplacer(" ", "_", "-", "_")
+
+// backfillExerciseMetadata copies the metadata that only exists in the Typesense documents into
+// the exercises table. Only NULL columns are filled, so values edited in the database win.
+// Values outside the controlled vocabularies are reported and skipped.
+func backfillExerciseMetadata(ctx context.Context, args []string) error {
+	fs := flagSet("backfill-exercise-metadata", backfillExerciseMetadataUsage)
+	dryRun := fs.Bool("dry-run", false, "Report what would change without writing to the database")
+	fs.Parse(args)
+
+	deps, err := newDependencies()
+	if err != nil {
+		return err
+	}
+	defer deps.db.Close()
+
+	export, err := deps.typesense.Collection(provider.ExercisesCollection).Documents().Export(ctx, &api.ExportDocumentsParams{})
+	if err != nil {
+		return fmt.Errorf("failed to export exercises from Typesense: %w", err)
+	}
+	defer export.Close()
+
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	var (
+		documents, updated int
+		rejected           = map[string]map[string]int{} // column -> value -> count
+	)
+
+	scanner := bufio.NewScanner(export)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		var doc map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			log.Printf("WARN: Skipping malformed document: %v", err)
+			continue
+		}
+		documents++
+
+		idStr, _ := doc["uuid"].(string)
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			log.Printf("WARN: Skipping document without a valid uuid: %q", idStr)
+			continue
+		}
+
+		update := sq.Update("exercises").Where(squirrel.Eq{"id": id})
+		changes := 0
+		if description, _ := doc["description"].(string); strings.TrimSpace(description) != "" {
+			update = update.Set("description", squirrel.Expr("COALESCE(description, ?)", strings.TrimSpace(description)))
+			changes++
+		}
+		for column := range model.ExerciseVocabularies {
+			raw, _ := doc[column].(string)
+			if strings.TrimSpace(raw) == "" {
+				continue
+			}
+			value := vocabularyNormalizer.Replace(strings.ToLower(strings.TrimSpace(raw)))
+			if !model.IsExerciseVocabularyValue(column, value) {
+				if rejected[column] == nil {
+					rejected[column] = map[string]int{}
+				}
+				rejected[column][raw]++
+				continue
+			}
+			update = update.Set(column, squirrel.Expr(fmt.Sprintf("COALESCE(%s, ?)", column), value))
+			changes++
+		}
+		if changes == 0 || *dryRun {
+			if changes > 0 {
+				updated++
+			}
+			continue
+		}
+
+		query, queryArgs, err := update.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build update for %s: %w", id, err)
+		}
+		// The outbox entry makes the search sync worker rebuild the document from the row.
+		tx, err := deps.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, query, queryArgs...)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to update exercise %s: %w", id, err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			if err := provider.EnqueueSearchSync(ctx, tx, sq, model.SearchEntityExercise, model.SearchOperationUpsert, id); err != nil {
+				tx.Rollback()
+				return err
+			}
+			updated++
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit update of exercise %s: %w", id, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read Typesense export: %w", err)
+	}
+
+	columns := make([]string, 0, len(rejected))
+	for column := range rejected {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		for value, count := range rejected[column] {
+			log.Printf("Skipped %d value(s) %q for %s: not in the vocabulary", count, value, column)
+		}
+	}
+
+	verb := "updated"
+	if *dryRun {
+		verb = "would update"
+	}
+	log.Printf("Backfill complete: %d documents read, %s %d exercises", documents, verb, updated)
+	return nil
+}
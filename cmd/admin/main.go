@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	_ "github.com/joho/godotenv/autoload"
+
+	"rtglabs-go/config"
+	"rtglabs-go/config/database"
+	"rtglabs-go/provider"
+
+	"github.com/typesense/typesense-go/v3/typesense"
+)
+
+// Descriptions shown by usage() and the per-command -h output.
+const (
+	reindexExercisesUsage  = "Rebuild the Typesense exercises collection from the database"
+	syncSearchUsage        = "Drain the search outbox once and exit (the API normally does this in the background)"
+	checkSearchSchemaUsage = "Compare the live Typesense exercises collection with the expected schema"
+
+	backfillExerciseMetadataUsage = "Copy exercise metadata that only exists in Typesense into the database"
+	setAdminUsage                 = "Grant or revoke admin access for a user"
+	seedExercisesUsage            = "Create or update the global exercise catalog from a versioned JSON/CSV dataset"
+)
+
+// commands maps each admin subcommand to its implementation.
+var commands = map[string]struct {
+	usage string
+	run   func(ctx context.Context, args []string) error
+}{
+	"check-search-schema": {
+		usage: checkSearchSchemaUsage,
+		run:   checkSearchSchema,
+	},
+	"reindex-exercises": {
+		usage: reindexExercisesUsage,
+		run:   reindexExercises,
+	},
+	"backfill-exercise-metadata": {
+		usage: backfillExerciseMetadataUsage,
+		run:   backfillExerciseMetadata,
+	},
+	"seed-exercises": {
+		usage: seedExercisesUsage,
+		run:   seedExercises,
+	},
+	"set-admin": {
+		usage: setAdminUsage,
+		run:   setAdmin,
+	},
+	"sync-search": {
+		usage: syncSearchUsage,
+		run:   syncSearch,
+	},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: admin <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", name, commands[name].usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	// Ctrl+C stops long running commands between batches.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, os.Args[2:]); err != nil {
+		log.Fatalf("%s failed: %v", os.Args[1], err)
+	}
+}
+
+// dependencies are the clients shared by the admin commands.
+type dependencies struct {
+	db        *sql.DB
+	typesense *typesense.Client
+}
+
+// newDependencies connects to the database and Typesense using the same environment
+// variables as the API server.
+func newDependencies() (*dependencies, error) {
+	dbURL := os.Getenv("DATABASE_URL")
+	driverName := os.Getenv("DB_DRIVER")
+	if dbURL == "" || driverName == "" {
+		return nil, fmt.Errorf("DATABASE_URL and DB_DRIVER must be set")
+	}
+	sqlDB, err := database.NewSQLClient(driverName, dbURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlDB.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	tsConfig, err := config.LoadTypesenseConfig()
+	if err != nil {
+		return nil, err
+	}
+	tsProvider, err := provider.NewTypesenseClient(tsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dependencies{db: sqlDB, typesense: tsProvider.Client}, nil
+}
+
+// flagSet creates a FlagSet that prints the command's usage on error.
+func flagSet(name, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: admin %s [flags]\n\n%s\n\nFlags:\n", name, description)
+		fs.PrintDefaults()
+	}
+	return fs
+}
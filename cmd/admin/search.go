@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// reindexExercises rebuilds the exercises collection from the database: every live exercise
+// is upserted in batches and every soft-deleted one is removed from the index.
+//
+// With --recreate the exercises are imported into a new collection instead, which then
+// replaces the old one by swapping the alias, so search keeps working throughout.
+func reindexExercises(ctx context.Context, args []string) error {
+	fs := flagSet("reindex-exercises", reindexExercisesUsage)
+	batchSize := fs.Int("batch-size", 500, "Number of exercises imported per request")
+	recreate := fs.Bool("recreate", false, "Import into a new collection with the current schema and swap the alias to it once complete")
+	keepOld := fs.Bool("keep-old", false, "With --recreate, keep the previous collection instead of dropping it after the swap")
+	fs.Parse(args)
+	if *batchSize < 1 {
+		return fmt.Errorf("--batch-size must be positive")
+	}
+
+	deps, err := newDependencies()
+	if err != nil {
+		return err
+	}
+	defer deps.db.Close()
+
+	indexer := provider.NewExerciseIndexer(deps.typesense)
+	target := indexer
+	started := time.Now()
+	if *recreate {
+		name, err := indexer.CreateCollection(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Building collection %q", name)
+		target = &provider.ExerciseIndexer{Client: deps.typesense, Collection: name}
+	} else if err := indexer.EnsureCollection(ctx); err != nil {
+		return err
+	}
+
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	// Keyset pagination on id keeps every batch query cheap, however large the table gets.
+	imported := 0
+	lastID := uuid.Nil
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch, err := fetchExerciseBatch(ctx, deps.db, sq, lastID, *batchSize)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		if err := target.Import(ctx, batch); err != nil {
+			return err
+		}
+		imported += len(batch)
+		lastID = batch[len(batch)-1].ID
+		log.Printf("Imported %d exercises", imported)
+	}
+
+	deleted := 0
+	if !*recreate {
+		query, args, err := sq.Select("id").From("exercises").Where(squirrel.NotEq{"deleted_at": nil}).ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build deleted exercises query: %w", err)
+		}
+		rows, err := deps.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to query deleted exercises: %w", err)
+		}
+		ids := make([]uuid.UUID, 0)
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan deleted exercise: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("deleted exercises rows error: %w", err)
+		}
+		for _, id := range ids {
+			if err := indexer.Delete(ctx, id); err != nil {
+				return err
+			}
+			deleted++
+		}
+	}
+
+	if *recreate {
+		if err := swapExercisesCollection(ctx, deps, indexer, target.Collection, started, *keepOld); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Reindex complete: %d exercises imported, %d deleted exercises removed", imported, deleted)
+	return nil
+}
+
+// swapExercisesCollection points the exercises alias at the freshly built collection. Changes
+// made while it was being built went to the previous collection, so every exercise touched
+// since started is queued again and the outbox drained against the new one.
+func swapExercisesCollection(ctx context.Context, deps *dependencies, indexer *provider.ExerciseIndexer, name string, started time.Time, keepOld bool) error {
+	previous, err := indexer.SwapAlias(ctx, name)
+	if err != nil {
+		return err
+	}
+	log.Printf("Alias %q now points at %q", indexer.Collection, name)
+
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	query, args, err := sq.Select("DISTINCT entity_id").
+		From("search_outbox").
+		Where(squirrel.Eq{"entity_type": model.SearchEntityExercise}).
+		Where(squirrel.GtOrEq{"created_at": started}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build changed exercises query: %w", err)
+	}
+	rows, err := deps.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query changed exercises: %w", err)
+	}
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan changed exercise: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("changed exercises rows error: %w", err)
+	}
+	// The worker checks the current row, so an upsert entry also removes exercises deleted meanwhile.
+	if err := provider.EnqueueSearchSync(ctx, deps.db, sq, model.SearchEntityExercise, model.SearchOperationUpsert, ids...); err != nil {
+		return err
+	}
+	processed, err := drainSearchOutbox(ctx, deps)
+	if err != nil {
+		return err
+	}
+	log.Printf("Caught up on %d exercises changed during the import (%d outbox entries processed)", len(ids), processed)
+
+	if previous == "" {
+		return nil
+	}
+	if keepOld {
+		log.Printf("Keeping previous collection %q", previous)
+		return nil
+	}
+	log.Printf("Dropping previous collection %q", previous)
+	return indexer.DropCollection(ctx, previous)
+}
+
+// checkSearchSchema compares the live exercises collection with the schema the indexer
+// writes and lists every difference.
+func checkSearchSchema(ctx context.Context, args []string) error {
+	fs := flagSet("check-search-schema", checkSearchSchemaUsage)
+	fs.Parse(args)
+
+	deps, err := newDependencies()
+	if err != nil {
+		return err
+	}
+	defer deps.db.Close()
+
+	indexer := provider.NewExerciseIndexer(deps.typesense)
+	drift, err := indexer.SchemaDrift(ctx)
+	if err != nil {
+		return err
+	}
+	if len(drift) == 0 {
+		log.Printf("Collection %q matches the expected schema", indexer.Collection)
+		return nil
+	}
+	for _, d := range drift {
+		log.Printf("Drift: %s", d)
+	}
+	return fmt.Errorf("%w: %d differences, run `admin reindex-exercises --recreate` to rebuild the collection", provider.ErrExerciseSchemaDrift, len(drift))
+}
+
+// fetchExerciseBatch returns up to limit live exercises, with their aliases, instructions and translations, with an id greater than afterID.
+func fetchExerciseBatch(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, afterID uuid.UUID, limit int) ([]model.Exercise, error) {
+	query, args, err := sq.Select(provider.ExerciseColumns...).
+		From("exercises").
+		Where(squirrel.And{
+			squirrel.Eq{"deleted_at": nil},
+			squirrel.Gt{"id": afterID},
+		}).
+		OrderBy("id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise batch query: %w", err)
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise batch: %w", err)
+	}
+	defer rows.Close()
+
+	batch := make([]model.Exercise, 0, limit)
+	for rows.Next() {
+		ex, err := provider.ScanExercise(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise: %w", err)
+		}
+		batch = append(batch, ex)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise batch rows error: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(batch))
+	for _, ex := range batch {
+		ids = append(ids, ex.ID)
+	}
+	aliases, err := provider.FetchExerciseAliases(ctx, db, sq, ids)
+	if err != nil {
+		return nil, err
+	}
+	instructions, err := provider.FetchExerciseInstructions(ctx, db, sq, ids)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := provider.FetchExerciseTranslations(ctx, db, sq, ids)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range batch {
+		batch[idx].Aliases = aliases[batch[idx].ID]
+		batch[idx].Instructions = instructions[batch[idx].ID]
+		batch[idx].Translations = translations[batch[idx].ID]
+	}
+	return batch, nil
+}
+
+// syncSearch processes the search outbox until no due entries are left.
+func syncSearch(ctx context.Context, args []string) error {
+	fs := flagSet("sync-search", syncSearchUsage)
+	fs.Parse(args)
+
+	deps, err := newDependencies()
+	if err != nil {
+		return err
+	}
+	defer deps.db.Close()
+
+	total, err := drainSearchOutbox(ctx, deps)
+	if err != nil {
+		return err
+	}
+	log.Printf("Search outbox drained: %d entries processed", total)
+	return nil
+}
+
+// drainSearchOutbox processes outbox batches until no due entries are left and returns
+// the number of processed entries.
+func drainSearchOutbox(ctx context.Context, deps *dependencies) (int, error) {
+	worker := provider.NewSearchSyncWorker(deps.db, provider.NewExerciseIndexer(deps.typesense))
+	total := 0
+	for {
+		processed, err := worker.ProcessBatch(ctx)
+		if err != nil {
+			return total, err
+		}
+		total += processed
+		if processed < worker.BatchSize {
+			return total, nil
+		}
+	}
+}
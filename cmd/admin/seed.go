@@ -0,0 +1,469 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// defaultSeedFile is the dataset shipped with the binary, used when --file is not given.
+const defaultSeedFile = "seeds/exercises.json"
+
+//go:embed seeds/exercises.json
+var seedFiles embed.FS
+
+// seedSlugPattern is the format of dataset slugs, e.g. "barbell-bench-press".
+var seedSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// seedCSVColumns are the header columns of a CSV dataset. Aliases are separated by "|".
+var seedCSVColumns = []string{
+	"slug", "name", "description", "position", "force_type", "difficulty", "movement_type",
+	"muscle_group", "equipment", "bodypart", "tracking_type", "aliases",
+}
+
+// seedDataset is a versioned exercise catalog. JSON datasets carry their version; CSV datasets
+// get it from the --version flag.
+type seedDataset struct {
+	Version   int            `json:"version"`
+	Exercises []seedExercise `json:"exercises"`
+}
+
+// seedExercise is one catalog exercise. Apart from the slug and name every field is optional;
+// vocabulary-controlled fields must use the values listed in model/exercise.go.
+type seedExercise struct {
+	Slug         string   `json:"slug"`
+	Name         string   `json:"name"`
+	Description  *string  `json:"description"`
+	Position     *string  `json:"position"`
+	ForceType    *string  `json:"force_type"`
+	Difficulty   *string  `json:"difficulty"`
+	MovementType *string  `json:"movement_type"`
+	MuscleGroup  *string  `json:"muscle_group"`
+	Equipment    *string  `json:"equipment"`
+	Bodypart     *string  `json:"bodypart"`
+	TrackingType *string  `json:"tracking_type"` // Defaults to weight_reps
+	Aliases      []string `json:"aliases"`
+}
+
+// columns returns the exercises columns set from the dataset entry.
+func (s *seedExercise) columns() map[string]any {
+	trackingType := model.ExerciseTrackingWeightReps
+	if s.TrackingType != nil {
+		trackingType = *s.TrackingType
+	}
+	return map[string]any{
+		"slug":          s.Slug,
+		"name":          s.Name,
+		"description":   s.Description,
+		"position":      s.Position,
+		"force_type":    s.ForceType,
+		"difficulty":    s.Difficulty,
+		"movement_type": s.MovementType,
+		"muscle_group":  s.MuscleGroup,
+		"equipment":     s.Equipment,
+		"bodypart":      s.Bodypart,
+		"tracking_type": trackingType,
+	}
+}
+
+// matches reports whether the exercise row already holds every value of the dataset entry.
+func (s *seedExercise) matches(ex *model.Exercise) bool {
+	same := func(a, b *string) bool {
+		return provider.StringPtrToString(a) == provider.StringPtrToString(b)
+	}
+	return provider.StringPtrToString(ex.Slug) == s.Slug &&
+		ex.Name == s.Name &&
+		same(ex.Description, s.Description) &&
+		same(ex.Position, s.Position) &&
+		same(ex.ForceType, s.ForceType) &&
+		same(ex.Difficulty, s.Difficulty) &&
+		same(ex.MovementType, s.MovementType) &&
+		same(ex.MuscleGroup, s.MuscleGroup) &&
+		same(ex.Equipment, s.Equipment) &&
+		same(ex.Bodypart, s.Bodypart) &&
+		ex.TrackingType == s.columns()["tracking_type"]
+}
+
+// seedExercises upserts the global exercise catalog from a dataset, matching rows on their slug.
+// Existing global exercises without a slug are adopted when their name matches, so seeding a
+// database that already has a hand-made catalog doesn't create duplicates. Aliases are only
+// added, never removed, so aliases added by admins survive a re-run.
+func seedExercises(ctx context.Context, args []string) error {
+	fs := flagSet("seed-exercises", seedExercisesUsage)
+	file := fs.String("file", "", "Dataset to load, .json or .csv (default: the dataset built into the binary)")
+	version := fs.Int("version", 0, "Dataset version of a CSV file")
+	dryRun := fs.Bool("dry-run", false, "Report what would change without writing to the database")
+	noSync := fs.Bool("no-sync", false, "Leave the search sync to the API's background worker")
+	fs.Parse(args)
+
+	dataset, err := loadSeedDataset(*file, *version)
+	if err != nil {
+		return err
+	}
+	if err := validateSeedDataset(dataset); err != nil {
+		return err
+	}
+	log.Printf("Loaded dataset version %d with %d exercises", dataset.Version, len(dataset.Exercises))
+
+	deps, err := newDependencies()
+	if err != nil {
+		return err
+	}
+	defer deps.db.Close()
+
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	tx, err := deps.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		created, updated, unchanged, skipped int
+		changedIDs                           []uuid.UUID
+	)
+	now := time.Now()
+	for idx := range dataset.Exercises {
+		entry := &dataset.Exercises[idx]
+		ex, err := findSeedExercise(ctx, tx, sq, entry)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case ex == nil:
+			id := uuid.New()
+			values := entry.columns()
+			values["id"] = id
+			values["created_at"] = now
+			values["updated_at"] = now
+			query, queryArgs, err := sq.Insert("exercises").SetMap(values).ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build insert for %s: %w", entry.Slug, err)
+			}
+			if _, err := tx.ExecContext(ctx, query, queryArgs...); err != nil {
+				return fmt.Errorf("failed to create %s: %w", entry.Slug, err)
+			}
+			if _, err := addSeedAliases(ctx, tx, sq, id, entry.Aliases, now); err != nil {
+				return err
+			}
+			log.Printf("Created %s (%s)", entry.Slug, entry.Name)
+			created++
+			changedIDs = append(changedIDs, id)
+
+		case ex.DeletedAt != nil:
+			// Deleted or merged into another exercise by an admin; seeding doesn't bring it back.
+			log.Printf("Skipped %s: exercise %s is deleted", entry.Slug, ex.ID)
+			skipped++
+
+		default:
+			fieldsChanged := !entry.matches(ex)
+			if fieldsChanged {
+				values := entry.columns()
+				values["updated_at"] = now
+				query, queryArgs, err := sq.Update("exercises").SetMap(values).Where(squirrel.Eq{"id": ex.ID}).ToSql()
+				if err != nil {
+					return fmt.Errorf("failed to build update for %s: %w", entry.Slug, err)
+				}
+				if _, err := tx.ExecContext(ctx, query, queryArgs...); err != nil {
+					return fmt.Errorf("failed to update %s: %w", entry.Slug, err)
+				}
+			}
+			aliasesAdded, err := addSeedAliases(ctx, tx, sq, ex.ID, entry.Aliases, now)
+			if err != nil {
+				return err
+			}
+			if !fieldsChanged && aliasesAdded == 0 {
+				unchanged++
+				continue
+			}
+			log.Printf("Updated %s (%s)", entry.Slug, entry.Name)
+			updated++
+			changedIDs = append(changedIDs, ex.ID)
+		}
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, sq, model.SearchEntityExercise, model.SearchOperationUpsert, changedIDs...); err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%d created, %d updated, %d unchanged, %d skipped", created, updated, unchanged, skipped)
+	if *dryRun {
+		log.Printf("Dry run, nothing written: %s", summary)
+		return nil
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	log.Printf("Seed complete: %s", summary)
+
+	if *noSync || len(changedIDs) == 0 {
+		return nil
+	}
+	processed, err := drainSearchOutbox(ctx, deps)
+	if err != nil {
+		return err
+	}
+	log.Printf("Search outbox drained: %d entries processed", processed)
+	return nil
+}
+
+// loadSeedDataset reads the dataset at path, or the built-in dataset when path is empty.
+func loadSeedDataset(path string, csvVersion int) (*seedDataset, error) {
+	var (
+		r   io.ReadCloser
+		err error
+	)
+	if path == "" {
+		path = defaultSeedFile
+		r, err = seedFiles.Open(path)
+	} else {
+		r, err = os.Open(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer r.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var dataset seedDataset
+		if err := json.NewDecoder(r).Decode(&dataset); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return &dataset, nil
+	case ".csv":
+		exercises, err := parseSeedCSV(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return &seedDataset{Version: csvVersion, Exercises: exercises}, nil
+	default:
+		return nil, fmt.Errorf("unsupported dataset format %q, use .json or .csv", filepath.Ext(path))
+	}
+}
+
+// parseSeedCSV reads a CSV dataset with a seedCSVColumns header. Columns may be in any order
+// and only slug and name are required; empty cells are left unset.
+func parseSeedCSV(r io.Reader) ([]seedExercise, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(seedCSVColumns, column) {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+		index[column] = i
+	}
+	for _, required := range []string{"slug", "name"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	var exercises []seedExercise
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		cell := func(column string) string {
+			if i, ok := index[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		optional := func(column string) *string {
+			if value := cell(column); value != "" {
+				return &value
+			}
+			return nil
+		}
+		entry := seedExercise{
+			Slug:         cell("slug"),
+			Name:         cell("name"),
+			Description:  optional("description"),
+			Position:     optional("position"),
+			ForceType:    optional("force_type"),
+			Difficulty:   optional("difficulty"),
+			MovementType: optional("movement_type"),
+			MuscleGroup:  optional("muscle_group"),
+			Equipment:    optional("equipment"),
+			Bodypart:     optional("bodypart"),
+			TrackingType: optional("tracking_type"),
+		}
+		for _, alias := range strings.Split(cell("aliases"), "|") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				entry.Aliases = append(entry.Aliases, alias)
+			}
+		}
+		exercises = append(exercises, entry)
+	}
+	return exercises, nil
+}
+
+// validateSeedDataset normalizes the entries and reports every invalid one at once, so a
+// dataset is either loaded completely or not at all.
+func validateSeedDataset(dataset *seedDataset) error {
+	if dataset.Version < 1 {
+		return fmt.Errorf("dataset version must be positive (set \"version\" in JSON or --version for CSV)")
+	}
+	if len(dataset.Exercises) == 0 {
+		return fmt.Errorf("dataset has no exercises")
+	}
+
+	var problems []string
+	slugs := make(map[string]bool, len(dataset.Exercises))
+	names := make(map[string]bool, len(dataset.Exercises))
+	for idx := range dataset.Exercises {
+		entry := &dataset.Exercises[idx]
+		entry.Slug = strings.TrimSpace(entry.Slug)
+		entry.Name = strings.TrimSpace(entry.Name)
+		label := fmt.Sprintf("exercise #%d (%s)", idx+1, entry.Slug)
+
+		switch {
+		case !seedSlugPattern.MatchString(entry.Slug):
+			problems = append(problems, fmt.Sprintf("%s: slug must be lowercase words separated by dashes", label))
+		case slugs[entry.Slug]:
+			problems = append(problems, fmt.Sprintf("%s: duplicate slug", label))
+		}
+		slugs[entry.Slug] = true
+
+		switch {
+		case entry.Name == "" || len(entry.Name) > 255:
+			problems = append(problems, fmt.Sprintf("%s: name is required and at most 255 characters", label))
+		case names[entry.Name]:
+			problems = append(problems, fmt.Sprintf("%s: duplicate name %q", label, entry.Name))
+		}
+		names[entry.Name] = true
+
+		vocabulary := []struct {
+			column string
+			field  **string
+		}{
+			{"position", &entry.Position},
+			{"force_type", &entry.ForceType},
+			{"difficulty", &entry.Difficulty},
+			{"movement_type", &entry.MovementType},
+			{"muscle_group", &entry.MuscleGroup},
+			{"equipment", &entry.Equipment},
+			{"bodypart", &entry.Bodypart},
+			{"tracking_type", &entry.TrackingType},
+		}
+		for _, v := range vocabulary {
+			column, field := v.column, v.field
+			if *field == nil {
+				continue
+			}
+			value := strings.TrimSpace(**field)
+			if value == "" {
+				*field = nil
+				continue
+			}
+			if !model.IsExerciseVocabularyValue(column, value) {
+				problems = append(problems, fmt.Sprintf("%s: invalid %s %q", label, column, value))
+			}
+			*field = &value
+		}
+		if entry.Description != nil && strings.TrimSpace(*entry.Description) == "" {
+			entry.Description = nil
+		}
+
+		aliases := make([]string, 0, len(entry.Aliases))
+		for _, alias := range entry.Aliases {
+			alias = strings.TrimSpace(alias)
+			if alias == "" || strings.EqualFold(alias, entry.Name) {
+				continue
+			}
+			if len(alias) > 255 {
+				problems = append(problems, fmt.Sprintf("%s: alias %q is longer than 255 characters", label, alias))
+				continue
+			}
+			aliases = append(aliases, alias)
+		}
+		entry.Aliases = aliases
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid dataset:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// findSeedExercise returns the exercise with the entry's slug, or else the live global
+// exercise without a slug that has the entry's name. It returns nil when neither exists.
+func findSeedExercise(ctx context.Context, tx *sql.Tx, sq squirrel.StatementBuilderType, entry *seedExercise) (*model.Exercise, error) {
+	conditions := []squirrel.Sqlizer{
+		squirrel.Eq{"slug": entry.Slug},
+		squirrel.Eq{"slug": nil, "owner_id": nil, "deleted_at": nil, "name": entry.Name},
+	}
+	for _, condition := range conditions {
+		query, args, err := sq.Select(provider.ExerciseColumns...).
+			From("exercises").
+			Where(condition).
+			Suffix("FOR UPDATE").
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build lookup for %s: %w", entry.Slug, err)
+		}
+		ex, err := provider.ScanExercise(tx.QueryRowContext(ctx, query, args...))
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up %s: %w", entry.Slug, err)
+		}
+		return &ex, nil
+	}
+	return nil, nil
+}
+
+// addSeedAliases adds the aliases the exercise doesn't have yet and returns how many were added.
+func addSeedAliases(ctx context.Context, tx *sql.Tx, sq squirrel.StatementBuilderType, exerciseID uuid.UUID, aliases []string, now time.Time) (int, error) {
+	added := 0
+	for _, alias := range aliases {
+		query, args, err := sq.Insert("exercise_aliases").
+			Columns("id", "exercise_id", "alias", "created_at", "updated_at").
+			Values(uuid.New(), exerciseID, alias, now, now).
+			Suffix("ON CONFLICT DO NOTHING").
+			ToSql()
+		if err != nil {
+			return 0, fmt.Errorf("failed to build alias insert: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to add alias %q: %w", alias, err)
+		}
+		if rows, _ := res.RowsAffected(); rows > 0 {
+			added++
+		}
+	}
+	return added, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// setAdmin grants (or with --revoke, removes) admin access for the user with the given email.
+func setAdmin(ctx context.Context, args []string) error {
+	fs := flagSet("set-admin", setAdminUsage)
+	email := fs.String("email", "", "Email of the user (required)")
+	revoke := fs.Bool("revoke", false, "Remove admin access instead of granting it")
+	fs.Parse(args)
+
+	if strings.TrimSpace(*email) == "" {
+		fs.Usage()
+		return fmt.Errorf("--email is required")
+	}
+
+	deps, err := newDependencies()
+	if err != nil {
+		return err
+	}
+	defer deps.db.Close()
+
+	query, queryArgs, err := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).
+		Update("users").
+		Set("is_admin", !*revoke).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"email": strings.TrimSpace(*email), "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+	res, err := deps.db.ExecContext(ctx, query, queryArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("no user with email %q", *email)
+	}
+
+	log.Printf("Set is_admin=%t for %s", !*revoke, *email)
+	return nil
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TypesenseConfig holds the Typesense connection settings.
+type TypesenseConfig struct {
+	APIKey string
+
+	// Nodes are the base URLs of the cluster nodes, e.g. "http://typesense:8108". Requests are
+	// spread over them and retried on the next node when one fails.
+	Nodes []string
+
+	ConnectionTimeout time.Duration // Per attempt; each retry on another node gets its own timeout
+	NumRetries        int
+	RetryInterval     time.Duration
+	// HealthCheckInterval is how long a node marked unhealthy is skipped, and how long the
+	// exercise search trusts a Typesense health check before falling back to Postgres.
+	HealthCheckInterval time.Duration
+
+	// The circuit breaker around exercise searches opens after BreakerFailures consecutive
+	// failures and lets a trial request through after BreakerTimeout.
+	BreakerFailures uint32
+	BreakerTimeout  time.Duration
+}
+
+// LoadTypesenseConfig loads the Typesense settings from environment variables:
+//
+//	TYPESENSE_API_KEY
+//	TYPESENSE_NODES                 comma separated hosts, host:port pairs or URLs; overrides TYPESENSE_HOST
+//	TYPESENSE_HOST                  single node host
+//	TYPESENSE_PROTOCOL              http (default) or https, for nodes given without a scheme
+//	TYPESENSE_PORT                  default 80, for nodes given without a port
+//	TYPESENSE_CONNECTION_TIMEOUT    default 5s
+//	TYPESENSE_NUM_RETRIES           default 3
+//	TYPESENSE_RETRY_INTERVAL        default 100ms
+//	TYPESENSE_HEALTHCHECK_INTERVAL  default 15s
+//	TYPESENSE_BREAKER_FAILURES      default 5
+//	TYPESENSE_BREAKER_TIMEOUT       default 30s
+//
+// Durations use Go syntax, e.g. "500ms" or "1m".
+func LoadTypesenseConfig() (*TypesenseConfig, error) {
+	protocol := strings.ToLower(getEnvString("TYPESENSE_PROTOCOL", "http"))
+	if protocol != "http" && protocol != "https" {
+		return nil, fmt.Errorf("TYPESENSE_PROTOCOL must be http or https, got %q", protocol)
+	}
+	port, err := getEnvInt("TYPESENSE_PORT", 80)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := os.Getenv("TYPESENSE_NODES")
+	if hosts == "" {
+		hosts = os.Getenv("TYPESENSE_HOST")
+	}
+	cfg := &TypesenseConfig{APIKey: os.Getenv("TYPESENSE_API_KEY")}
+	for _, node := range strings.Split(hosts, ",") {
+		node = strings.TrimSpace(node)
+		if node == "" {
+			continue
+		}
+		if !strings.Contains(node, "://") {
+			node = protocol + "://" + node
+		}
+		// A colon after the scheme means the node brings its own port.
+		if !strings.Contains(node[strings.Index(node, "://")+3:], ":") {
+			node = node + ":" + strconv.Itoa(port)
+		}
+		cfg.Nodes = append(cfg.Nodes, strings.TrimRight(node, "/"))
+	}
+
+	if cfg.ConnectionTimeout, err = getEnvDuration("TYPESENSE_CONNECTION_TIMEOUT", 5*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.NumRetries, err = getEnvInt("TYPESENSE_NUM_RETRIES", 3); err != nil {
+		return nil, err
+	}
+	if cfg.RetryInterval, err = getEnvDuration("TYPESENSE_RETRY_INTERVAL", 100*time.Millisecond); err != nil {
+		return nil, err
+	}
+	if cfg.HealthCheckInterval, err = getEnvDuration("TYPESENSE_HEALTHCHECK_INTERVAL", 15*time.Second); err != nil {
+		return nil, err
+	}
+	failures, err := getEnvInt("TYPESENSE_BREAKER_FAILURES", 5)
+	if err != nil {
+		return nil, err
+	}
+	if failures < 1 {
+		return nil, fmt.Errorf("TYPESENSE_BREAKER_FAILURES must be positive, got %d", failures)
+	}
+	cfg.BreakerFailures = uint32(failures)
+	if cfg.BreakerTimeout, err = getEnvDuration("TYPESENSE_BREAKER_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func getEnvString(key, defaultValue string) string {
+	if s := os.Getenv(key); s != "" {
+		return s
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) (int, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return defaultValue, nil
+	}
+	val, err := strconv.Atoi(s)
+	if err != nil || val < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", key, s)
+	}
+	return val, nil
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	s := os.Getenv(key)
+	if s == "" {
+		return defaultValue, nil
+	}
+	val, err := time.ParseDuration(s)
+	if err != nil || val <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as 5s, got %q", key, s)
+	}
+	return val, nil
+}
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"time"
+
+	"rtglabs-go/model"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
@@ -14,5 +18,71 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 }
 
 func NewValidator() echo.Validator {
-	return &CustomValidator{validator: validator.New()}
+	v := validator.New()
+
+	// Custom tags used by the profile settings. Registration only fails on programmer error
+	// (duplicate/empty tag), so a panic at startup is the right outcome.
+	mustRegister(v, "iana_timezone", validateIANATimezone)
+	mustRegister(v, "birthdate", validateBirthdate)
+	mustRegister(v, "gender", validateGender)
+	mustRegister(v, "weekday", validateWeekday)
+	mustRegister(v, "locale", validateLocale)
+
+	// Exercise metadata: one tag per controlled vocabulary, e.g. "exercise_equipment".
+	for column := range model.ExerciseVocabularies {
+		mustRegister(v, "exercise_"+column, validateExerciseVocabulary(column))
+	}
+
+	return &CustomValidator{validator: v}
+}
+
+func mustRegister(v *validator.Validate, tag string, fn validator.Func) {
+	if err := v.RegisterValidation(tag, fn); err != nil {
+		panic(err)
+	}
+}
+
+// validateIANATimezone accepts IANA names such as "Europe/Berlin" or "UTC".
+// "Local" is rejected because it depends on the server's configuration.
+func validateIANATimezone(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// validateBirthdate accepts a YYYY-MM-DD date that yields a plausible age.
+func validateBirthdate(fl validator.FieldLevel) bool {
+	birthdate, err := time.Parse("2006-01-02", fl.Field().String())
+	if err != nil {
+		return false
+	}
+	age := (&model.Profile{Birthdate: &birthdate}).AgeAt(time.Now())
+	return age >= model.ProfileMinAgeYears && age <= model.ProfileMaxAgeYears
+}
+
+// validateGender accepts the values of the model.Gender enum.
+func validateGender(fl validator.FieldLevel) bool {
+	return model.Gender(fl.Field().Int()).IsValid()
+}
+
+// validateWeekday accepts 0 (Sunday) through 6 (Saturday), matching time.Weekday.
+func validateWeekday(fl validator.FieldLevel) bool {
+	day := fl.Field().Int()
+	return day >= int64(time.Sunday) && day <= int64(time.Saturday)
+}
+
+// validateLocale accepts one of model.SupportedLocales, or "" to clear a saved locale.
+func validateLocale(fl validator.FieldLevel) bool {
+	locale := fl.Field().String()
+	return locale == "" || model.IsSupportedLocale(locale)
+}
+
+// validateExerciseVocabulary returns a validator accepting the controlled vocabulary of an exercise column.
+func validateExerciseVocabulary(column string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return model.IsExerciseVocabularyValue(column, fl.Field().String())
+	}
 }
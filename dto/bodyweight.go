@@ -59,3 +59,33 @@ type ListBodyweightResponse struct {
 	Data                        []BodyweightResponse `json:"data"`
 	provider.PaginationResponse                      // Embed the common pagination fields
 }
+
+// --- Import DTOs ---
+
+// ImportBodyweightRow is a single parsed row of a bodyweight CSV import.
+// Weight is already converted to the user's profile unit.
+type ImportBodyweightRow struct {
+	Row    int       `json:"row"` // 1-based line number in the CSV, header included
+	Date   string    `json:"date"`
+	Weight float64   `json:"weight"`
+	At     time.Time `json:"-"`
+}
+
+// ImportBodyweightRowError describes why a CSV row was rejected.
+type ImportBodyweightRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportBodyweightResponse is returned for both dry-run previews and real imports.
+type ImportBodyweightResponse struct {
+	Message           string                     `json:"message"`
+	DryRun            bool                       `json:"dry_run"`
+	TotalRows         int                        `json:"total_rows"`
+	ValidRows         int                        `json:"valid_rows"`
+	Imported          int                        `json:"imported"`
+	SkippedDuplicates int                        `json:"skipped_duplicates"` // Same day appears more than once in the file; the last row wins
+	SkippedExisting   int                        `json:"skipped_existing"`   // A bodyweight is already logged for that day
+	Errors            []ImportBodyweightRowError `json:"errors"`
+	Rows              []ImportBodyweightRow      `json:"rows"` // Rows that are (or would be) inserted
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BodyweightGoalDateLayout is the layout used for goal dates in requests and responses.
+const BodyweightGoalDateLayout = "2006-01-02"
+
+// --- Request DTOs ---
+
+// UpsertBodyweightGoalRequest defines the request body for setting (or replacing) the user's bodyweight goal.
+// StartWeight is optional; when omitted the latest logged bodyweight is used.
+type UpsertBodyweightGoalRequest struct {
+	TargetWeight float64  `json:"target_weight" validate:"required,gt=0"`
+	TargetDate   string   `json:"target_date" validate:"required,datetime=2006-01-02"`
+	WeeklyRate   *float64 `json:"weekly_rate,omitempty" validate:"omitempty,gt=0"`
+	StartWeight  *float64 `json:"start_weight,omitempty" validate:"omitempty,gt=0"`
+}
+
+// --- Response DTOs ---
+
+// BodyweightGoalProgress holds the values computed from the user's recent bodyweights.
+type BodyweightGoalProgress struct {
+	CurrentWeight     *float64   `json:"current_weight"`       // Latest logged bodyweight, nil if none
+	RemainingWeight   float64    `json:"remaining_weight"`     // Absolute distance left to the target
+	ProgressPercent   float64    `json:"progress_percent"`     // 0-100, share of start->target covered
+	TrendPerWeek      *float64   `json:"trend_per_week"`       // Signed weekly change from the regression, nil if not enough data
+	RequiredPerWeek   *float64   `json:"required_per_week"`    // Signed weekly change needed to hit the target date
+	ProjectedDate     *time.Time `json:"projected_date"`       // When the target is reached at the current trend
+	PlannedDate       *time.Time `json:"planned_date"`         // When the target is reached at the planned weekly rate
+	OnTrack           bool       `json:"on_track"`             // Projected date is on or before the target date
+	DataPointsInTrend int        `json:"data_points_in_trend"` // Number of bodyweights used for the trend
+	TrendWindowInDays int        `json:"trend_window_in_days"`
+}
+
+// BodyweightGoalResponse is the DTO for the user's active bodyweight goal with its progress.
+type BodyweightGoalResponse struct {
+	ID           uuid.UUID              `json:"id"`
+	UserID       uuid.UUID              `json:"user_id"`
+	StartWeight  float64                `json:"start_weight"`
+	TargetWeight float64                `json:"target_weight"`
+	TargetDate   string                 `json:"target_date"`
+	WeeklyRate   *float64               `json:"weekly_rate"`
+	ReachedAt    *time.Time             `json:"reached_at"`
+	Progress     BodyweightGoalProgress `json:"progress"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// UpsertBodyweightGoalResponse defines the structure for a successful goal create/replace response.
+type UpsertBodyweightGoalResponse struct {
+	Message string                 `json:"message"`
+	Goal    BodyweightGoalResponse `json:"goal"`
+}
+
+// DeleteBodyweightGoalResponse defines the structure for a successful goal deletion response.
+type DeleteBodyweightGoalResponse struct {
+	Message string `json:"message"`
+}
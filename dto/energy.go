@@ -0,0 +1,69 @@
+package dto
+
+import "rtglabs-go/model"
+
+// EnergyEstimateRequest holds the optional query parameters for the energy estimate.
+type EnergyEstimateRequest struct {
+	// AvgDailyCalories is the user's average daily intake over the trend window. The trend
+	// refinement is opt-in: only when it is given is the observed bodyweight trend used to
+	// back-calculate the real-world TDEE, since the trend alone can't tell how much was eaten.
+	// Without it the response reports EnergyRefinementNeedsIntake and the implied intake.
+	AvgDailyCalories *float64 `query:"avg_daily_calories" validate:"omitempty,gt=0,lte=15000"`
+}
+
+// EnergyInputs are the profile values the estimate is based on, converted to metric.
+type EnergyInputs struct {
+	WeightKg float64      `json:"weight_kg"`
+	HeightCm float64      `json:"height_cm"`
+	Age      int          `json:"age"`
+	Gender   model.Gender `json:"gender"`
+}
+
+// EnergyActivity describes how the activity factor was derived from logged workouts.
+type EnergyActivity struct {
+	Factor            float64 `json:"factor"`
+	WindowDays        int     `json:"window_days"`
+	Sessions          int     `json:"sessions"`
+	SessionsPerWeek   float64 `json:"sessions_per_week"`
+	AvgSessionMinutes float64 `json:"avg_session_minutes"`
+	VolumeKg          float64 `json:"volume_kg"` // Total weight x reps of completed sets in the window
+}
+
+// Values of EnergyRefinement.Status.
+const (
+	EnergyRefinementApplied          = "applied"           // TDEE blends the formula with the observed TDEE
+	EnergyRefinementNeedsIntake      = "needs_intake"      // Trend is usable; send avg_daily_calories to apply it
+	EnergyRefinementInsufficientData = "insufficient_data" // Not enough bodyweight data for a trend
+)
+
+// EnergyRefinement describes the adjustment made from the observed bodyweight trend.
+type EnergyRefinement struct {
+	Status               string   `json:"status"` // One of the EnergyRefinement* values
+	Applied              bool     `json:"applied"`
+	WindowDays           int      `json:"window_days"`
+	DataPoints           int      `json:"data_points"`
+	TrendKgPerWeek       *float64 `json:"trend_kg_per_week"`
+	AvgDailyCalories     *float64 `json:"avg_daily_calories"`
+	ObservedTDEE         *float64 `json:"observed_tdee"`          // Intake minus the energy stored/lost as bodyweight
+	ImpliedDailyCalories *float64 `json:"implied_daily_calories"` // Intake implied by the trend when none was given
+	Weight               float64  `json:"weight"`                 // Share of the observed TDEE in the final estimate (0-1)
+}
+
+// EnergyTargets are daily calorie targets in kcal.
+type EnergyTargets struct {
+	Cut      float64  `json:"cut"`
+	Maintain float64  `json:"maintain"`
+	Bulk     float64  `json:"bulk"`
+	Goal     *float64 `json:"goal"` // Matches the weekly rate of the active bodyweight goal, if any
+}
+
+// EnergyEstimateResponse is the response body for the energy estimate.
+type EnergyEstimateResponse struct {
+	Inputs      EnergyInputs     `json:"inputs"`
+	BMR         float64          `json:"bmr"`
+	Activity    EnergyActivity   `json:"activity"`
+	FormulaTDEE float64          `json:"formula_tdee"` // BMR x activity factor
+	TDEE        float64          `json:"tdee"`         // Formula TDEE refined by the bodyweight trend
+	Refinement  EnergyRefinement `json:"refinement"`
+	Targets     EnergyTargets    `json:"targets"`
+}
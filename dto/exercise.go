@@ -15,6 +15,9 @@ import (
 type ExerciseResponse struct {
 	ID           uuid.UUID  `json:"id"`
 	Name         string     `json:"name"`
+	Slug         *string    `json:"slug"`          // Stable identifier of seeded catalog exercises
+	OwnerID      *uuid.UUID `json:"owner_id"`      // Set for custom exercises, null for the global catalog
+	IsCustom     bool       `json:"is_custom"`     // Visible only to its owner
 	Description  string     `json:"description"`   // <--- ADD THIS
 	Position     string     `json:"position"`      // <--- ADD THIS
 	ForceType    string     `json:"force_type"`    // <--- ADD THIS
@@ -23,25 +26,60 @@ type ExerciseResponse struct {
 	MuscleGroup  string     `json:"muscle_group"`  // <--- ADD THIS
 	Equipment    string     `json:"equipment"`     // <--- ADD THIS
 	Bodypart     string     `json:"bodypart"`      // <--- ADD THIS
+	TrackingType string     `json:"tracking_type"` // Which values its sets record, e.g. weight_reps or duration
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
 	DeletedAt    *time.Time `json:"deleted_at"`
+	Aliases      []string   `json:"aliases,omitempty"`       // Alternative names, e.g. "RDL"
+	MatchedAlias string     `json:"matched_alias,omitempty"` // Search results: the alias that matched the query
+	// Highlights maps name/description to their text with the terms matching the search query
+	// wrapped in <mark> tags. Only present in search results.
+	Highlights map[string]string `json:"highlights,omitempty"`
+	// Muscles lists the muscles the exercise works, primary first, with the share of its
+	// volume attributed to each.
+	Muscles []ExerciseMuscleResponse `json:"muscles,omitempty"`
+	// Note is the user's own note on the exercise. Present in workouts, workout logs and the
+	// exercise page once the user wrote one.
+	Note *ExerciseNoteResponse `json:"note,omitempty"`
+}
+
+// ExerciseFacetCountResponse is the number of exercises matching the search with a facet value.
+type ExerciseFacetCountResponse struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// ExerciseFacetResponse lists the value counts of a filterable field, most frequent first.
+type ExerciseFacetResponse struct {
+	Field  string                       `json:"field"`
+	Counts []ExerciseFacetCountResponse `json:"counts"`
 }
 
 // ListExerciseResponse represents the paginated list response for exercises.
 type ListExerciseResponse struct {
-	Data []ExerciseResponse `json:"data"`
+	Data   []ExerciseResponse      `json:"data"`
+	Facets []ExerciseFacetResponse `json:"facets"`
 	provider.PaginationResponse
 }
 
 // CreateExerciseRequest defines the request for creating multiple exercises.
 type CreateExerciseRequest struct {
-	Exercises []ExerciseNameOnly `json:"exercises" validate:"required,dive"`
+	Exercises []CreateExerciseItem `json:"exercises" validate:"required,min=1,dive"`
 }
 
-// ExerciseNameOnly is a helper struct for creating exercises by name.
-type ExerciseNameOnly struct {
-	Name string `json:"name" validate:"required"`
+// CreateExerciseItem is a single exercise to create. Apart from the name every field is
+// optional; the vocabulary-controlled ones must use the values listed in model/exercise.go.
+type CreateExerciseItem struct {
+	Name         string  `json:"name" validate:"required,max=255"`
+	Description  *string `json:"description" validate:"omitempty,max=5000"`
+	Position     *string `json:"position" validate:"omitempty,exercise_position"`
+	ForceType    *string `json:"force_type" validate:"omitempty,exercise_force_type"`
+	Difficulty   *string `json:"difficulty" validate:"omitempty,exercise_difficulty"`
+	MovementType *string `json:"movement_type" validate:"omitempty,exercise_movement_type"`
+	MuscleGroup  *string `json:"muscle_group" validate:"omitempty,exercise_muscle_group"`
+	Equipment    *string `json:"equipment" validate:"omitempty,exercise_equipment"`
+	Bodypart     *string `json:"bodypart" validate:"omitempty,exercise_bodypart"`
+	TrackingType *string `json:"tracking_type" validate:"omitempty,exercise_tracking_type"` // Defaults to weight_reps
 }
 
 // CreateExerciseResponse is the response for a successful exercise creation.
@@ -49,3 +87,172 @@ type CreateExerciseResponse struct {
 	Message   string             `json:"message"`
 	Exercises []ExerciseResponse `json:"exercises"`
 }
+
+// PromoteExerciseResponse is the response for promoting a custom exercise to the global catalog.
+type PromoteExerciseResponse struct {
+	Message  string           `json:"message"`
+	Exercise ExerciseResponse `json:"exercise"`
+}
+
+// UpdateExerciseRequest replaces the editable fields of an exercise. It has the same fields and
+// rules as CreateExerciseItem; omitted optional fields are cleared, except the tracking type
+// which can't be unset.
+type UpdateExerciseRequest struct {
+	Name         string  `json:"name" validate:"required,max=255"`
+	Description  *string `json:"description" validate:"omitempty,max=5000"`
+	Position     *string `json:"position" validate:"omitempty,exercise_position"`
+	ForceType    *string `json:"force_type" validate:"omitempty,exercise_force_type"`
+	Difficulty   *string `json:"difficulty" validate:"omitempty,exercise_difficulty"`
+	MovementType *string `json:"movement_type" validate:"omitempty,exercise_movement_type"`
+	MuscleGroup  *string `json:"muscle_group" validate:"omitempty,exercise_muscle_group"`
+	Equipment    *string `json:"equipment" validate:"omitempty,exercise_equipment"`
+	Bodypart     *string `json:"bodypart" validate:"omitempty,exercise_bodypart"`
+	TrackingType *string `json:"tracking_type" validate:"omitempty,exercise_tracking_type"` // Unchanged when omitted; locked once the exercise is in use
+}
+
+// ExerciseUsageResponse summarizes how the authenticated user has used an exercise.
+// Only completed sets of the user's own workout logs are counted.
+type ExerciseUsageResponse struct {
+	WorkoutCount    int        `json:"workout_count"` // Workout templates containing the exercise
+	SessionCount    int        `json:"session_count"` // Workout logs with at least one completed set
+	TotalSets       int        `json:"total_sets"`
+	TotalReps       int        `json:"total_reps"`
+	TotalVolume     float64    `json:"total_volume"`      // Sum of weight × reps
+	MaxWeight       *float64   `json:"max_weight"`        // null when no weighted set was logged
+	LastPerformedAt *time.Time `json:"last_performed_at"` // null when never logged
+}
+
+// ExerciseDetailResponse is a single exercise together with its instructions, media and the
+// user's usage stats.
+type ExerciseDetailResponse struct {
+	ExerciseResponse
+	Instructions ExerciseInstructionsResponse `json:"instructions"`
+	Media        []ExerciseMediaResponse      `json:"media"`
+	Usage        ExerciseUsageResponse        `json:"usage"`
+}
+
+// ExerciseInstructionsResponse explains how to perform an exercise. The lists are empty when
+// no instructions were written yet.
+type ExerciseInstructionsResponse struct {
+	Steps          []string   `json:"steps"`
+	FormCues       []string   `json:"form_cues"`
+	CommonMistakes []string   `json:"common_mistakes"`
+	UpdatedAt      *time.Time `json:"updated_at"` // null when no instructions were written yet
+}
+
+// UpdateExerciseInstructionsRequest replaces the instructions of an exercise. Omitted lists are cleared.
+type UpdateExerciseInstructionsRequest struct {
+	Steps          []string `json:"steps" validate:"max=30,dive,required,max=1000"`
+	FormCues       []string `json:"form_cues" validate:"max=20,dive,required,max=500"`
+	CommonMistakes []string `json:"common_mistakes" validate:"max=20,dive,required,max=500"`
+}
+
+// ExerciseMediaResponse is an image, GIF or video of an exercise.
+type ExerciseMediaResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Kind        string    `json:"kind"` // image, gif or video
+	URL         string    `json:"url"`
+	ContentType *string   `json:"content_type"` // Set for uploaded files
+	Caption     *string   `json:"caption"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateExerciseMediaRequest attaches an external media URL, e.g. a YouTube video, to an
+// exercise. Files are uploaded as multipart form data instead (see StoreExerciseMedia).
+type CreateExerciseMediaRequest struct {
+	Kind     string  `json:"kind" validate:"required,oneof=image gif video"`
+	URL      string  `json:"url" validate:"required,http_url,max=2048"`
+	Caption  *string `json:"caption" validate:"omitempty,max=255"`
+	Position *int    `json:"position" validate:"omitempty,min=0"` // Defaults to after the existing media
+}
+
+// DeleteExerciseResponse is the response for a successful exercise deletion.
+type DeleteExerciseResponse struct {
+	Message string `json:"message"`
+}
+
+// CreateExerciseAliasRequest adds an alias to an exercise.
+type CreateExerciseAliasRequest struct {
+	Alias string `json:"alias" validate:"required,max=255"`
+}
+
+// ExerciseAliasResponse is a single exercise alias.
+type ExerciseAliasResponse struct {
+	ID         uuid.UUID `json:"id"`
+	ExerciseID uuid.UUID `json:"exercise_id"`
+	Alias      string    `json:"alias"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ListExerciseAliasResponse lists the aliases of an exercise.
+type ListExerciseAliasResponse struct {
+	Data []ExerciseAliasResponse `json:"data"`
+}
+
+// ExerciseAlternativeResponse is a suggested replacement for an exercise.
+type ExerciseAlternativeResponse struct {
+	Exercise    ExerciseResponse `json:"exercise"`
+	Score       int              `json:"score"`
+	Reasons     []string         `json:"reasons"`      // e.g. same_muscle_group, logged_before
+	TimesLogged int              `json:"times_logged"` // Workout logs of the user containing the exercise
+}
+
+// ListExerciseAlternativeResponse lists the alternatives of an exercise, best first.
+type ListExerciseAlternativeResponse struct {
+	Exercise ExerciseResponse              `json:"exercise"` // The exercise being replaced
+	Data     []ExerciseAlternativeResponse `json:"data"`
+}
+
+// MergeExerciseRequest merges the exercise in the URL into another one.
+type MergeExerciseRequest struct {
+	IntoID uuid.UUID `json:"into_id" validate:"required"` // The exercise that is kept
+}
+
+// MergeExerciseResponse reports the kept exercise and how many history rows were re-pointed.
+type MergeExerciseResponse struct {
+	Message  string           `json:"message"`
+	Exercise ExerciseResponse `json:"exercise"`
+	Moved    map[string]int64 `json:"moved"` // Table name -> re-pointed rows
+}
+
+// ExerciseTranslationResponse holds the texts of an exercise in one locale.
+type ExerciseTranslationResponse struct {
+	Locale         string    `json:"locale"`
+	Name           string    `json:"name"`
+	Description    *string   `json:"description"`
+	Steps          []string  `json:"steps"`
+	FormCues       []string  `json:"form_cues"`
+	CommonMistakes []string  `json:"common_mistakes"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ListExerciseTranslationResponse lists the translations of an exercise, ordered by locale.
+type ListExerciseTranslationResponse struct {
+	Data []ExerciseTranslationResponse `json:"data"`
+}
+
+// UpsertExerciseTranslationRequest creates or replaces the translation of an exercise to the
+// locale in the URL. Omitted texts fall back to the default locale.
+type UpsertExerciseTranslationRequest struct {
+	Name           string   `json:"name" validate:"required,max=255"`
+	Description    *string  `json:"description" validate:"omitempty,max=5000"`
+	Steps          []string `json:"steps" validate:"max=30,dive,required,max=1000"`
+	FormCues       []string `json:"form_cues" validate:"max=20,dive,required,max=500"`
+	CommonMistakes []string `json:"common_mistakes" validate:"max=20,dive,required,max=500"`
+}
+
+// ExerciseNoteResponse is a user's own note on an exercise.
+type ExerciseNoteResponse struct {
+	Note       string    `json:"note"`
+	PinnedCues []string  `json:"pinned_cues"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// UpdateExerciseNoteRequest creates or replaces the user's note on an exercise.
+type UpdateExerciseNoteRequest struct {
+	Note       string   `json:"note" validate:"max=5000"`
+	PinnedCues []string `json:"pinned_cues" validate:"max=5,dive,required,max=120"`
+}
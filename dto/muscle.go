@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MuscleResponse is a single entry of the muscle catalog.
+type MuscleResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	MuscleGroup *string   `json:"muscle_group"` // The exercise muscle_group the muscle belongs to
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListMuscleResponse lists the muscle catalog.
+type ListMuscleResponse struct {
+	Data []MuscleResponse `json:"data"`
+}
+
+// MuscleRequest creates or replaces a muscle of the catalog (admin only).
+type MuscleRequest struct {
+	Slug        string  `json:"slug" validate:"required,max=100"` // snake_case, e.g. "front_delts"
+	Name        string  `json:"name" validate:"required,max=255"`
+	MuscleGroup *string `json:"muscle_group" validate:"omitempty,exercise_muscle_group"`
+}
+
+// ExerciseMuscleResponse is a muscle worked by an exercise.
+type ExerciseMuscleResponse struct {
+	MuscleID    uuid.UUID `json:"muscle_id"`
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	MuscleGroup *string   `json:"muscle_group"`
+	Role        string    `json:"role"`   // primary or secondary
+	Weight      float64   `json:"weight"` // Share of the exercise's volume attributed to the muscle
+}
+
+// UpdateExerciseMusclesRequest replaces the muscle mapping of an exercise (admin only).
+// An empty list removes the mapping.
+type UpdateExerciseMusclesRequest struct {
+	Muscles []ExerciseMuscleItem `json:"muscles" validate:"dive"`
+}
+
+// ExerciseMuscleItem maps one muscle to the exercise. Weight defaults to 1 for primary and
+// 0.5 for secondary muscles.
+type ExerciseMuscleItem struct {
+	MuscleID uuid.UUID `json:"muscle_id" validate:"required"`
+	Role     string    `json:"role" validate:"required,oneof=primary secondary"`
+	Weight   *float64  `json:"weight" validate:"omitempty,gt=0,lte=1"`
+}
+
+// ListExerciseMuscleResponse lists the muscles worked by an exercise.
+type ListExerciseMuscleResponse struct {
+	Data []ExerciseMuscleResponse `json:"data"`
+}
+
+// MuscleVolumeRequest holds the optional query parameters of the muscle volume breakdown.
+type MuscleVolumeRequest struct {
+	Days int `query:"days" validate:"omitempty,min=1,max=365"` // Defaults to 28
+}
+
+// MuscleVolumeItem is the volume attributed to one muscle.
+type MuscleVolumeItem struct {
+	Muscle      MuscleResponse `json:"muscle"`
+	Volume      float64        `json:"volume"`       // Weight × reps × the muscle's weight for each exercise
+	Sets        float64        `json:"sets"`         // Completed sets, a secondary muscle at 0.5 counting half
+	PrimarySets int            `json:"primary_sets"` // Completed sets working the muscle as a primary mover
+}
+
+// MuscleVolumeResponse breaks the user's recent training volume down per muscle.
+type MuscleVolumeResponse struct {
+	WindowDays int                `json:"window_days"`
+	Since      time.Time          `json:"since"`
+	Data       []MuscleVolumeItem `json:"data"` // Highest volume first
+}
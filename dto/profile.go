@@ -1,6 +1,15 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+
+	"rtglabs-go/model"
+
+	"github.com/google/uuid"
+)
+
+// ProfileDateLayout is the layout used for the birthdate in requests and responses.
+const ProfileDateLayout = "2006-01-02"
 
 // GetProfileResponse represents the response body for retrieving a user's profile.
 type GetProfileResponse struct {
@@ -12,24 +21,73 @@ type GetProfileResponse struct {
 
 // Adjusted ProfileResponse for consistency
 type ProfileResponse struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Units     int       `json:"units"`
-	Gender    int       `json:"gender"`
-	Age       int       `json:"age"`
-	Height    float64   `json:"height"` // Change back to float64 to match your request
-	Weight    float64   `json:"weight"` // Change back to float64 to match your request
-	CreatedAt string    `json:"created_at"`
-	UpdatedAt string    `json:"updated_at"`
+	ID                 uuid.UUID    `json:"id"`
+	UserID             uuid.UUID    `json:"user_id"`
+	Units              int          `json:"units"`
+	Gender             model.Gender `json:"gender"` // 0 = unspecified, 1 = male, 2 = female, 3 = other
+	Age                int          `json:"age"`    // Derived from birthdate when it is set
+	Height             float64      `json:"height"` // Change back to float64 to match your request
+	Weight             float64      `json:"weight"` // Change back to float64 to match your request
+	Birthdate          *string      `json:"birthdate"`
+	Timezone           string       `json:"timezone"`
+	WeekStartDay       int          `json:"week_start_day"` // 0 = Sunday ... 6 = Saturday
+	DefaultRestSeconds int          `json:"default_rest_seconds"`
+	AvatarURL          *string      `json:"avatar_url"`
+	Locale             *string      `json:"locale"` // One of model.SupportedLocales; null follows the device language
+	CreatedAt          string       `json:"created_at"`
+	UpdatedAt          string       `json:"updated_at"`
+
+	BodyweightGoal *BodyweightGoalResponse `json:"bodyweight_goal"` // Active goal with progress, nil if none set
 }
 
 // UpdateProfileRequest represents the request body for updating a profile.
+// The settings added after the initial version are pointers so clients that don't send them
+// keep their current values. Sending "birthdate": null clears the stored birthdate.
 type UpdateProfileRequest struct {
-	Units  int     `json:"units"`
-	Age    int     `json:"age"`
-	Height float64 `json:"height"`
-	Gender int     `json:"gender"`
-	Weight float64 `json:"weight"`
+	Units              int          `json:"units"`
+	Age                int          `json:"age"`
+	Height             float64      `json:"height"`
+	Gender             model.Gender `json:"gender" validate:"gender"`
+	Weight             float64      `json:"weight"`
+	Birthdate          *string      `json:"birthdate,omitempty" validate:"omitempty,birthdate"`
+	Timezone           *string      `json:"timezone,omitempty" validate:"omitempty,iana_timezone"`
+	WeekStartDay       *int         `json:"week_start_day,omitempty" validate:"omitempty,weekday"`
+	DefaultRestSeconds *int         `json:"default_rest_seconds,omitempty" validate:"omitempty,min=0,max=3600"`
+	Locale             *string      `json:"locale,omitempty" validate:"omitempty,locale"` // "" clears it
+
+	ClearBirthdate bool `json:"-"` // Set when birthdate was sent as an explicit null
+}
+
+// UnmarshalJSON decodes the request and tells an explicit "birthdate": null apart from an omitted birthdate.
+func (r *UpdateProfileRequest) UnmarshalJSON(data []byte) error {
+	type plain UpdateProfileRequest
+	var fields struct {
+		plain
+		Birthdate json.RawMessage `json:"birthdate"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*r = UpdateProfileRequest(fields.plain)
+	switch {
+	case len(fields.Birthdate) == 0:
+	case string(fields.Birthdate) == "null":
+		r.ClearBirthdate = true
+	default:
+		if err := json.Unmarshal(fields.Birthdate, &r.Birthdate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateAvatarResponse is returned after a successful avatar upload.
+// AvatarURL is the default (medium) size that is also stored on the profile.
+type UpdateAvatarResponse struct {
+	Message   string            `json:"message"`
+	AvatarURL string            `json:"avatar_url"`
+	Sizes     map[string]string `json:"sizes"` // e.g. {"small": ".../64.jpg", "medium": ".../256.jpg", "large": ".../512.jpg"}
 }
 
 // UpdateProfileResponse represents the response body for a successful profile update.
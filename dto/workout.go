@@ -22,6 +22,11 @@ type ExerciseInstanceResponse struct {
 type CreateWorkoutRequest struct {
 	Name      string                         `json:"name" validate:"required,max=255"`
 	Exercises []CreateWorkoutExerciseRequest `json:"exercises" validate:"required,min=1,dive"`
+
+	FolderID *uuid.UUID `json:"folder_id"` // The template is added at the end of the folder
+	Tags     []string   `json:"tags" validate:"max=20,dive,required,max=50"`
+
+	ExerciseGroups []ExerciseGroupRequest `json:"exercise_groups" validate:"max=50,dive"` // Referenced by the exercises' group_client_id
 }
 
 // CreateWorkoutExerciseRequest represents a single exercise within a workout template creation request.
@@ -32,7 +37,58 @@ type CreateWorkoutExerciseRequest struct {
 	Sets                     *uint      `json:"sets" validate:"omitempty,min=0"`
 	Weight                   *float64   `json:"weight" validate:"omitempty,min=0"`
 	Reps                     *uint      `json:"reps" validate:"omitempty,min=0"`
+	DurationSeconds          *uint      `json:"duration_seconds" validate:"omitempty,min=0"` // Target for duration tracked exercises
+	DistanceMeters           *float64   `json:"distance_meters" validate:"omitempty,min=0"`  // Target for distance tracked exercises
 	ExerciseInstanceClientID *string    `json:"exercise_instance_client_id"`
+
+	GroupClientID *string `json:"group_client_id"` // client_id of an exercise_groups entry, null for straight sets
+
+	// Per-set targets, in set order. When given, sets is their count and reps/weight are ignored when logging
+	SetPrescriptions []SetPrescriptionRequest `json:"set_prescriptions" validate:"max=30,dive"`
+}
+
+// SetPrescriptionRequest holds the targets of one set of a workout template exercise.
+type SetPrescriptionRequest struct {
+	SetType          string   `json:"set_type" validate:"required,oneof=warmup working backoff drop amrap failure"`
+	TargetReps       *int     `json:"target_reps" validate:"omitempty,min=0,max=1000"`
+	TargetRepsMax    *int     `json:"target_reps_max" validate:"omitempty,min=1,max=1000"` // With target_reps, a rep range
+	TargetWeight     *float64 `json:"target_weight" validate:"omitempty,min=0"`
+	TargetPercent1RM *float64 `json:"target_percent_1rm" validate:"omitempty,gt=0,max=150"` // Instead of target_weight
+	TargetRPE        *float64 `json:"target_rpe" validate:"omitempty,min=1,max=10"`
+	RestSeconds      *int     `json:"rest_seconds" validate:"omitempty,min=0,max=3600"`
+}
+
+// SetPrescriptionResponse holds the targets of one set of a workout template exercise.
+type SetPrescriptionResponse struct {
+	ID               uuid.UUID `json:"id"`
+	SetNumber        int       `json:"set_number"`
+	SetType          string    `json:"set_type"`
+	TargetReps       *int      `json:"target_reps"`
+	TargetRepsMax    *int      `json:"target_reps_max"`
+	TargetWeight     *float64  `json:"target_weight"`
+	TargetPercent1RM *float64  `json:"target_percent_1rm"`
+	TargetRPE        *float64  `json:"target_rpe"`
+	RestSeconds      *int      `json:"rest_seconds"`
+}
+
+// ExerciseGroupRequest declares a superset, giant set or circuit in a workout template request.
+// Exercises join it by setting their group_client_id to its client_id.
+type ExerciseGroupRequest struct {
+	ClientID                    string `json:"client_id" validate:"required,max=50"`
+	GroupType                   string `json:"group_type" validate:"required,oneof=superset giant_set circuit"`
+	Rounds                      int    `json:"rounds" validate:"omitempty,min=1,max=50"` // Defaults to 1
+	RestBetweenExercisesSeconds *int   `json:"rest_between_exercises_seconds" validate:"omitempty,min=0,max=3600"`
+	RestBetweenRoundsSeconds    *int   `json:"rest_between_rounds_seconds" validate:"omitempty,min=0,max=3600"`
+}
+
+// ExerciseGroupResponse is a superset, giant set or circuit of a workout template or workout log.
+// Its exercises reference it through their group_id.
+type ExerciseGroupResponse struct {
+	ID                          uuid.UUID `json:"id"`
+	GroupType                   string    `json:"group_type"`
+	Rounds                      int       `json:"rounds"`
+	RestBetweenExercisesSeconds *int      `json:"rest_between_exercises_seconds"`
+	RestBetweenRoundsSeconds    *int      `json:"rest_between_rounds_seconds"`
 }
 
 // WorkoutExerciseResponse represents a single exercise associated with a workout (pivot data).
@@ -46,11 +102,16 @@ type WorkoutExerciseResponse struct {
 	Sets               *uint                     `json:"sets"`                 // Removed ""
 	Weight             *float64                  `json:"weight"`               // Removed ""
 	Reps               *uint                     `json:"reps"`                 // Removed ""
+	DurationSeconds    *uint                     `json:"duration_seconds"`
+	DistanceMeters     *float64                  `json:"distance_meters"`
 	CreatedAt          time.Time                 `json:"created_at"`
 	UpdatedAt          time.Time                 `json:"updated_at"`
 	DeletedAt          *time.Time                `json:"deleted_at"`        // Removed ""
 	Exercise           *ExerciseResponse         `json:"exercise"`          // Removed ""
 	ExerciseInstance   *ExerciseInstanceResponse `json:"exercise_instance"` // Removed ""
+
+	GroupID          *uuid.UUID                `json:"group_id"` // null for straight sets
+	SetPrescriptions []SetPrescriptionResponse `json:"set_prescriptions"`
 }
 
 // WorkoutResponse represents the full workout details to be returned in a response.
@@ -63,6 +124,12 @@ type WorkoutResponse struct {
 	UpdatedAt        time.Time                 `json:"updated_at"`
 	DeletedAt        *time.Time                `json:"deleted_at"`        // Removed ""
 	WorkoutExercises []WorkoutExerciseResponse `json:"workout_exercises"` // Removed "" - For slice, an empty slice [] will be []
+
+	FolderID *uuid.UUID `json:"folder_id"` // null for templates at the top level
+	Position int        `json:"position"`  // Order within the folder
+	Tags     []string   `json:"tags"`
+
+	ExerciseGroups []ExerciseGroupResponse `json:"exercise_groups"` // In the order of their first exercise
 }
 
 // CreateWorkoutResponse is the response for a successful workout template creation.
@@ -86,6 +153,11 @@ type DeleteWorkoutResponse struct {
 type UpdateWorkoutRequest struct {
 	Name      string                         `json:"name" validate:"required,max=255"`
 	Exercises []UpdateWorkoutExerciseRequest `json:"exercises" validate:"required,dive"`
+
+	Tags []string `json:"tags" validate:"max=20,dive,required,max=50"` // Omitted keeps the current tags, [] clears them
+
+	// Omitted keeps the current groups (new exercises join none), otherwise replaces them all
+	ExerciseGroups []ExerciseGroupRequest `json:"exercise_groups" validate:"max=50,dive"`
 }
 
 // UpdateWorkoutExerciseRequest represents a single exercise within a workout template update request.
@@ -96,6 +168,47 @@ type UpdateWorkoutExerciseRequest struct {
 	Sets                     *uint      `json:"sets" validate:"omitempty,min=0"`
 	Weight                   *float64   `json:"weight" validate:"omitempty,min=0"`
 	Reps                     *uint      `json:"reps" validate:"omitempty,min=0"`
+	DurationSeconds          *uint      `json:"duration_seconds" validate:"omitempty,min=0"` // Target for duration tracked exercises
+	DistanceMeters           *float64   `json:"distance_meters" validate:"omitempty,min=0"`  // Target for distance tracked exercises
 	ExerciseInstanceID       *uuid.UUID `json:"exercise_instance_id" validate:"omitempty,uuid"`
 	ExerciseInstanceClientID *string    `json:"exercise_instance_client_id"`
+
+	GroupClientID *string `json:"group_client_id"` // client_id of an exercise_groups entry, null for straight sets
+
+	// Omitted keeps the current per-set targets, [] removes them, otherwise replaces them
+	SetPrescriptions []SetPrescriptionRequest `json:"set_prescriptions" validate:"max=30,dive"`
+}
+
+// WorkoutFolderResponse is a folder of workout templates.
+type WorkoutFolderResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	Position     int       `json:"position"`
+	WorkoutCount int       `json:"workout_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ListWorkoutFolderResponse lists the user's folders in display order.
+type ListWorkoutFolderResponse struct {
+	Data []WorkoutFolderResponse `json:"data"`
+}
+
+// CreateWorkoutFolderRequest creates a folder at the end of the user's folders.
+type CreateWorkoutFolderRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// UpdateWorkoutFolderRequest renames and/or reorders a folder.
+type UpdateWorkoutFolderRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Position *int   `json:"position" validate:"omitempty,min=0"`
+}
+
+// ArrangeWorkoutsRequest moves workout templates into a folder, or to the top level when
+// FolderID is null, in the given order. Templates already there keep their relative order
+// after the listed ones.
+type ArrangeWorkoutsRequest struct {
+	FolderID   *uuid.UUID  `json:"folder_id"`
+	WorkoutIDs []uuid.UUID `json:"workout_ids" validate:"required,min=1,max=200"`
 }
@@ -51,6 +51,8 @@ type UpdateExerciseSetRequest struct {
 	SetNumber                *int       `json:"set_number"`           // Nullable
 	Weight                   float64    `json:"weight"`               // Non-nullable (assuming `weight` in Zod is `z.number()`)
 	Reps                     *int       `json:"reps"`                 // Nullable
+	DurationSeconds          *int       `json:"duration_seconds"`     // Nullable, for duration tracked exercises
+	DistanceMeters           *float64   `json:"distance_meters"`      // Nullable, for distance tracked exercises
 	FinishedAt               *time.Time `json:"finished_at"`
 	Status                   *int       `json:"status"` // Nullable (matches Zod after adjustment)
 }
@@ -92,6 +94,8 @@ type WorkoutLogResponse struct {
 	UpdatedAt                  time.Time                   `json:"updated_at"`
 	DeletedAt                  *time.Time                  `json:"deleted_at"`
 	LoggedExerciseInstances    []LoggedExerciseInstanceLog `json:"logged_exercise_instances"`
+
+	ExerciseGroups []ExerciseGroupResponse `json:"exercise_groups"` // Copied from the template when the log was started
 }
 
 // LoggedExerciseInstanceLog defines the structure for an exercise instance within a workout log response.
@@ -105,6 +109,8 @@ type LoggedExerciseInstanceLog struct {
 	CreatedAt    time.Time             `json:"created_at"` // **Matches Zod**
 	UpdatedAt    time.Time             `json:"updated_at"` // **Matches Zod**
 	DeletedAt    *time.Time            `json:"deleted_at"` // **Matches Zod**
+
+	GroupID *uuid.UUID `json:"group_id"` // null for straight sets
 }
 
 // ExerciseSetResponse defines the structure for an individual exercise set in responses.
@@ -117,9 +123,17 @@ type ExerciseSetResponse struct {
 	SetNumber                *int       `json:"set_number"`
 	Weight                   float64    `json:"weight"`
 	Reps                     *int       `json:"reps"`
+	DurationSeconds          *int       `json:"duration_seconds"`
+	DistanceMeters           *float64   `json:"distance_meters"`
 	FinishedAt               *time.Time `json:"finished_at"`
 	Status                   int        `json:"status"` // **Matches Zod (non-nullable)**
 	CreatedAt                time.Time  `json:"created_at"`
 	UpdatedAt                time.Time  `json:"updated_at"`
 	DeletedAt                *time.Time `json:"deleted_at"`
+
+	// Targets of the set prescription the set was expanded from, null otherwise
+	SetType       *string  `json:"set_type"`
+	TargetRepsMax *int     `json:"target_reps_max"`
+	TargetRPE     *float64 `json:"target_rpe"`
+	RestSeconds   *int     `json:"rest_seconds"`
 }
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"time"
 
+	mail "rtglabs-go/provider" // Import email sender and storage
+
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
@@ -24,12 +26,14 @@ type AuthHandler struct {
 	DB             *sql.DB
 	sq             squirrel.StatementBuilderType
 	GoogleClientID string
+	EmailSender    mail.EmailSender // Used for bodyweight goal notifications triggered by profile updates
+	Storage        mail.Storage     // Where uploaded avatars are stored
 	// ... potentially a logger, or other dependencies
 }
 
 // NewAuthHandler creates a new AuthHandler instance.
 // It now accepts a *sql.DB instance.
-func NewAuthHandler(db *sql.DB, googleClientID string) *AuthHandler {
+func NewAuthHandler(db *sql.DB, googleClientID string, emailSender mail.EmailSender, storage mail.Storage) *AuthHandler {
 	// FIX: Use squirrel.Dollar for PostgreSQL
 	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar) // <--- CHANGED THIS LINE
 
@@ -37,6 +41,8 @@ func NewAuthHandler(db *sql.DB, googleClientID string) *AuthHandler {
 		DB:             db,
 		sq:             sq,
 		GoogleClientID: googleClientID, // ✅ set it here
+		EmailSender:    emailSender,
+		Storage:        storage,
 	}
 } // ValidateToken checks if the token is valid and not expired.
 // It returns the user ID if valid, or an error otherwise.
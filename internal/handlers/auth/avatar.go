@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// avatarMaxUploadSize is the largest accepted upload before decoding.
+	avatarMaxUploadSize = 5 << 20
+	// avatarJPEGQuality balances size and quality for profile pictures.
+	avatarJPEGQuality = 85
+	// avatarDefaultSize is the size stored in profiles.avatar_url.
+	avatarDefaultSize = "medium"
+)
+
+// avatarSizes are the fixed square renditions generated for every upload.
+var avatarSizes = map[string]int{
+	"small":  64,
+	"medium": 256,
+	"large":  512,
+}
+
+// UpdateAvatar accepts a multipart image upload (field "avatar"), validates it, strips its
+// metadata by re-encoding, stores fixed-size renditions through the storage backend and
+// points profiles.avatar_url at the default size.
+func (h *AuthHandler) UpdateAvatar(c echo.Context) error {
+	// 1. Get the authenticated user ID from the context.
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	if h.Storage == nil {
+		c.Logger().Error("UpdateAvatar: No storage backend configured")
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Avatar uploads are not available")
+	}
+
+	// 2. Read the upload, refusing anything larger than the limit.
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Image file is required in the 'avatar' field")
+	}
+	if fileHeader.Size > avatarMaxUploadSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Image is too large (max 5 MB)")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger().Errorf("UpdateAvatar: Failed to open uploaded file: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read uploaded file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, avatarMaxUploadSize+1))
+	if err != nil {
+		c.Logger().Errorf("UpdateAvatar: Failed to read uploaded file: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read uploaded file")
+	}
+	if len(data) > avatarMaxUploadSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Image is too large (max 5 MB)")
+	}
+
+	// 3. Decode (type is sniffed from the bytes). Re-encoding below drops EXIF metadata.
+	img, err := provider.DecodeImage(data)
+	if err != nil {
+		if errors.Is(err, provider.ErrUnsupportedImageType) {
+			return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Only JPEG, PNG and WebP images are supported")
+		}
+		if errors.Is(err, provider.ErrImageTooLarge) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Image dimensions are too large")
+		}
+		c.Logger().Warnf("UpdateAvatar: Failed to decode image: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid image file")
+	}
+
+	ctx := c.Request().Context()
+
+	// 4. Store every rendition under a fresh version directory so CDNs and clients
+	// never serve a stale cached image for the new URL.
+	// Any failure from here on removes the renditions already stored for this version.
+	version := uuid.New().String()
+	versionDir := fmt.Sprintf("avatars/%s/%s", userID, version)
+	sizes := make(map[string]string, len(avatarSizes))
+	for name, px := range avatarSizes {
+		encoded, err := provider.EncodeJPEG(provider.ResizeSquare(img, px), avatarJPEGQuality)
+		if err != nil {
+			c.Logger().Errorf("UpdateAvatar: Failed to encode %s avatar: %v", name, err)
+			h.deleteAvatarFiles(c, versionDir)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to process image")
+		}
+
+		key := fmt.Sprintf("%s/%d.jpg", versionDir, px)
+		url, err := h.Storage.Put(ctx, key, bytes.NewReader(encoded), "image/jpeg")
+		if err != nil {
+			c.Logger().Errorf("UpdateAvatar: Failed to store %s avatar: %v", name, err)
+			h.deleteAvatarFiles(c, versionDir)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to store image")
+		}
+		sizes[name] = url
+	}
+	avatarURL := sizes[avatarDefaultSize]
+
+	// 5. Remember the previous avatar so its files can be cleaned up after the switch.
+	var previousURL sql.NullString
+	prevQuery, prevArgs, err := h.sq.Select("avatar_url").From("profiles").Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateAvatar: Failed to build previous avatar query: %v", err)
+		h.deleteAvatarFiles(c, versionDir)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update avatar")
+	}
+	if err := h.DB.QueryRowContext(ctx, prevQuery, prevArgs...).Scan(&previousURL); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.Logger().Errorf("UpdateAvatar: Failed to fetch previous avatar: %v", err)
+		h.deleteAvatarFiles(c, versionDir)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update avatar")
+	}
+
+	// 6. Point the profile at the new avatar, creating a profile with defaults if needed.
+	now := time.Now()
+	upsertQuery, upsertArgs, err := h.sq.Insert("profiles").
+		Columns("id", "user_id", "units", "age", "avatar_url", "created_at", "updated_at").
+		Values(uuid.New(), userID, 0, 0, avatarURL, now, now).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET avatar_url = EXCLUDED.avatar_url, updated_at = EXCLUDED.updated_at").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateAvatar: Failed to build avatar update query: %v", err)
+		h.deleteAvatarFiles(c, versionDir)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update avatar")
+	}
+	if _, err := h.DB.ExecContext(ctx, upsertQuery, upsertArgs...); err != nil {
+		c.Logger().Errorf("UpdateAvatar: Failed to update avatar: %v", err)
+		h.deleteAvatarFiles(c, versionDir)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update avatar")
+	}
+
+	// 7. Best-effort cleanup of the previous upload. External URLs (e.g. Google) are left alone.
+	if previousURL.Valid {
+		if key, ok := h.Storage.KeyForURL(previousURL.String); ok {
+			h.deleteAvatarFiles(c, path.Dir(key))
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateAvatarResponse{
+		Message:   "Avatar updated successfully.",
+		AvatarURL: avatarURL,
+		Sizes:     sizes,
+	})
+}
+
+// deleteAvatarFiles removes every rendition stored under dir. Failures are only logged.
+// It ignores request cancellation so a failed upload doesn't leave orphaned files behind.
+func (h *AuthHandler) deleteAvatarFiles(c echo.Context, dir string) {
+	ctx := context.WithoutCancel(c.Request().Context())
+	for _, px := range avatarSizes {
+		if err := h.Storage.Delete(ctx, fmt.Sprintf("%s/%d.jpg", dir, px)); err != nil {
+			c.Logger().Warnf("UpdateAvatar: Failed to delete avatar file: %v", err)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"rtglabs-go/dto"
+	bw_handlers "rtglabs-go/internal/handlers/bodyweights"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// energyWindowDays is how far back workouts and bodyweights are considered.
+	energyWindowDays = 28
+	// energyMinTrendDays is the minimum span of bodyweight data before the trend is trusted.
+	energyMinTrendDays = 14
+)
+
+// GetEnergyEstimate estimates BMR (Mifflin-St Jeor) and TDEE for the authenticated user.
+// The activity factor is derived from the workouts logged over the last four weeks, and the
+// estimate is refined with the observed bodyweight trend when the client opts in by supplying
+// the average daily intake for the same period; refinement.status tells which case applied.
+func (h *AuthHandler) GetEnergyEstimate(c echo.Context) error {
+	// 1. Get the user ID from the context.
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	req := new(dto.EnergyEstimateRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	now := time.Now()
+
+	// 2. Load the profile values the equation needs.
+	var (
+		profile      model.Profile
+		height       sql.NullFloat64
+		gender       sql.NullInt64
+		birthdate    sql.NullTime
+		timezoneName sql.NullString
+	)
+	profileQuery, profileArgs, err := h.sq.Select("units", "age", "height", "gender", "birthdate", "timezone").
+		From("profiles").
+		Where(squirrel.Eq{"user_id": userID, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("GetEnergyEstimate: Failed to build profile query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to estimate energy expenditure")
+	}
+	err = h.DB.QueryRowContext(ctx, profileQuery, profileArgs...).Scan(
+		&profile.Units, &profile.Age, &height, &gender, &birthdate, &timezoneName,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "Complete your profile (age, height and weight) to get an energy estimate")
+		}
+		c.Logger().Errorf("GetEnergyEstimate: Failed to fetch profile: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to estimate energy expenditure")
+	}
+	profile.Height = height.Float64
+	profile.Gender = model.Gender(gender.Int64)
+	profile.Birthdate = provider.NullTimeToTimePtr(birthdate)
+	loc := provider.LoadLocationOrUTC(timezoneName.String)
+
+	// Bodyweights and heights are stored in the profile unit; the equation is metric.
+	kgPerUnit, cmPerUnit := 1.0, 1.0
+	if profile.Units == model.ProfileUnitsImperial {
+		kgPerUnit, cmPerUnit = provider.KgPerLb, provider.CmPerIn
+	}
+
+	windowStart := provider.StartOfDayIn(now, loc).AddDate(0, 0, -energyWindowDays)
+
+	// 3. Bodyweights in the window (for the trend) and the latest one (for the BMR).
+	points, latestWeight, err := h.fetchEnergyBodyweights(ctx, userID, windowStart)
+	if err != nil {
+		c.Logger().Errorf("GetEnergyEstimate: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to estimate energy expenditure")
+	}
+
+	inputs := dto.EnergyInputs{
+		HeightCm: profile.Height * cmPerUnit,
+		Age:      profile.AgeAt(now.In(loc)),
+		Gender:   profile.Gender,
+	}
+	if latestWeight != nil {
+		inputs.WeightKg = *latestWeight * kgPerUnit
+	}
+
+	missing := make([]string, 0, 3)
+	if inputs.WeightKg <= 0 {
+		missing = append(missing, "weight")
+	}
+	if inputs.HeightCm <= 0 {
+		missing = append(missing, "height")
+	}
+	if inputs.Age <= 0 {
+		missing = append(missing, "age")
+	}
+	if len(missing) > 0 {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, fmt.Sprintf("Complete your profile to get an energy estimate (missing: %s)", strings.Join(missing, ", ")))
+	}
+
+	// 4. Activity factor from completed workouts in the window.
+	summary, err := h.fetchEnergyTrainingSummary(ctx, userID, windowStart, kgPerUnit, inputs.WeightKg)
+	if err != nil {
+		c.Logger().Errorf("GetEnergyEstimate: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to estimate energy expenditure")
+	}
+	factor := provider.ActivityFactor(summary)
+
+	bmr := provider.MifflinStJeorBMR(inputs.WeightKg, inputs.HeightCm, inputs.Age, energySex(profile.Gender))
+	formulaTDEE := bmr * factor
+
+	// 5. Refine with the observed bodyweight trend.
+	refinement := dto.EnergyRefinement{
+		Status:           dto.EnergyRefinementInsufficientData,
+		WindowDays:       energyWindowDays,
+		DataPoints:       len(points),
+		AvgDailyCalories: req.AvgDailyCalories,
+	}
+	tdee := formulaTDEE
+	if trend, ok := provider.WeeklyWeightTrend(points); ok {
+		trendKg := trend * kgPerUnit
+		refinement.TrendKgPerWeek = &trendKg
+
+		spanDays := points[len(points)-1].At.Sub(points[0].At).Hours() / 24
+		if spanDays >= energyMinTrendDays {
+			balance := provider.KcalPerDayForWeeklyChange(trendKg)
+			if req.AvgDailyCalories != nil {
+				// Energy in minus energy stored is what was actually spent. Trust it in proportion
+				// to how much of the window the bodyweight data covers.
+				observed := provider.RoundKcal(*req.AvgDailyCalories - balance)
+				refinement.ObservedTDEE = &observed
+				refinement.Weight = math.Min(1, spanDays/energyWindowDays)
+				refinement.Status = dto.EnergyRefinementApplied
+				refinement.Applied = true
+				tdee = refinement.Weight*observed + (1-refinement.Weight)*formulaTDEE
+			} else {
+				implied := provider.RoundKcal(formulaTDEE + balance)
+				refinement.ImpliedDailyCalories = &implied
+				refinement.Status = dto.EnergyRefinementNeedsIntake
+			}
+		}
+	}
+
+	// 6. Calorie targets.
+	floor := provider.CalorieFloor(bmr)
+	targets := dto.EnergyTargets{
+		Cut:      provider.RoundKcal(math.Max(floor, tdee-provider.EnergyCutDeficitKcal)),
+		Maintain: provider.RoundKcal(tdee),
+		Bulk:     provider.RoundKcal(tdee + provider.EnergyBulkSurplusKcal),
+	}
+
+	goal, err := bw_handlers.FetchActiveBodyweightGoal(ctx, h.DB, h.sq, userID)
+	if err != nil {
+		c.Logger().Errorf("GetEnergyEstimate: Failed to fetch bodyweight goal: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to estimate energy expenditure")
+	}
+	if goal != nil && goal.ReachedAt == nil && goal.WeeklyRate != nil && *goal.WeeklyRate > 0 {
+		balance := provider.KcalPerDayForWeeklyChange(*goal.WeeklyRate * kgPerUnit)
+		if goal.IsLoss() {
+			balance = -balance
+		}
+		goalTarget := provider.RoundKcal(math.Max(floor, tdee+balance))
+		targets.Goal = &goalTarget
+	}
+
+	return c.JSON(http.StatusOK, dto.EnergyEstimateResponse{
+		Inputs: inputs,
+		BMR:    provider.RoundKcal(bmr),
+		Activity: dto.EnergyActivity{
+			Factor:            factor,
+			WindowDays:        energyWindowDays,
+			Sessions:          summary.Sessions,
+			SessionsPerWeek:   summary.SessionsPerWeek,
+			AvgSessionMinutes: summary.AvgSessionMinutes,
+			VolumeKg:          summary.VolumeKg,
+		},
+		FormulaTDEE: provider.RoundKcal(formulaTDEE),
+		TDEE:        provider.RoundKcal(tdee),
+		Refinement:  refinement,
+		Targets:     targets,
+	})
+}
+
+// fetchEnergyBodyweights returns the bodyweights logged since windowStart (oldest first)
+// and the user's latest bodyweight overall, both in the profile unit.
+func (h *AuthHandler) fetchEnergyBodyweights(ctx context.Context, userID uuid.UUID, windowStart time.Time) ([]provider.WeightPoint, *float64, error) {
+	query, args, err := h.sq.Select("weight", "created_at").
+		From("bodyweights").
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Eq{"deleted_at": nil},
+			squirrel.GtOrEq{"created_at": windowStart},
+		}).
+		OrderBy("created_at ASC").
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build bodyweights query: %w", err)
+	}
+
+	rows, err := h.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query bodyweights: %w", err)
+	}
+	defer rows.Close()
+
+	points := make([]provider.WeightPoint, 0)
+	for rows.Next() {
+		var p provider.WeightPoint
+		if err := rows.Scan(&p.Weight, &p.At); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan bodyweight: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("bodyweights rows error: %w", err)
+	}
+
+	if len(points) > 0 {
+		return points, &points[len(points)-1].Weight, nil
+	}
+
+	latestQuery, latestArgs, err := h.sq.Select("weight").
+		From("bodyweights").
+		Where(squirrel.Eq{"user_id": userID, "deleted_at": nil}).
+		OrderBy("created_at DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build latest bodyweight query: %w", err)
+	}
+	var latest float64
+	if err := h.DB.QueryRowContext(ctx, latestQuery, latestArgs...).Scan(&latest); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return points, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to fetch latest bodyweight: %w", err)
+	}
+	return points, &latest, nil
+}
+
+// fetchEnergyTrainingSummary aggregates the completed workout logs finished since windowStart.
+// Set weights are stored in the profile unit and converted to kg with kgPerUnit.
+func (h *AuthHandler) fetchEnergyTrainingSummary(ctx context.Context, userID uuid.UUID, windowStart time.Time, kgPerUnit, bodyweightKg float64) (provider.TrainingSummary, error) {
+	query, args, err := h.sq.Select(
+		"COUNT(*)",
+		"COALESCE(SUM(wl.total_active_duration_seconds), 0)",
+	).
+		From("workout_logs wl").
+		Where(squirrel.And{
+			squirrel.Eq{"wl.user_id": userID},
+			squirrel.Eq{"wl.deleted_at": nil},
+			squirrel.Eq{"wl.status": model.WorkoutLogStatusCompleted},
+			squirrel.GtOrEq{"wl.finished_at": windowStart},
+		}).
+		ToSql()
+	if err != nil {
+		return provider.TrainingSummary{}, fmt.Errorf("failed to build workout summary query: %w", err)
+	}
+	var (
+		sessions      int
+		activeSeconds float64
+	)
+	if err := h.DB.QueryRowContext(ctx, query, args...).Scan(&sessions, &activeSeconds); err != nil {
+		return provider.TrainingSummary{}, fmt.Errorf("failed to fetch workout summary: %w", err)
+	}
+
+	volumeQuery, volumeArgs, err := h.sq.Select("COALESCE(SUM(es.weight * es.reps), 0)").
+		From("exercise_sets es").
+		Join("workout_logs wl ON wl.id = es.workout_log_id").
+		Where(squirrel.And{
+			squirrel.Eq{"wl.user_id": userID},
+			squirrel.Eq{"wl.deleted_at": nil},
+			squirrel.Eq{"wl.status": model.WorkoutLogStatusCompleted},
+			squirrel.GtOrEq{"wl.finished_at": windowStart},
+			squirrel.Eq{"es.deleted_at": nil},
+			squirrel.Eq{"es.status": model.ExerciseSetStatusCompleted},
+		}).
+		ToSql()
+	if err != nil {
+		return provider.TrainingSummary{}, fmt.Errorf("failed to build workout volume query: %w", err)
+	}
+	var volume float64
+	if err := h.DB.QueryRowContext(ctx, volumeQuery, volumeArgs...).Scan(&volume); err != nil {
+		return provider.TrainingSummary{}, fmt.Errorf("failed to fetch workout volume: %w", err)
+	}
+
+	return provider.NewTrainingSummary(energyWindowDays, sessions, activeSeconds, volume*kgPerUnit, bodyweightKg), nil
+}
+
+// energySex maps the profile gender onto the constants of the BMR equation.
+func energySex(g model.Gender) provider.EnergySex {
+	switch g {
+	case model.GenderMale:
+		return provider.EnergySexMale
+	case model.GenderFemale:
+		return provider.EnergySexFemale
+	}
+	return provider.EnergySexUnknown
+}
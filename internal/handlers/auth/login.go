@@ -30,8 +30,8 @@ func (h *AuthHandler) StoreLogin(c echo.Context) error {
 
 	// 1. Query User and their Profile using a LEFT JOIN
 	userQuery := h.sq.Select(
-		"u.id", "u.name", "u.email", "u.password", "u.email_verified_at", "u.created_at", "u.updated_at",
-		"p.id", "p.user_id", "p.units", "p.age", "p.height", "p.gender", "p.created_at", "p.updated_at", "p.deleted_at",
+		append([]string{"u.id", "u.name", "u.email", "u.password", "u.email_verified_at", "u.created_at", "u.updated_at"},
+			profileJoinColumns...)...,
 	).
 		From("users u").
 		LeftJoin("profiles p ON u.id = p.user_id").
@@ -46,24 +46,12 @@ func (h *AuthHandler) StoreLogin(c echo.Context) error {
 
 	row := h.DB.QueryRowContext(ctx, sqlQuery, args...)
 
-	var (
-		profileID     sql.NullString
-		profileUserID sql.NullString
-		profileUnits  sql.NullInt64
-		profileAge    sql.NullInt64
-		profileHeight sql.NullFloat64
-		profileGender sql.NullInt64
-		// REMOVED profileWeight as it's no longer scanned from the profiles table
-		profileCreatedAt sql.NullTime
-		profileUpdatedAt sql.NullTime
-		profileDeletedAt sql.NullTime
-	)
-
-	err = row.Scan(
+	// Nullable profile fields from the LEFT JOIN
+	var joined profileRow
+
+	err = row.Scan(append([]any{
 		&entUser.ID, &entUser.Name, &entUser.Email, &entUser.Password, &entUser.EmailVerifiedAt, &entUser.CreatedAt, &entUser.UpdatedAt,
-		&profileID, &profileUserID, &profileUnits, &profileAge, &profileHeight, &profileGender, // REMOVED profileWeight from scan list
-		&profileCreatedAt, &profileUpdatedAt, &profileDeletedAt,
-	)
+	}, joined.dest()...)...)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -74,23 +62,7 @@ func (h *AuthHandler) StoreLogin(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to authenticate")
 	}
 
-	if profileID.Valid {
-		entProfile.ID = uuid.MustParse(profileID.String)
-		entProfile.UserID = uuid.MustParse(profileUserID.String)
-		entProfile.Units = int(profileUnits.Int64)
-		entProfile.Age = int(profileAge.Int64)
-		entProfile.Height = profileHeight.Float64
-		entProfile.Gender = int(profileGender.Int64)
-		entProfile.CreatedAt = profileCreatedAt.Time
-		entProfile.UpdatedAt = profileUpdatedAt.Time
-		if profileDeletedAt.Valid {
-			entProfile.DeletedAt = &profileDeletedAt.Time
-		} else {
-			entProfile.DeletedAt = nil
-		}
-	} else {
-		entProfile = model.Profile{}
-	}
+	entProfile = joined.profile()
 
 	// 2. Validate password
 	if err := bcrypt.CompareHashAndPassword([]byte(entUser.Password), []byte(req.Password)); err != nil {
@@ -136,17 +108,7 @@ func (h *AuthHandler) StoreLogin(c echo.Context) error {
 	}
 
 	if entProfile.ID != uuid.Nil {
-		responseUser.Profile = &dto.ProfileResponse{
-			ID:     entProfile.ID,
-			UserID: entProfile.UserID,
-			Units:  entProfile.Units,
-			Gender: entProfile.Gender,
-			Age:    entProfile.Age,
-			Height: entProfile.Height,
-			// Weight is not part of the profile DTO here, as it's dynamically fetched from bodyweights in GetProfile
-			CreatedAt: entProfile.CreatedAt.Format(time.RFC3339Nano),
-			UpdatedAt: entProfile.UpdatedAt.Format(time.RFC3339Nano),
-		}
+		responseUser.Profile = buildProfileResponse(&entProfile)
 	}
 
 	response := dto.LoginResponse{
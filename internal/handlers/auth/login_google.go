@@ -8,6 +8,7 @@ import (
 	"rtglabs-go/dto"
 	"rtglabs-go/model"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"google.golang.org/api/idtoken"
@@ -36,6 +37,7 @@ func (h *AuthHandler) StoreGoogleLogin(c echo.Context) error {
 	email, _ := payload.Claims["email"].(string)
 	name, _ := payload.Claims["name"].(string)
 	verified, _ := payload.Claims["email_verified"].(bool)
+	picture, _ := payload.Claims["picture"].(string)
 
 	var user model.User
 
@@ -100,7 +102,23 @@ func (h *AuthHandler) StoreGoogleLogin(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Database error during Google login")
 	}
 
-	// 6. Create session
+	// 6. Import the Google profile picture if the user has no avatar yet. An existing avatar is
+	// never replaced, and no profile is created for it: a missing profile tells the app that
+	// onboarding hasn't happened, so users who haven't onboarded get the picture on a later sign-in.
+	if picture != "" {
+		_, err = h.sq.Update("profiles").
+			Set("avatar_url", picture).
+			Set("updated_at", time.Now()).
+			Where(squirrel.Eq{"user_id": user.ID, "avatar_url": nil}).
+			RunWith(h.DB).
+			ExecContext(ctx)
+		if err != nil {
+			// Not worth failing the login over.
+			c.Logger().Errorf("❌ Failed to import Google profile picture: %v", err)
+		}
+	}
+
+	// 7. Create session
 	sessionID := uuid.New()
 	token := uuid.New().String()
 	expiresAt := time.Now().Add(7 * 24 * time.Hour)
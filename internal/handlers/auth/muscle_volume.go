@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// muscleVolumeDefaultDays is the window of GetMuscleVolume when none is requested.
+const muscleVolumeDefaultDays = 28
+
+// GetMuscleVolume attributes the volume of the user's completed sets over the last days to the
+// muscles their exercises work, using the primary/secondary mapping and its weights.
+func (h *AuthHandler) GetMuscleVolume(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	req := new(dto.MuscleVolumeRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	days := req.Days
+	if days == 0 {
+		days = muscleVolumeDefaultDays
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	volumes, err := provider.FetchMuscleVolume(c.Request().Context(), h.DB, h.sq, userID, since)
+	if err != nil {
+		c.Logger().Errorf("GetMuscleVolume: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compute muscle volume")
+	}
+
+	items := make([]dto.MuscleVolumeItem, 0, len(volumes))
+	for _, v := range volumes {
+		items = append(items, dto.MuscleVolumeItem{
+			Muscle: dto.MuscleResponse{
+				ID:          v.Muscle.ID,
+				Slug:        v.Muscle.Slug,
+				Name:        v.Muscle.Name,
+				MuscleGroup: v.Muscle.MuscleGroup,
+				CreatedAt:   v.Muscle.CreatedAt,
+				UpdatedAt:   v.Muscle.UpdatedAt,
+			},
+			Volume:      v.Volume,
+			Sets:        v.Sets,
+			PrimarySets: v.PrimarySets,
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MuscleVolumeResponse{
+		WindowDays: days,
+		Since:      since,
+		Data:       items,
+	})
+}
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"rtglabs-go/dto"
-	"rtglabs-go/model" // Import your model package
+	bw_handlers "rtglabs-go/internal/handlers/bodyweights" // Shared bodyweight goal helpers
+	"rtglabs-go/model"                                     // Import your model package
+	"rtglabs-go/provider"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -34,8 +36,8 @@ func (h *AuthHandler) GetProfile(c echo.Context) error {
 	// --- Query User and Profile Data with LEFT JOIN ---
 	// Select all columns needed from both users and profiles tables.
 	sqlQuery, args, err := h.sq.Select(
-		"u.id", "u.name", "u.email", "u.email_verified_at", "u.created_at", "u.updated_at",
-		"p.id", "p.user_id", "p.units", "p.age", "p.height", "p.gender", "p.created_at", "p.updated_at", "p.deleted_at",
+		append([]string{"u.id", "u.name", "u.email", "u.email_verified_at", "u.created_at", "u.updated_at"},
+			profileJoinColumns...)...,
 	).
 		From("users u").
 		LeftJoin("profiles p ON u.id = p.user_id").
@@ -49,23 +51,12 @@ func (h *AuthHandler) GetProfile(c echo.Context) error {
 
 	row := h.DB.QueryRowContext(ctx, sqlQuery, args...)
 
-	// Variables for scanning potentially NULL profile fields from LEFT JOIN
-	var (
-		profileID        sql.NullString
-		profileUserID    sql.NullString
-		profileUnits     sql.NullInt64
-		profileAge       sql.NullInt64
-		profileHeight    sql.NullFloat64
-		profileGender    sql.NullInt64
-		profileCreatedAt sql.NullTime
-		profileUpdatedAt sql.NullTime
-		profileDeletedAt sql.NullTime
-	)
-
-	err = row.Scan(
+	// Nullable profile fields from the LEFT JOIN
+	var joined profileRow
+
+	err = row.Scan(append([]any{
 		&entUser.ID, &entUser.Name, &entUser.Email, &entUser.EmailVerifiedAt, &entUser.CreatedAt, &entUser.UpdatedAt,
-		&profileID, &profileUserID, &profileUnits, &profileAge, &profileHeight, &profileGender, &profileCreatedAt, &profileUpdatedAt, &profileDeletedAt,
-	)
+	}, joined.dest()...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return echo.NewHTTPError(http.StatusNotFound, "User not found")
@@ -74,25 +65,8 @@ func (h *AuthHandler) GetProfile(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve user/profile data")
 	}
 
-	// Populate model.Profile from scanned null-aware types if a profile was found
-	if profileID.Valid {
-		entProfile.ID = uuid.MustParse(profileID.String)
-		entProfile.UserID = uuid.MustParse(profileUserID.String)
-		entProfile.Units = int(profileUnits.Int64)
-		entProfile.Age = int(profileAge.Int64)
-		entProfile.Height = profileHeight.Float64
-		entProfile.Gender = int(profileGender.Int64)
-		entProfile.CreatedAt = profileCreatedAt.Time
-		entProfile.UpdatedAt = profileUpdatedAt.Time
-		if profileDeletedAt.Valid {
-			entProfile.DeletedAt = &profileDeletedAt.Time
-		} else {
-			entProfile.DeletedAt = nil
-		}
-	} else {
-		// If no profile was found, ensure entProfile is its zero value
-		entProfile = model.Profile{}
-	}
+	// Populate model.Profile from scanned null-aware types; it stays zero if no profile was found
+	entProfile = joined.profile()
 
 	// --- Query the Latest Bodyweight ---
 	var latestBodyweightValue sql.NullFloat64 // This will hold the weight value, can be NULL
@@ -123,29 +97,38 @@ func (h *AuthHandler) GetProfile(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve latest bodyweight")
 	}
 
+	// --- Query the active Bodyweight Goal (with computed progress) ---
+	var goalResponse *dto.BodyweightGoalResponse
+	goal, err := bw_handlers.FetchActiveBodyweightGoal(ctx, h.DB, h.sq, userID)
+	if err != nil {
+		c.Logger().Errorf("GetProfile: Failed to fetch bodyweight goal: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve bodyweight goal")
+	}
+	if goal != nil {
+		built, err := bw_handlers.BuildBodyweightGoalResponse(ctx, h.DB, h.sq, goal, time.Now())
+		if err != nil {
+			c.Logger().Errorf("GetProfile: Failed to compute bodyweight goal progress: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve bodyweight goal")
+		}
+		goalResponse = &built
+	}
+
 	// --- Build the Profile DTO ---
 	var profileResponse *dto.ProfileResponse
-	if entProfile.ID != uuid.Nil || latestBodyweightValue.Valid {
+	if entProfile.ID != uuid.Nil {
+		profileResponse = buildProfileResponse(&entProfile)
+	} else if latestBodyweightValue.Valid || goalResponse != nil {
 		profileResponse = &dto.ProfileResponse{}
-
-		// Populate fields from the 'profiles' table (if a profile existed)
-		if entProfile.ID != uuid.Nil {
-			profileResponse.ID = entProfile.ID
-			profileResponse.UserID = entProfile.UserID
-			profileResponse.Units = entProfile.Units // Get units from profile table
-			profileResponse.Gender = entProfile.Gender
-			profileResponse.Age = entProfile.Age
-			profileResponse.Height = entProfile.Height
-			profileResponse.CreatedAt = entProfile.CreatedAt.Format(time.RFC3339Nano)
-			profileResponse.UpdatedAt = entProfile.UpdatedAt.Format(time.RFC3339Nano)
-		}
-
+	}
+	if profileResponse != nil {
 		// Always populate Weight from the latest bodyweight if available
 		if latestBodyweightValue.Valid {
 			profileResponse.Weight = latestBodyweightValue.Float64
 		} else {
 			profileResponse.Weight = 0.0 // Or nil if Weight is a pointer in DTO
 		}
+
+		profileResponse.BodyweightGoal = goalResponse
 	}
 
 	// 5. Build the final response DTO.
@@ -178,6 +161,16 @@ func (h *AuthHandler) UpdateProfile(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	// Birthdate has already been validated by the "birthdate" tag, so parsing can't fail here.
+	var birthdate *time.Time
+	if req.Birthdate != nil {
+		parsed, err := time.Parse(dto.ProfileDateLayout, *req.Birthdate)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid birthdate, expected YYYY-MM-DD")
+		}
+		birthdate = &parsed
+	}
+
 	ctx := c.Request().Context()
 	tx, err := h.DB.BeginTx(ctx, nil) // Start a transaction for atomicity
 	if err != nil {
@@ -205,9 +198,20 @@ func (h *AuthHandler) UpdateProfile(c echo.Context) error {
 		// --- Create a new profile if one doesn't exist ---
 		fmt.Println("Profile not found, creating a new one for user:", userID.String())
 
+		age := req.Age
+		if birthdate != nil {
+			age = (&model.Profile{Birthdate: birthdate}).AgeAt(time.Now())
+		}
+
 		insertProfileQuery, insertProfileArgs, err := h.sq.Insert("profiles").
-			Columns("id", "user_id", "units", "age", "height", "gender"). // <-- NO "weight" here, which is correct after schema change
-			Values(uuid.New(), userID, req.Units, req.Age, req.Height, req.Gender).
+			Columns("id", "user_id", "units", "age", "height", "gender", // <-- NO "weight" here, which is correct after schema change
+				"birthdate", "timezone", "week_start_day", "default_rest_seconds", "locale").
+			Values(uuid.New(), userID, req.Units, age, req.Height, req.Gender,
+				birthdate,
+				stringPtrOrDefault(req.Timezone, model.ProfileDefaultTimezone),
+				provider.IntPtrToInt(req.WeekStartDay, model.ProfileDefaultWeekStartDay),
+				provider.IntPtrToInt(req.DefaultRestSeconds, model.ProfileDefaultRestSeconds),
+				localeOrNull(req.Locale)).
 			ToSql()
 		if err != nil {
 			c.Logger().Errorf("UpdateProfile: Failed to build create profile query: %v", err)
@@ -227,14 +231,40 @@ func (h *AuthHandler) UpdateProfile(c echo.Context) error {
 		// --- Update the existing profile if it was found ---
 		fmt.Println("Existing profile found, updating it for user:", userID.String())
 
-		updateProfileQuery, updateProfileArgs, err := h.sq.Update("profiles").
+		updateProfileBuilder := h.sq.Update("profiles").
 			Set("units", req.Units).
-			Set("age", req.Age).
 			Set("height", req.Height).
 			Set("gender", req.Gender).
 			Set("updated_at", time.Now()). // Manually set updated_at
-			Where(squirrel.Eq{"id": existingProfile.ID}).
-			ToSql()
+			Where(squirrel.Eq{"id": existingProfile.ID})
+
+		// The optional settings are only touched when the client sends them.
+		// Age is kept in sync with the birthdate so older clients reading "age" still see a fresh value.
+		if birthdate != nil {
+			updateProfileBuilder = updateProfileBuilder.
+				Set("birthdate", birthdate).
+				Set("age", (&model.Profile{Birthdate: birthdate}).AgeAt(time.Now()))
+		} else if req.ClearBirthdate {
+			updateProfileBuilder = updateProfileBuilder.
+				Set("birthdate", nil).
+				Set("age", req.Age)
+		} else {
+			updateProfileBuilder = updateProfileBuilder.Set("age", req.Age)
+		}
+		if req.Timezone != nil {
+			updateProfileBuilder = updateProfileBuilder.Set("timezone", *req.Timezone)
+		}
+		if req.WeekStartDay != nil {
+			updateProfileBuilder = updateProfileBuilder.Set("week_start_day", *req.WeekStartDay)
+		}
+		if req.DefaultRestSeconds != nil {
+			updateProfileBuilder = updateProfileBuilder.Set("default_rest_seconds", *req.DefaultRestSeconds)
+		}
+		if req.Locale != nil {
+			updateProfileBuilder = updateProfileBuilder.Set("locale", localeOrNull(req.Locale))
+		}
+
+		updateProfileQuery, updateProfileArgs, err := updateProfileBuilder.ToSql()
 		if err != nil {
 			c.Logger().Errorf("UpdateProfile: Failed to build update profile query: %v", err)
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update profile")
@@ -272,7 +302,119 @@ func (h *AuthHandler) UpdateProfile(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to complete profile update")
 	}
 
+	// A weight logged through the profile can also complete the bodyweight goal.
+	if req.Weight > 0 {
+		if err := bw_handlers.CheckBodyweightGoalReached(ctx, h.DB, h.sq, h.EmailSender, userID, req.Weight); err != nil {
+			c.Logger().Errorf("UpdateProfile: Failed to check bodyweight goal: %v", err)
+		}
+	}
+
 	// Re-fetch and return the updated profile (which will now correctly include the new latest weight)
 	return h.GetProfile(c)
 }
 
+// stringPtrOrDefault dereferences p, returning def when it is nil.
+func stringPtrOrDefault(p *string, def string) string {
+	if p != nil {
+		return *p
+	}
+	return def
+}
+
+// localeOrNull returns the locale to store, or nil (NULL) when it is unset or cleared with "".
+func localeOrNull(locale *string) any {
+	if locale == nil || *locale == "" {
+		return nil
+	}
+	return *locale
+}
+
+// profileJoinColumns are the profile columns selected next to a user through "LEFT JOIN profiles p".
+// Scan them with profileRow.dest so login, register and GetProfile stay in sync.
+var profileJoinColumns = []string{
+	"p.id", "p.user_id", "p.units", "p.age", "p.height", "p.gender", "p.created_at", "p.updated_at", "p.deleted_at",
+	"p.birthdate", "p.timezone", "p.week_start_day", "p.default_rest_seconds", "p.avatar_url",
+	"p.locale",
+}
+
+// profileRow holds the nullable profileJoinColumns of a users LEFT JOIN profiles row.
+type profileRow struct {
+	id                 sql.NullString
+	userID             sql.NullString
+	units              sql.NullInt64
+	age                sql.NullInt64
+	height             sql.NullFloat64
+	gender             sql.NullInt64
+	createdAt          sql.NullTime
+	updatedAt          sql.NullTime
+	deletedAt          sql.NullTime
+	birthdate          sql.NullTime
+	timezone           sql.NullString
+	weekStartDay       sql.NullInt64
+	defaultRestSeconds sql.NullInt64
+	avatarURL          sql.NullString
+	locale             sql.NullString
+}
+
+// dest returns the scan destinations in profileJoinColumns order.
+func (r *profileRow) dest() []any {
+	return []any{
+		&r.id, &r.userID, &r.units, &r.age, &r.height, &r.gender, &r.createdAt, &r.updatedAt, &r.deletedAt,
+		&r.birthdate, &r.timezone, &r.weekStartDay, &r.defaultRestSeconds, &r.avatarURL,
+		&r.locale,
+	}
+}
+
+// profile converts the scanned row to a model.Profile, returning the zero value when the user has no profile.
+func (r *profileRow) profile() model.Profile {
+	if !r.id.Valid {
+		return model.Profile{}
+	}
+	p := model.Profile{
+		ID:                 uuid.MustParse(r.id.String),
+		UserID:             uuid.MustParse(r.userID.String),
+		Units:              int(r.units.Int64),
+		Age:                int(r.age.Int64),
+		Height:             r.height.Float64,
+		Gender:             model.Gender(r.gender.Int64),
+		Birthdate:          provider.NullTimeToTimePtr(r.birthdate),
+		Timezone:           r.timezone.String,
+		WeekStartDay:       int(r.weekStartDay.Int64),
+		DefaultRestSeconds: int(r.defaultRestSeconds.Int64),
+		Locale:             provider.NullStringToStringPtr(r.locale),
+		CreatedAt:          r.createdAt.Time,
+		UpdatedAt:          r.updatedAt.Time,
+	}
+	if r.avatarURL.Valid {
+		p.AvatarURL = &r.avatarURL.String
+	}
+	if r.deletedAt.Valid {
+		p.DeletedAt = &r.deletedAt.Time
+	}
+	return p
+}
+
+// buildProfileResponse maps the stored profile to its response DTO. Age is derived from the
+// birthdate in the profile's timezone; Weight and BodyweightGoal are left for GetProfile to fill in.
+func buildProfileResponse(p *model.Profile) *dto.ProfileResponse {
+	resp := &dto.ProfileResponse{
+		ID:                 p.ID,
+		UserID:             p.UserID,
+		Units:              p.Units,
+		Gender:             p.Gender,
+		Age:                p.AgeAt(time.Now().In(provider.LoadLocationOrUTC(p.Timezone))),
+		Height:             p.Height,
+		Timezone:           p.Timezone,
+		WeekStartDay:       p.WeekStartDay,
+		DefaultRestSeconds: p.DefaultRestSeconds,
+		AvatarURL:          p.AvatarURL,
+		Locale:             p.Locale,
+		CreatedAt:          p.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:          p.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	if p.Birthdate != nil {
+		birthdate := p.Birthdate.Format(dto.ProfileDateLayout)
+		resp.Birthdate = &birthdate
+	}
+	return resp
+}
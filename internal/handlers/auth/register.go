@@ -61,9 +61,8 @@ func (h *AuthHandler) StoreRegister(c echo.Context) error {
 	// Select all columns needed from both users and profiles tables.
 	// The order here must match the order in Scan.
 	fetchUserQuery, fetchUserArgs, err := h.sq.Select(
-		"u.id", "u.name", "u.email", "u.email_verified_at", "u.created_at", "u.updated_at",
-		// REMOVED 'p.weight' from here as it no longer exists in the 'profiles' table
-		"p.id", "p.user_id", "p.units", "p.age", "p.height", "p.gender", "p.created_at", "p.updated_at", "p.deleted_at",
+		append([]string{"u.id", "u.name", "u.email", "u.email_verified_at", "u.created_at", "u.updated_at"},
+			profileJoinColumns...)...,
 	).
 		From("users u").
 		LeftJoin("profiles p ON u.id = p.user_id").
@@ -76,25 +75,12 @@ func (h *AuthHandler) StoreRegister(c echo.Context) error {
 
 	row := h.DB.QueryRowContext(ctx, fetchUserQuery, fetchUserArgs...)
 
-	// Variables for scanning potentially NULL profile fields from LEFT JOIN
-	var (
-		profileID     sql.NullString
-		profileUserID sql.NullString
-		profileUnits  sql.NullInt64
-		profileAge    sql.NullInt64
-		profileHeight sql.NullFloat64
-		profileGender sql.NullInt64
-		// REMOVED profileWeight as it's no longer scanned from the profiles table
-		profileCreatedAt sql.NullTime
-		profileUpdatedAt sql.NullTime
-		profileDeletedAt sql.NullTime
-	)
-
-	err = row.Scan(
+	// Nullable profile fields from the LEFT JOIN
+	var joined profileRow
+
+	err = row.Scan(append([]any{
 		&entUser.ID, &entUser.Name, &entUser.Email, &entUser.EmailVerifiedAt, &entUser.CreatedAt, &entUser.UpdatedAt,
-		&profileID, &profileUserID, &profileUnits, &profileAge, &profileHeight, &profileGender, // REMOVED profileWeight from scan list
-		&profileCreatedAt, &profileUpdatedAt, &profileDeletedAt,
-	)
+	}, joined.dest()...)...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.Logger().Errorf("StoreRegister: Created user not found immediately after insert (possible race condition or DB issue): %v", err)
@@ -105,24 +91,7 @@ func (h *AuthHandler) StoreRegister(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch created user")
 	}
 
-	// Populate model.Profile from scanned null-aware types if a profile was found
-	if profileID.Valid {
-		entProfile.ID = uuid.MustParse(profileID.String)
-		entProfile.UserID = uuid.MustParse(profileUserID.String)
-		entProfile.Units = int(profileUnits.Int64)
-		entProfile.Age = int(profileAge.Int64)
-		entProfile.Height = profileHeight.Float64
-		entProfile.Gender = int(profileGender.Int64)
-		entProfile.CreatedAt = profileCreatedAt.Time
-		entProfile.UpdatedAt = profileUpdatedAt.Time
-		if profileDeletedAt.Valid {
-			entProfile.DeletedAt = &profileDeletedAt.Time
-		} else {
-			entProfile.DeletedAt = nil
-		}
-	} else {
-		entProfile = model.Profile{} // Ensure it's zeroed if no profile found
-	}
+	entProfile = joined.profile()
 
 	// 3. Generate token and create NEW session for the newly registered user
 	token := uuid.New().String()
@@ -160,17 +129,7 @@ func (h *AuthHandler) StoreRegister(c echo.Context) error {
 	}
 
 	if entProfile.ID != uuid.Nil {
-		responseUser.Profile = &dto.ProfileResponse{
-			ID:     entProfile.ID,
-			UserID: entProfile.UserID,
-			Units:  entProfile.Units,
-			Gender: entProfile.Gender,
-			Age:    entProfile.Age,
-			Height: entProfile.Height,
-			// Weight is not included here, as it's handled by the bodyweights table and fetched separately in GetProfile
-			CreatedAt: entProfile.CreatedAt.Format(time.RFC3339Nano),
-			UpdatedAt: entProfile.UpdatedAt.Format(time.RFC3339Nano),
-		}
+		responseUser.Profile = buildProfileResponse(&entProfile)
 	}
 
 	// Construct the RegisterResponse with token and expiry
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"rtglabs-go/dto"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyBodyweightGoal soft-deletes the user's active bodyweight goal.
+func (h *BodyweightHandler) DestroyBodyweightGoal(c echo.Context) error {
+	// 1. Get the authenticated user ID from the context.
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	ctx := c.Request().Context()
+	now := time.Now()
+
+	// 2. Soft-delete the active goal.
+	updateQuery, updateArgs, err := h.sq.Update("bodyweight_goals").
+		Set("deleted_at", now).
+		Set("updated_at", now).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Eq{"deleted_at": nil},
+		}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyBodyweightGoal: Failed to build soft delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete bodyweight goal")
+	}
+
+	res, err := h.DB.ExecContext(ctx, updateQuery, updateArgs...)
+	if err != nil {
+		c.Logger().Errorf("DestroyBodyweightGoal: Failed to execute soft delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete bodyweight goal")
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		c.Logger().Errorf("DestroyBodyweightGoal: Failed to get rows affected: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete bodyweight goal")
+	}
+	if rowsAffected == 0 {
+		return c.JSON(http.StatusNotFound, dto.DeleteBodyweightGoalResponse{
+			Message: "No bodyweight goal set.",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteBodyweightGoalResponse{
+		Message: "Bodyweight goal deleted successfully.",
+	})
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetBodyweightGoal returns the user's active bodyweight goal together with its computed progress,
+// trend and projected completion date.
+func (h *BodyweightHandler) GetBodyweightGoal(c echo.Context) error {
+	// 1. Get the authenticated user ID from the context.
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	ctx := c.Request().Context()
+
+	// 2. Load the active goal.
+	goal, err := FetchActiveBodyweightGoal(ctx, h.DB, h.sq, userID)
+	if err != nil {
+		c.Logger().Errorf("GetBodyweightGoal: Failed to fetch bodyweight goal: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve bodyweight goal")
+	}
+	if goal == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "No bodyweight goal set")
+	}
+
+	// 3. Compute progress from the recent bodyweights and return it.
+	response, err := BuildBodyweightGoalResponse(ctx, h.DB, h.sq, goal, time.Now())
+	if err != nil {
+		c.Logger().Errorf("GetBodyweightGoal: Failed to compute bodyweight goal progress: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to compute bodyweight goal progress")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
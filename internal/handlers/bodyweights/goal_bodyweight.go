@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// goalTrendWindowDays is how far back bodyweights are considered when fitting the trend line.
+const goalTrendWindowDays = 28
+
+// FetchActiveBodyweightGoal returns the user's active (non-deleted) bodyweight goal,
+// or nil when the user has not set one. It is exported so the profile handler can reuse it.
+func FetchActiveBodyweightGoal(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, userID uuid.UUID) (*model.BodyweightGoal, error) {
+	query, args, err := sq.Select(
+		"id", "user_id", "start_weight", "target_weight", "target_date", "weekly_rate",
+		"reached_at", "created_at", "updated_at", "deleted_at",
+	).
+		From("bodyweight_goals").
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Eq{"deleted_at": nil},
+		}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build bodyweight goal query: %w", err)
+	}
+
+	var (
+		goal          model.BodyweightGoal
+		nullRate      sql.NullFloat64
+		nullReachedAt sql.NullTime
+		nullDeletedAt sql.NullTime
+	)
+	err = db.QueryRowContext(ctx, query, args...).Scan(
+		&goal.ID, &goal.UserID, &goal.StartWeight, &goal.TargetWeight, &goal.TargetDate, &nullRate,
+		&nullReachedAt, &goal.CreatedAt, &goal.UpdatedAt, &nullDeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to scan bodyweight goal: %w", err)
+	}
+
+	goal.WeeklyRate = provider.NullFloat64ToFloat64Ptr(nullRate)
+	goal.ReachedAt = provider.NullTimeToTimePtr(nullReachedAt)
+	goal.DeletedAt = provider.NullTimeToTimePtr(nullDeletedAt)
+	return &goal, nil
+}
+
+// BuildBodyweightGoalResponse loads the user's recent bodyweights and computes the goal's
+// progress, trend and projected completion date. Day boundaries use the user's profile timezone.
+func BuildBodyweightGoalResponse(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, goal *model.BodyweightGoal, now time.Time) (dto.BodyweightGoalResponse, error) {
+	loc := provider.UserLocation(ctx, db, sq, goal.UserID)
+
+	query, args, err := sq.Select("weight", "created_at").
+		From("bodyweights").
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": goal.UserID},
+			squirrel.Eq{"deleted_at": nil},
+			squirrel.GtOrEq{"created_at": provider.StartOfDayIn(now, loc).AddDate(0, 0, -goalTrendWindowDays)},
+		}).
+		OrderBy("created_at ASC").
+		ToSql()
+	if err != nil {
+		return dto.BodyweightGoalResponse{}, fmt.Errorf("failed to build recent bodyweights query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return dto.BodyweightGoalResponse{}, fmt.Errorf("failed to query recent bodyweights: %w", err)
+	}
+	defer rows.Close()
+
+	points := make([]provider.WeightPoint, 0)
+	for rows.Next() {
+		var p provider.WeightPoint
+		if err := rows.Scan(&p.Weight, &p.At); err != nil {
+			return dto.BodyweightGoalResponse{}, fmt.Errorf("failed to scan recent bodyweight: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return dto.BodyweightGoalResponse{}, fmt.Errorf("recent bodyweights rows error: %w", err)
+	}
+
+	// The trend window may be empty for users who haven't logged recently,
+	// so fall back to the overall latest bodyweight for the current weight.
+	var currentWeight *float64
+	if len(points) > 0 {
+		currentWeight = &points[len(points)-1].Weight
+	} else {
+		latestQuery, latestArgs, err := sq.Select("weight").
+			From("bodyweights").
+			Where(squirrel.And{
+				squirrel.Eq{"user_id": goal.UserID},
+				squirrel.Eq{"deleted_at": nil},
+			}).
+			OrderBy("created_at DESC").
+			Limit(1).
+			ToSql()
+		if err != nil {
+			return dto.BodyweightGoalResponse{}, fmt.Errorf("failed to build latest bodyweight query: %w", err)
+		}
+		var latest float64
+		err = db.QueryRowContext(ctx, latestQuery, latestArgs...).Scan(&latest)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return dto.BodyweightGoalResponse{}, fmt.Errorf("failed to fetch latest bodyweight: %w", err)
+		}
+		if err == nil {
+			currentWeight = &latest
+		}
+	}
+
+	return dto.BodyweightGoalResponse{
+		ID:           goal.ID,
+		UserID:       goal.UserID,
+		StartWeight:  goal.StartWeight,
+		TargetWeight: goal.TargetWeight,
+		TargetDate:   goal.TargetDate.Format(dto.BodyweightGoalDateLayout),
+		WeeklyRate:   goal.WeeklyRate,
+		ReachedAt:    goal.ReachedAt,
+		Progress:     computeBodyweightGoalProgress(goal, currentWeight, points, now, loc),
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	}, nil
+}
+
+// computeBodyweightGoalProgress derives the progress figures for a goal.
+// It performs no I/O so the math is easy to reason about in isolation.
+func computeBodyweightGoalProgress(goal *model.BodyweightGoal, currentWeight *float64, points []provider.WeightPoint, now time.Time, loc *time.Location) dto.BodyweightGoalProgress {
+	progress := dto.BodyweightGoalProgress{
+		CurrentWeight:     currentWeight,
+		DataPointsInTrend: len(points),
+		TrendWindowInDays: goalTrendWindowDays,
+	}
+
+	current := goal.StartWeight
+	if currentWeight != nil {
+		current = *currentWeight
+	}
+
+	// Distance covered and remaining, measured in the direction of the goal.
+	total := math.Abs(goal.TargetWeight - goal.StartWeight)
+	covered := current - goal.StartWeight
+	remaining := goal.TargetWeight - current
+	if goal.IsLoss() {
+		covered = -covered
+		remaining = -remaining
+	}
+	progress.RemainingWeight = math.Max(0, remaining)
+	if total == 0 {
+		progress.ProgressPercent = 100
+	} else {
+		progress.ProgressPercent = math.Min(100, math.Max(0, covered/total*100))
+	}
+
+	// The target date is a calendar day in the user's timezone, so treat the whole day as "on time".
+	deadline := provider.DateInLocation(goal.TargetDate, loc).AddDate(0, 0, 1)
+
+	if daysLeft := deadline.Sub(now).Hours() / 24; daysLeft > 0 && progress.RemainingWeight > 0 {
+		required := (goal.TargetWeight - current) / (daysLeft / 7)
+		progress.RequiredPerWeek = &required
+	}
+
+	if trend, ok := provider.WeeklyWeightTrend(points); ok {
+		progress.TrendPerWeek = &trend
+	}
+
+	if goal.WeeklyRate != nil && *goal.WeeklyRate > 0 {
+		planned := goal.CreatedAt.Add(weeksToDuration(total / *goal.WeeklyRate))
+		progress.PlannedDate = &planned
+	}
+
+	switch {
+	case goal.ReachedAt != nil || progress.RemainingWeight == 0:
+		// Already there: the projection is the moment it happened.
+		reached := now
+		if goal.ReachedAt != nil {
+			reached = *goal.ReachedAt
+		}
+		progress.ProjectedDate = &reached
+		progress.OnTrack = true
+	case progress.TrendPerWeek != nil:
+		// Only project when the trend moves towards the target.
+		trend := *progress.TrendPerWeek
+		if goal.IsLoss() {
+			trend = -trend
+		}
+		if trend > 0 {
+			projected := now.Add(weeksToDuration(progress.RemainingWeight / trend))
+			progress.ProjectedDate = &projected
+			progress.OnTrack = projected.Before(deadline)
+		}
+	}
+
+	return progress
+}
+
+// weeksToDuration converts a fractional number of weeks into a time.Duration.
+func weeksToDuration(weeks float64) time.Duration {
+	return time.Duration(weeks * 7 * 24 * float64(time.Hour))
+}
+
+// CheckBodyweightGoalReached marks the user's active goal as reached when the given weight
+// satisfies it and sends a congratulation email. The reached_at update is conditional so the
+// email is only ever sent once per goal, even with concurrent bodyweight writes.
+// The email is sent in the background so a slow SMTP server never delays the request;
+// send failures are logged and the goal stays marked as reached.
+func CheckBodyweightGoalReached(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, emailSender provider.EmailSender, userID uuid.UUID, weight float64) error {
+	goal, err := FetchActiveBodyweightGoal(ctx, db, sq, userID)
+	if err != nil {
+		return err
+	}
+	if goal == nil || goal.ReachedAt != nil || !goal.IsReachedBy(weight) {
+		return nil
+	}
+
+	now := time.Now()
+	updateQuery, updateArgs, err := sq.Update("bodyweight_goals").
+		Set("reached_at", now).
+		Set("updated_at", now).
+		Where(squirrel.And{
+			squirrel.Eq{"id": goal.ID},
+			squirrel.Eq{"reached_at": nil},
+			squirrel.Eq{"deleted_at": nil},
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build goal reached update query: %w", err)
+	}
+
+	res, err := db.ExecContext(ctx, updateQuery, updateArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to mark bodyweight goal as reached: %w", err)
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		// Another request already marked it; that request sends the email.
+		return nil
+	}
+
+	if emailSender == nil {
+		return nil
+	}
+
+	var email, name string
+	userQuery, userArgs, err := sq.Select("email", "name").From("users").Where(squirrel.Eq{"id": userID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build user query: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, userQuery, userArgs...).Scan(&email, &name); err != nil {
+		return fmt.Errorf("failed to fetch user for goal email: %w", err)
+	}
+
+	go func(startWeight, targetWeight float64) {
+		if err := emailSender.SendBodyweightGoalReachedEmail(email, name, startWeight, targetWeight); err != nil {
+			log.Printf("ERROR: Failed to send goal reached email for user %s: %v", userID, err)
+		}
+	}(goal.StartWeight, goal.TargetWeight)
+	return nil
+}
@@ -5,23 +5,26 @@ import (
 	"time"
 
 	"rtglabs-go/dto"
-	"rtglabs-go/model" // Import your model package
+	"rtglabs-go/model"         // Import your model package
+	mail "rtglabs-go/provider" // Import email sender
 
 	"github.com/Masterminds/squirrel" // Import squirrel
 )
 
 type BodyweightHandler struct {
-	DB *sql.DB                       // Use standard SQL DB
-	sq squirrel.StatementBuilderType // Use squirrel builder
+	DB          *sql.DB                       // Use standard SQL DB
+	EmailSender mail.EmailSender              // Used to notify users when a bodyweight goal is reached
+	sq          squirrel.StatementBuilderType // Use squirrel builder
 }
 
-func NewBodyweightHandler(db *sql.DB) *BodyweightHandler { // Accept *sql.DB
+func NewBodyweightHandler(db *sql.DB, emailSender mail.EmailSender) *BodyweightHandler { // Accept *sql.DB
 	// Initialize squirrel with the appropriate placeholder format for PostgreSQL
 	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 	return &BodyweightHandler{
-		DB: db,
-		sq: sq,
+		DB:          db,
+		EmailSender: emailSender,
+		sq:          sq,
 	}
 }
 
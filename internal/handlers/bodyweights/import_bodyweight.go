@@ -0,0 +1,436 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// importMaxFileSize caps the uploaded CSV at 5 MB. A decade of daily weigh-ins is well below
+	// 1 MB; the rest leaves room for exports of other apps that carry extra columns per row.
+	importMaxFileSize = 5 << 20
+	// importBatchSize keeps each bulk INSERT well below PostgreSQL's parameter limit.
+	importBatchSize = 1000
+	// importMaxWeight rejects obviously broken values (e.g. grams instead of kilograms).
+	importMaxWeight = 1000
+
+	kgToLb = 2.20462262185
+)
+
+// importDateLayouts are tried in order when no explicit date_format is provided.
+var importDateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006/01/02",
+	"01/02/2006",
+	"02.01.2006",
+}
+
+// importColumns holds the resolved CSV column indexes for an import.
+type importColumns struct {
+	date   int
+	weight int
+	unit   int // -1 when the file has no unit column
+}
+
+// ImportBodyweight imports bodyweights from a CSV upload (multipart field "file").
+//
+// Form fields (all optional):
+//   - date_column, weight_column, unit_column: header names (case-insensitive), defaults "date", "weight", "unit"
+//   - date_format: Go time layout; by default several common layouts are tried
+//   - default_unit: "kg" or "lb", used when the file has no unit column (defaults to the profile unit)
+//   - dry_run: "true" to only validate and preview without inserting anything
+//
+// Rows are de-duplicated by calendar day (the last row for a day wins) and days that already
+// have a bodyweight are skipped. A real import is all-or-nothing: if any row is invalid nothing
+// is inserted and the per-row errors are returned.
+func (h *BodyweightHandler) ImportBodyweight(c echo.Context) error {
+	// 1. Get the authenticated user ID from the context.
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	dryRun, _ := strconv.ParseBool(c.FormValue("dry_run"))
+	if !dryRun {
+		dryRun, _ = strconv.ParseBool(c.QueryParam("dry_run"))
+	}
+
+	// 2. Open the uploaded file.
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "CSV file is required in the 'file' field")
+	}
+	if fileHeader.Size > importMaxFileSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "CSV file is too large (max 5 MB)")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger().Errorf("ImportBodyweight: Failed to open uploaded file: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read uploaded file")
+	}
+	defer file.Close()
+
+	ctx := c.Request().Context()
+
+	// 3. Work out which unit the user's bodyweights are stored in, and the timezone used to bucket days.
+	profileUnits := model.ProfileUnitsMetric
+	timezone := model.ProfileDefaultTimezone
+	unitsQuery, unitsArgs, err := h.sq.Select("units", "timezone").From("profiles").Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		c.Logger().Errorf("ImportBodyweight: Failed to build profile units query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+	}
+	if err := h.DB.QueryRowContext(ctx, unitsQuery, unitsArgs...).Scan(&profileUnits, &timezone); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.Logger().Errorf("ImportBodyweight: Failed to fetch profile units: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+	}
+
+	defaultUnit := "kg"
+	if profileUnits == model.ProfileUnitsImperial {
+		defaultUnit = "lb"
+	}
+	if v := c.FormValue("default_unit"); v != "" {
+		unit, ok := normalizeWeightUnit(v)
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "default_unit must be 'kg' or 'lb'")
+		}
+		defaultUnit = unit
+	}
+
+	// 4. Read the header and resolve the configured columns.
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Validate row lengths ourselves for nicer error messages
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return echo.NewHTTPError(http.StatusBadRequest, "CSV file is empty")
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read CSV header: "+err.Error())
+	}
+
+	columns, err := resolveImportColumns(header,
+		formValueOrDefault(c, "date_column", "date"),
+		formValueOrDefault(c, "weight_column", "weight"),
+		formValueOrDefault(c, "unit_column", "unit"),
+	)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	loc := provider.LoadLocationOrUTC(timezone)
+
+	dateLayouts := importDateLayouts
+	if v := c.FormValue("date_format"); v != "" {
+		dateLayouts = []string{v}
+	}
+
+	// 5. Parse and validate every row, de-duplicating by day (last row wins).
+	response := dto.ImportBodyweightResponse{
+		DryRun: dryRun,
+		Errors: []dto.ImportBodyweightRowError{},
+		Rows:   []dto.ImportBodyweightRow{},
+	}
+	byDay := make(map[string]dto.ImportBodyweightRow)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			response.Errors = append(response.Errors, dto.ImportBodyweightRowError{Row: line, Message: err.Error()})
+			continue
+		}
+		// Ignore completely blank lines (e.g. a trailing empty row from spreadsheet exports).
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		response.TotalRows++
+
+		row, rowErr := parseImportRow(record, columns, dateLayouts, defaultUnit, profileUnits, loc)
+		if rowErr != nil {
+			response.Errors = append(response.Errors, dto.ImportBodyweightRowError{Row: line, Message: rowErr.Error()})
+			continue
+		}
+		row.Row = line
+		response.ValidRows++
+
+		if _, exists := byDay[row.Date]; exists {
+			response.SkippedDuplicates++
+		}
+		byDay[row.Date] = row
+	}
+
+	if response.TotalRows == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "CSV file has no data rows")
+	}
+
+	// 6. Skip days that already have a bodyweight logged.
+	if len(byDay) > 0 {
+		existingDays, err := h.fetchExistingBodyweightDays(c, userID, byDay, loc)
+		if err != nil {
+			return err
+		}
+		for day := range existingDays {
+			if _, ok := byDay[day]; ok {
+				delete(byDay, day)
+				response.SkippedExisting++
+			}
+		}
+	}
+
+	for _, row := range byDay {
+		response.Rows = append(response.Rows, row)
+	}
+	sort.Slice(response.Rows, func(i, j int) bool { return response.Rows[i].Date < response.Rows[j].Date })
+
+	if dryRun {
+		response.Message = fmt.Sprintf("Dry run: %d bodyweight records would be imported.", len(response.Rows))
+		return c.JSON(http.StatusOK, response)
+	}
+
+	if len(response.Errors) > 0 {
+		response.Message = "Import aborted: fix the invalid rows and try again."
+		response.Rows = []dto.ImportBodyweightRow{}
+		return c.JSON(http.StatusUnprocessableEntity, response)
+	}
+
+	// 7. Insert everything in a single transaction.
+	if len(response.Rows) > 0 {
+		tx, err := h.DB.BeginTx(ctx, nil)
+		if err != nil {
+			c.Logger().Errorf("ImportBodyweight: Failed to begin transaction: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+		}
+		defer tx.Rollback() // Rollback on error unless committed
+
+		now := time.Now()
+		for start := 0; start < len(response.Rows); start += importBatchSize {
+			end := min(start+importBatchSize, len(response.Rows))
+
+			insertBuilder := h.sq.Insert("bodyweights").Columns("id", "user_id", "weight", "created_at", "updated_at")
+			for _, row := range response.Rows[start:end] {
+				insertBuilder = insertBuilder.Values(uuid.New(), userID, row.Weight, row.At, now)
+			}
+
+			insertQuery, insertArgs, err := insertBuilder.ToSql()
+			if err != nil {
+				c.Logger().Errorf("ImportBodyweight: Failed to build insert query: %v", err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+			}
+			if _, err := tx.ExecContext(ctx, insertQuery, insertArgs...); err != nil {
+				c.Logger().Errorf("ImportBodyweight: Failed to insert bodyweights: %v", err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			c.Logger().Errorf("ImportBodyweight: Failed to commit transaction: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+		}
+
+		// The import may complete the user's goal. Check against the user's latest bodyweight rather
+		// than the last CSV row: a backfill of history is usually older than what's already logged.
+		latestQuery, latestArgs, err := h.sq.Select("weight").
+			From("bodyweights").
+			Where(squirrel.And{
+				squirrel.Eq{"user_id": userID},
+				squirrel.Eq{"deleted_at": nil},
+			}).
+			OrderBy("created_at DESC").
+			Limit(1).
+			ToSql()
+		if err != nil {
+			c.Logger().Errorf("ImportBodyweight: Failed to build latest bodyweight query: %v", err)
+		} else {
+			var latestWeight float64
+			if err := h.DB.QueryRowContext(ctx, latestQuery, latestArgs...).Scan(&latestWeight); err != nil {
+				c.Logger().Errorf("ImportBodyweight: Failed to fetch latest bodyweight: %v", err)
+			} else if err := CheckBodyweightGoalReached(ctx, h.DB, h.sq, h.EmailSender, userID, latestWeight); err != nil {
+				c.Logger().Errorf("ImportBodyweight: Failed to check bodyweight goal: %v", err)
+			}
+		}
+	}
+
+	response.Imported = len(response.Rows)
+	response.Message = fmt.Sprintf("%d bodyweight records imported successfully.", response.Imported)
+	return c.JSON(http.StatusCreated, response)
+}
+
+// fetchExistingBodyweightDays returns the set of days (YYYY-MM-DD in the user's timezone) within
+// the import's date range on which the user already has a bodyweight.
+func (h *BodyweightHandler) fetchExistingBodyweightDays(c echo.Context, userID uuid.UUID, byDay map[string]dto.ImportBodyweightRow, loc *time.Location) (map[string]struct{}, error) {
+	var minAt, maxAt time.Time
+	for _, row := range byDay {
+		if minAt.IsZero() || row.At.Before(minAt) {
+			minAt = row.At
+		}
+		if row.At.After(maxAt) {
+			maxAt = row.At
+		}
+	}
+
+	query, args, err := h.sq.Select().
+		Column(squirrel.Expr("DISTINCT DATE(created_at AT TIME ZONE ?)", loc.String())).
+		From("bodyweights").
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Eq{"deleted_at": nil},
+			squirrel.GtOrEq{"created_at": provider.StartOfDayIn(minAt, loc)},
+			squirrel.Lt{"created_at": provider.StartOfDayIn(maxAt, loc).AddDate(0, 0, 1)},
+		}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("ImportBodyweight: Failed to build existing days query: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+	}
+
+	rows, err := h.DB.QueryContext(c.Request().Context(), query, args...)
+	if err != nil {
+		c.Logger().Errorf("ImportBodyweight: Failed to query existing days: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+	}
+	defer rows.Close()
+
+	days := make(map[string]struct{})
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			c.Logger().Errorf("ImportBodyweight: Failed to scan existing day: %v", err)
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+		}
+		days[day.Format(dto.BodyweightGoalDateLayout)] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		c.Logger().Errorf("ImportBodyweight: Rows error for existing days: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to import bodyweights")
+	}
+	return days, nil
+}
+
+// resolveImportColumns maps the configured column names onto header indexes.
+// The unit column is optional: if it isn't present the default unit is used for every row.
+func resolveImportColumns(header []string, dateColumn, weightColumn, unitColumn string) (importColumns, error) {
+	columns := importColumns{date: -1, weight: -1, unit: -1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) // Strip a UTF-8 BOM from Excel exports
+		switch name {
+		case strings.ToLower(dateColumn):
+			columns.date = i
+		case strings.ToLower(weightColumn):
+			columns.weight = i
+		case strings.ToLower(unitColumn):
+			columns.unit = i
+		}
+	}
+
+	if columns.date == -1 {
+		return columns, fmt.Errorf("date column %q not found in CSV header", dateColumn)
+	}
+	if columns.weight == -1 {
+		return columns, fmt.Errorf("weight column %q not found in CSV header", weightColumn)
+	}
+	return columns, nil
+}
+
+// parseImportRow validates a single CSV record and converts its weight to the profile unit.
+// Dates without an explicit offset are interpreted in the user's timezone.
+func parseImportRow(record []string, columns importColumns, dateLayouts []string, defaultUnit string, profileUnits int, loc *time.Location) (dto.ImportBodyweightRow, error) {
+	var row dto.ImportBodyweightRow
+
+	if columns.date >= len(record) || columns.weight >= len(record) {
+		return row, errors.New("row has fewer columns than the header")
+	}
+
+	rawDate := strings.TrimSpace(record[columns.date])
+	if rawDate == "" {
+		return row, errors.New("date is empty")
+	}
+	var at time.Time
+	var parsed bool
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, rawDate, loc); err == nil {
+			at, parsed = t, true
+			break
+		}
+	}
+	if !parsed {
+		return row, fmt.Errorf("unrecognized date %q", rawDate)
+	}
+	if at.After(time.Now()) {
+		return row, fmt.Errorf("date %q is in the future", rawDate)
+	}
+
+	// Accept a decimal comma as well, which is common in European exports.
+	rawWeight := strings.ReplaceAll(strings.TrimSpace(record[columns.weight]), ",", ".")
+	weight, err := strconv.ParseFloat(rawWeight, 64)
+	if err != nil {
+		return row, fmt.Errorf("invalid weight %q", record[columns.weight])
+	}
+	if weight <= 0 || weight > importMaxWeight {
+		return row, fmt.Errorf("weight %v is out of range", weight)
+	}
+
+	unit := defaultUnit
+	if columns.unit != -1 && columns.unit < len(record) && strings.TrimSpace(record[columns.unit]) != "" {
+		u, ok := normalizeWeightUnit(record[columns.unit])
+		if !ok {
+			return row, fmt.Errorf("unknown unit %q", record[columns.unit])
+		}
+		unit = u
+	}
+
+	// Bodyweights are stored in the user's profile unit.
+	switch {
+	case unit == "lb" && profileUnits != model.ProfileUnitsImperial:
+		weight = weight / kgToLb
+	case unit == "kg" && profileUnits == model.ProfileUnitsImperial:
+		weight = weight * kgToLb
+	}
+
+	row.At = at
+	row.Date = provider.DayKeyIn(at, loc)
+	row.Weight = math.Round(weight*100) / 100 // Round to two decimals
+	return row, nil
+}
+
+// normalizeWeightUnit maps the many spellings found in exports onto "kg" or "lb".
+func normalizeWeightUnit(raw string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "kg", "kgs", "kilogram", "kilograms":
+		return "kg", true
+	case "lb", "lbs", "pound", "pounds":
+		return "lb", true
+	}
+	return "", false
+}
+
+// formValueOrDefault returns the form value for name, or def if it is empty.
+func formValueOrDefault(c echo.Context, name, def string) string {
+	if v := strings.TrimSpace(c.FormValue(name)); v != "" {
+		return v
+	}
+	return def
+}
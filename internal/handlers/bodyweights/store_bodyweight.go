@@ -98,6 +98,12 @@ func (h *BodyweightHandler) StoreBodyweight(c echo.Context) error {
 		createdBodyweight.DeletedAt = nil
 	}
 
+	// Mark the bodyweight goal as reached (and notify the user) if this weight satisfies it.
+	// A failure here must not fail the bodyweight write itself, so it is only logged.
+	if err := CheckBodyweightGoalReached(ctx, h.DB, h.sq, h.EmailSender, userID, createdBodyweight.Weight); err != nil {
+		c.Logger().Errorf("StoreBodyweight: Failed to check bodyweight goal: %v", err)
+	}
+
 	// 5. Build the DTO response using the fetched model.Bodyweight.
 	response := dto.CreateBodyweightResponse{
 		Message:    "Bodyweight record created successfully.",
@@ -129,6 +129,12 @@ func (h *BodyweightHandler) UpdateBodyweight(c echo.Context) error {
 		updatedBodyweight.DeletedAt = nil
 	}
 
+	// Mark the bodyweight goal as reached (and notify the user) if this weight satisfies it.
+	// A failure here must not fail the bodyweight write itself, so it is only logged.
+	if err := CheckBodyweightGoalReached(ctx, h.DB, h.sq, h.EmailSender, userID, updatedBodyweight.Weight); err != nil {
+		c.Logger().Errorf("UpdateBodyweight: Failed to check bodyweight goal: %v", err)
+	}
+
 	// 6. Build the DTO response and return.
 	response := dto.UpdateBodyweightResponse{
 		Message:    "Bodyweight record updated successfully.",
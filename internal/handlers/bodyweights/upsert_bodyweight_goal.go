@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// UpsertBodyweightGoal sets the user's bodyweight goal. An existing active goal is
+// soft-deleted and replaced so the history of previous goals is kept.
+func (h *BodyweightHandler) UpsertBodyweightGoal(c echo.Context) error {
+	// 1. Get the authenticated user ID from the context.
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	// 2. Bind and validate the request body.
+	var req dto.UpsertBodyweightGoalRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	targetDate, err := time.Parse(dto.BodyweightGoalDateLayout, req.TargetDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid target_date, expected YYYY-MM-DD")
+	}
+
+	ctx := c.Request().Context()
+	now := time.Now()
+
+	// The target date is inclusive and interpreted in the user's timezone, so a goal ending today is still valid.
+	loc := provider.UserLocation(ctx, h.DB, h.sq, userID)
+	if provider.DateInLocation(targetDate, loc).AddDate(0, 0, 1).Before(now) {
+		return echo.NewHTTPError(http.StatusBadRequest, "target_date must not be in the past")
+	}
+
+	// 3. Resolve the start weight: explicit value from the request, otherwise the latest bodyweight.
+	var startWeight float64
+	if req.StartWeight != nil {
+		startWeight = *req.StartWeight
+	} else {
+		latestQuery, latestArgs, err := h.sq.Select("weight").
+			From("bodyweights").
+			Where(squirrel.And{
+				squirrel.Eq{"user_id": userID},
+				squirrel.Eq{"deleted_at": nil},
+			}).
+			OrderBy("created_at DESC").
+			Limit(1).
+			ToSql()
+		if err != nil {
+			c.Logger().Errorf("UpsertBodyweightGoal: Failed to build latest bodyweight query: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+		}
+		err = h.DB.QueryRowContext(ctx, latestQuery, latestArgs...).Scan(&startWeight)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return echo.NewHTTPError(http.StatusBadRequest, "Log a bodyweight or provide start_weight before setting a goal")
+			}
+			c.Logger().Errorf("UpsertBodyweightGoal: Failed to fetch latest bodyweight: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+		}
+	}
+
+	if startWeight == req.TargetWeight {
+		return echo.NewHTTPError(http.StatusBadRequest, "target_weight must differ from the current weight")
+	}
+
+	// 4. Replace any existing goal and insert the new one in a single transaction.
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+	}
+	defer tx.Rollback() // Rollback on error unless committed
+
+	deleteQuery, deleteArgs, err := h.sq.Update("bodyweight_goals").
+		Set("deleted_at", now).
+		Set("updated_at", now).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Eq{"deleted_at": nil},
+		}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to build replace goal query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+	}
+	if _, err = tx.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to replace existing goal: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+	}
+
+	insertQuery, insertArgs, err := h.sq.Insert("bodyweight_goals").
+		Columns("id", "user_id", "start_weight", "target_weight", "target_date", "weekly_rate", "created_at", "updated_at").
+		Values(uuid.New(), userID, startWeight, req.TargetWeight, targetDate, req.WeeklyRate, now, now).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to build insert goal query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+	}
+	if _, err = tx.ExecContext(ctx, insertQuery, insertArgs...); err != nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to insert goal: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+	}
+
+	if err = tx.Commit(); err != nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save bodyweight goal")
+	}
+
+	// 5. Re-fetch the goal and build the response with progress.
+	goal, err := FetchActiveBodyweightGoal(ctx, h.DB, h.sq, userID)
+	if err != nil || goal == nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to fetch saved goal: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Bodyweight goal saved, but failed to retrieve it")
+	}
+
+	goalResponse, err := BuildBodyweightGoalResponse(ctx, h.DB, h.sq, goal, now)
+	if err != nil {
+		c.Logger().Errorf("UpsertBodyweightGoal: Failed to compute goal progress: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Bodyweight goal saved, but failed to compute progress")
+	}
+
+	return c.JSON(http.StatusOK, dto.UpsertBodyweightGoalResponse{
+		Message: "Bodyweight goal saved successfully.",
+		Goal:    goalResponse,
+	})
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyExercise soft deletes an exercise and removes it from the search index. Workouts and
+// logged sets keep referencing the row, so the user's history stays intact.
+func (h *ExerciseHandler) DestroyExercise(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("DestroyExercise: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete exercise")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	if _, err := h.lockManageableExercise(ctx, c, tx, userID, exerciseID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	query, args, err := h.sq.Update("exercises").
+		Set("deleted_at", now).
+		Set("updated_at", now).
+		Where(squirrel.Eq{"id": exerciseID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyExercise: Failed to build update query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete exercise")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		c.Logger().Errorf("DestroyExercise: Failed to soft delete exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete exercise")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationDelete, exerciseID); err != nil {
+		c.Logger().Errorf("DestroyExercise: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete exercise")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("DestroyExercise: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete exercise")
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteExerciseResponse{
+		Message: "Exercise deleted successfully.",
+	})
+}
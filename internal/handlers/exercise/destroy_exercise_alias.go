@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyExerciseAlias removes an alias from an exercise (admin only) and re-syncs the
+// exercise to the search index.
+func (h *ExerciseHandler) DestroyExerciseAlias(c echo.Context) error {
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+	aliasID, err := uuid.Parse(c.Param("alias_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid alias ID format")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseAlias: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete alias")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	query, args, err := h.sq.Delete("exercise_aliases").
+		Where(squirrel.Eq{"id": aliasID, "exercise_id": exerciseID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseAlias: Failed to build delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete alias")
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseAlias: Failed to delete alias: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete alias")
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		c.Logger().Errorf("DestroyExerciseAlias: Failed to get rows affected: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete alias")
+	} else if n == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Alias not found")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseID); err != nil {
+		c.Logger().Errorf("DestroyExerciseAlias: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete alias")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("DestroyExerciseAlias: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete alias")
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteExerciseResponse{
+		Message: "Alias deleted successfully.",
+	})
+}
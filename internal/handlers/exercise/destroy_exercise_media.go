@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"rtglabs-go/dto"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyExerciseMedia removes a media attachment from an exercise and deletes the uploaded
+// file, if any. Owners manage their custom exercises and admins every exercise.
+func (h *ExerciseHandler) DestroyExerciseMedia(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+	mediaID, err := uuid.Parse(c.Param("media_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid media ID format")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseMedia: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete media")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	if _, err := h.lockManageableExercise(ctx, c, tx, userID, exerciseID); err != nil {
+		return err
+	}
+
+	query, args, err := h.sq.Delete("exercise_media").
+		Where(squirrel.Eq{"id": mediaID, "exercise_id": exerciseID}).
+		Suffix("RETURNING storage_key").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseMedia: Failed to build delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete media")
+	}
+	var storageKey sql.NullString
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&storageKey); err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Media not found")
+		}
+		c.Logger().Errorf("DestroyExerciseMedia: Failed to delete media: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete media")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("DestroyExerciseMedia: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete media")
+	}
+
+	// Best-effort: a leftover file is harmless once nothing points at it.
+	if storageKey.Valid && h.Storage != nil {
+		if err := h.Storage.Delete(ctx, storageKey.String); err != nil {
+			c.Logger().Warnf("DestroyExerciseMedia: Failed to delete file: %v", err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteExerciseResponse{
+		Message: "Media deleted successfully.",
+	})
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyExerciseNote deletes the user's note on an exercise.
+func (h *ExerciseHandler) DestroyExerciseNote(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	query, args, err := h.sq.Delete("exercise_notes").
+		Where(squirrel.Eq{"user_id": userID, "exercise_id": exerciseID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseNote: Failed to build delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete note")
+	}
+	res, err := h.DB.ExecContext(c.Request().Context(), query, args...)
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseNote: Failed to delete note: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete note")
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Note not found")
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteExerciseResponse{
+		Message: "Note deleted successfully.",
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyExerciseTranslation removes the translation of an exercise to the locale in the URL,
+// so that locale falls back to the default texts. Owners manage their custom exercises and
+// admins every exercise.
+func (h *ExerciseHandler) DestroyExerciseTranslation(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+	locale, err := parseTranslationLocale(c.Param("locale"))
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseTranslation: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete translation")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	if _, err := h.lockManageableExercise(ctx, c, tx, userID, exerciseID); err != nil {
+		return err
+	}
+
+	query, args, err := h.sq.Delete("exercise_translations").
+		Where(squirrel.Eq{"exercise_id": exerciseID, "locale": locale}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseTranslation: Failed to build delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete translation")
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		c.Logger().Errorf("DestroyExerciseTranslation: Failed to delete translation: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete translation")
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Translation not found")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseID); err != nil {
+		c.Logger().Errorf("DestroyExerciseTranslation: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete translation")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("DestroyExerciseTranslation: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete translation")
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteExerciseResponse{
+		Message: "Translation deleted successfully.",
+	})
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyMuscle removes a muscle from the catalog (admin only). Muscles still mapped to an
+// exercise can't be removed.
+func (h *ExerciseHandler) DestroyMuscle(c echo.Context) error {
+	muscleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid muscle ID format")
+	}
+
+	query, args, err := h.sq.Delete("muscles").
+		Where(squirrel.Eq{"id": muscleID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyMuscle: Failed to build delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete muscle")
+	}
+	res, err := h.DB.ExecContext(c.Request().Context(), query, args...)
+	if err != nil {
+		if provider.IsForeignKeyViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "The muscle is still mapped to exercises")
+		}
+		c.Logger().Errorf("DestroyMuscle: Failed to delete muscle: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete muscle")
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		c.Logger().Errorf("DestroyMuscle: Failed to get rows affected: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete muscle")
+	} else if n == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Muscle not found")
+	}
+
+	return c.JSON(http.StatusOK, dto.DeleteExerciseResponse{
+		Message: "Muscle deleted successfully.",
+	})
+}
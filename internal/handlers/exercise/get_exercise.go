@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/internal/middleware"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetExercise returns a single exercise visible to the user together with their usage stats.
+func (h *ExerciseHandler) GetExercise(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	ctx := c.Request().Context()
+	query, args, err := h.sq.Select(provider.ExerciseColumns...).
+		From("exercises").
+		Where(squirrel.And{squirrel.Eq{"id": exerciseID, "deleted_at": nil}, provider.ExerciseVisibleTo("", userID)}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to build select query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+	ex, err := provider.ScanExercise(h.DB.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+		}
+		c.Logger().Errorf("GetExercise: Failed to fetch exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+
+	aliases, err := provider.FetchExerciseAliases(ctx, h.DB, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to fetch aliases: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+	ex.Aliases = aliases[exerciseID]
+
+	muscles, err := provider.FetchExerciseMuscles(ctx, h.DB, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to fetch muscles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+	ex.Muscles = muscles[exerciseID]
+
+	instructions, err := provider.FetchExerciseInstructions(ctx, h.DB, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to fetch instructions: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+	ex.Instructions = instructions[exerciseID]
+
+	// Name, description and instructions are returned in the language picked by the Locale middleware.
+	locale := middleware.RequestLocale(c)
+	translations, err := provider.FetchLocalizedExercises(ctx, h.DB, h.sq, []uuid.UUID{exerciseID}, locale)
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to fetch translations: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+	ex.Localize(translations[exerciseID])
+
+	media, err := provider.FetchExerciseMedia(ctx, h.DB, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to fetch media: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+	mediaResponses := make([]dto.ExerciseMediaResponse, 0, len(media[exerciseID]))
+	for i := range media[exerciseID] {
+		mediaResponses = append(mediaResponses, toExerciseMediaResponse(&media[exerciseID][i]))
+	}
+
+	notes, err := provider.FetchExerciseNotes(ctx, h.DB, h.sq, userID, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to fetch note: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+
+	usage, err := h.fetchExerciseUsage(ctx, userID, exerciseID)
+	if err != nil {
+		c.Logger().Errorf("GetExercise: Failed to fetch usage stats: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve exercise")
+	}
+
+	exerciseResponse := toExerciseResponse(&ex)
+	exerciseResponse.Note = toExerciseNoteResponse(notes[exerciseID])
+
+	return c.JSON(http.StatusOK, dto.ExerciseDetailResponse{
+		ExerciseResponse: exerciseResponse,
+		Instructions:     toExerciseInstructionsResponse(ex.Instructions),
+		Media:            mediaResponses,
+		Usage:            usage,
+	})
+}
+
+// fetchExerciseUsage counts the user's workout templates containing the exercise and
+// aggregates the completed sets of their workout logs.
+func (h *ExerciseHandler) fetchExerciseUsage(ctx context.Context, userID, exerciseID uuid.UUID) (dto.ExerciseUsageResponse, error) {
+	var usage dto.ExerciseUsageResponse
+
+	workoutsQuery, workoutsArgs, err := h.sq.Select("COUNT(DISTINCT we.workout_id)").
+		From("workout_exercises AS we").
+		Join("workouts AS w ON w.id = we.workout_id").
+		Where(squirrel.Eq{
+			"we.exercise_id": exerciseID,
+			"w.user_id":      userID,
+			"we.deleted_at":  nil,
+			"w.deleted_at":   nil,
+		}).
+		ToSql()
+	if err != nil {
+		return usage, err
+	}
+	if err := h.DB.QueryRowContext(ctx, workoutsQuery, workoutsArgs...).Scan(&usage.WorkoutCount); err != nil {
+		return usage, err
+	}
+
+	setsQuery, setsArgs, err := h.sq.Select(
+		"COUNT(DISTINCT es.workout_log_id)",
+		"COUNT(es.id)",
+		"COALESCE(SUM(es.reps), 0)",
+		"COALESCE(SUM(es.weight * es.reps), 0)",
+		"MAX(es.weight)",
+		"MAX(COALESCE(es.finished_at, wl.finished_at, wl.started_at, es.created_at))",
+	).
+		From("exercise_sets AS es").
+		Join("workout_logs AS wl ON wl.id = es.workout_log_id").
+		Where(squirrel.Eq{
+			"es.exercise_id": exerciseID,
+			"es.status":      model.ExerciseSetStatusCompleted,
+			"wl.user_id":     userID,
+			"es.deleted_at":  nil,
+			"wl.deleted_at":  nil,
+		}).
+		ToSql()
+	if err != nil {
+		return usage, err
+	}
+	var (
+		maxWeight     sql.NullFloat64
+		lastPerformed sql.NullTime
+	)
+	err = h.DB.QueryRowContext(ctx, setsQuery, setsArgs...).Scan(
+		&usage.SessionCount, &usage.TotalSets, &usage.TotalReps, &usage.TotalVolume, &maxWeight, &lastPerformed,
+	)
+	if err != nil {
+		return usage, err
+	}
+	usage.MaxWeight = provider.NullFloat64ToFloat64Ptr(maxWeight)
+	usage.LastPerformedAt = provider.NullTimeToTimePtr(lastPerformed)
+	return usage, nil
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetExerciseAlternatives suggests exercises that can replace the given one, e.g. when a machine
+// is taken. Candidates are ranked by shared muscle group, movement type, force type and bodypart,
+// and boosted when the user has logged them before. ?equipment=dumbbell,cable limits the
+// suggestions to the equipment at hand (bodyweight exercises are always allowed).
+func (h *ExerciseHandler) GetExerciseAlternatives(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	var equipment []string
+	for _, param := range c.QueryParams()["equipment"] {
+		for _, value := range strings.Split(param, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if !model.IsExerciseVocabularyValue("equipment", value) {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid equipment value '"+value+"'")
+			}
+			equipment = append(equipment, value)
+		}
+	}
+
+	ctx := c.Request().Context()
+	query, args, err := h.sq.Select(provider.ExerciseColumns...).
+		From("exercises").
+		Where(squirrel.And{squirrel.Eq{"id": exerciseID, "deleted_at": nil}, provider.ExerciseVisibleTo("", userID)}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("GetExerciseAlternatives: Failed to build select query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find alternatives")
+	}
+	source, err := provider.ScanExercise(h.DB.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+		}
+		c.Logger().Errorf("GetExerciseAlternatives: Failed to fetch exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find alternatives")
+	}
+
+	alternatives, err := provider.FindExerciseAlternatives(ctx, h.DB, h.sq, userID, &source, equipment, limit)
+	if err != nil {
+		c.Logger().Errorf("GetExerciseAlternatives: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to find alternatives")
+	}
+
+	data := make([]dto.ExerciseAlternativeResponse, 0, len(alternatives))
+	for i := range alternatives {
+		data = append(data, dto.ExerciseAlternativeResponse{
+			Exercise:    toExerciseResponse(&alternatives[i].Exercise),
+			Score:       alternatives[i].Score,
+			Reasons:     alternatives[i].Reasons,
+			TimesLogged: alternatives[i].TimesLogged,
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.ListExerciseAlternativeResponse{
+		Exercise: toExerciseResponse(&source),
+		Data:     data,
+	})
+}
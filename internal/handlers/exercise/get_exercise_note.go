@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// GetExerciseNote returns the user's note on an exercise.
+func (h *ExerciseHandler) GetExerciseNote(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	notes, err := provider.FetchExerciseNotes(c.Request().Context(), h.DB, h.sq, userID, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("GetExerciseNote: Failed to fetch note: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve note")
+	}
+	note := notes[exerciseID]
+	if note == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Note not found")
+	}
+	return c.JSON(http.StatusOK, toExerciseNoteResponse(note))
+}
+
+// toExerciseNoteResponse converts a note, which may be nil, to its response.
+func toExerciseNoteResponse(n *model.ExerciseNote) *dto.ExerciseNoteResponse {
+	if n == nil {
+		return nil
+	}
+	res := &dto.ExerciseNoteResponse{Note: n.Note, PinnedCues: n.PinnedCues, UpdatedAt: n.UpdatedAt}
+	if res.PinnedCues == nil {
+		res.PinnedCues = []string{}
+	}
+	return res
+}
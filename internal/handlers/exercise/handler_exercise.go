@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"net/http"
 	"rtglabs-go/dto"
 	"rtglabs-go/model"
+	"rtglabs-go/provider"
 	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 	"github.com/typesense/typesense-go/v3/typesense"
 )
 
@@ -15,14 +20,18 @@ type ExerciseHandler struct {
 	DB              *sql.DB
 	sq              squirrel.StatementBuilderType
 	TypesenseClient *typesense.Client
+	Searcher        provider.ExerciseSearcher // Backend used by IndexExercise
+	Storage         provider.Storage          // Backend for uploaded exercise media
 }
 
 // NewExerciseHandler function (no change)
-func NewExerciseHandler(db *sql.DB, tsClient *typesense.Client) *ExerciseHandler {
+func NewExerciseHandler(db *sql.DB, tsClient *typesense.Client, searcher provider.ExerciseSearcher, storage provider.Storage) *ExerciseHandler {
 	return &ExerciseHandler{
 		DB:              db,
 		sq:              squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 		TypesenseClient: tsClient,
+		Searcher:        searcher,
+		Storage:         storage,
 	}
 }
 
@@ -34,10 +43,86 @@ func toExerciseResponse(ex *model.Exercise) dto.ExerciseResponse {
 	}
 
 	return dto.ExerciseResponse{
-		ID:        ex.ID,
-		Name:      ex.Name,
-		CreatedAt: ex.CreatedAt,
-		UpdatedAt: ex.UpdatedAt,
-		DeletedAt: deletedAt,
+		ID:           ex.ID,
+		Name:         ex.Name,
+		Slug:         ex.Slug,
+		OwnerID:      ex.OwnerID,
+		IsCustom:     ex.IsCustom(),
+		Description:  provider.StringPtrToString(ex.Description),
+		Position:     provider.StringPtrToString(ex.Position),
+		ForceType:    provider.StringPtrToString(ex.ForceType),
+		Difficulty:   provider.StringPtrToString(ex.Difficulty),
+		MovementType: provider.StringPtrToString(ex.MovementType),
+		MuscleGroup:  provider.StringPtrToString(ex.MuscleGroup),
+		Equipment:    provider.StringPtrToString(ex.Equipment),
+		Bodypart:     provider.StringPtrToString(ex.Bodypart),
+		TrackingType: ex.TrackingType,
+		CreatedAt:    ex.CreatedAt,
+		UpdatedAt:    ex.UpdatedAt,
+		DeletedAt:    deletedAt,
+		Aliases:      ex.Aliases,
+		Muscles:      toExerciseMuscleResponses(ex.Muscles),
 	}
 }
+
+// lockManageableExercise loads a live exercise with FOR UPDATE and checks that userID may modify
+// it: owners manage their custom exercises and admins manage every exercise. Custom exercises of
+// other users are reported as not found so their existence isn't leaked.
+func (h *ExerciseHandler) lockManageableExercise(ctx context.Context, c echo.Context, tx *sql.Tx, userID, exerciseID uuid.UUID) (*model.Exercise, error) {
+	query, args, err := h.sq.Select(provider.ExerciseColumns...).
+		From("exercises").
+		Where(squirrel.Eq{"id": exerciseID, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("lockManageableExercise: Failed to build select query: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load exercise")
+	}
+	ex, err := provider.ScanExercise(tx.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+		}
+		c.Logger().Errorf("lockManageableExercise: Failed to fetch exercise: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load exercise")
+	}
+	if ex.OwnerID != nil && *ex.OwnerID == userID {
+		return &ex, nil
+	}
+
+	isAdmin, err := provider.IsAdmin(ctx, h.DB, h.sq, userID)
+	if err != nil {
+		c.Logger().Errorf("lockManageableExercise: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load exercise")
+	}
+	switch {
+	case isAdmin:
+		return &ex, nil
+	case ex.IsCustom():
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+	default:
+		return nil, echo.NewHTTPError(http.StatusForbidden, "Only admins can modify global exercises")
+	}
+}
+
+// ensureVisibleExercise checks that exerciseID is a live exercise userID may use: the global
+// catalog or one of their custom exercises. Other exercises are reported as not found.
+func (h *ExerciseHandler) ensureVisibleExercise(ctx context.Context, c echo.Context, userID, exerciseID uuid.UUID) error {
+	query, args, err := h.sq.Select("1").
+		From("exercises").
+		Where(squirrel.And{squirrel.Eq{"id": exerciseID, "deleted_at": nil}, provider.ExerciseVisibleTo("", userID)}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("ensureVisibleExercise: Failed to build select query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load exercise")
+	}
+	var found int
+	if err := h.DB.QueryRowContext(ctx, query, args...).Scan(&found); err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+		}
+		c.Logger().Errorf("ensureVisibleExercise: Failed to fetch exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load exercise")
+	}
+	return nil
+}
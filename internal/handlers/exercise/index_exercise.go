@@ -2,23 +2,30 @@
 package handlers
 
 import (
-	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"rtglabs-go/dto"
+	"rtglabs-go/internal/middleware"
+	"rtglabs-go/model"
 	"rtglabs-go/provider"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"github.com/typesense/typesense-go/v3/typesense/api"
-	"github.com/typesense/typesense-go/v3/typesense/api/pointer"
 )
 
-// IndexExercise handler
+// IndexExercise handler. The search itself is delegated to the configured ExerciseSearcher
+// (Typesense, Postgres, or Typesense with automatic failover to Postgres).
+// Results can be filtered on every provider.ExerciseFilterFields column, e.g.
+// ?muscle_group=chest,triceps&equipment=barbell; the response carries the facet counts.
 func (h *ExerciseHandler) IndexExercise(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
 	// ... (pagination parameters and searchName extraction - no change)
 	page, _ := strconv.Atoi(c.QueryParam("page"))
 	if page < 1 {
@@ -35,136 +42,48 @@ func (h *ExerciseHandler) IndexExercise(c echo.Context) error {
 
 	searchName := strings.TrimSpace(c.QueryParam("q"))
 
-	var pagination provider.PaginationResponse
-
-	searchParams := &api.SearchCollectionParams{
-		Q:       pointer.String(searchName),
-		QueryBy: pointer.String("name,description"),
-		Page:    pointer.Int(page),
-		PerPage: pointer.Int(limit),
+	filters, err := parseExerciseFilters(c.QueryParams())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	if searchName == "" {
-		searchParams.SortBy = pointer.String("created_at:desc")
-	}
+	// Resolved through the Locale middleware; hits come back with their names in this language.
+	locale := middleware.RequestLocale(c)
 
-	tsClient := h.TypesenseClient
-	searchRes, err := tsClient.Collection("exercises").Documents().Search(context.Background(), searchParams)
+	searchRes, err := h.Searcher.Search(c.Request().Context(), provider.ExerciseSearchQuery{
+		Q:       searchName,
+		UserID:  userID,
+		Page:    page,
+		PerPage: limit,
+		Filters: filters,
+		Locale:  locale,
+	})
 	if err != nil {
-		fmt.Printf("ERROR: Typesense search failed: %v\n", err)
+		c.Logger().Errorf("IndexExercise: Exercise search failed: %v", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to search exercises")
 	}
+	// Lets clients and operators see when results come from the fallback backend.
+	c.Response().Header().Set("X-Search-Backend", searchRes.Backend)
 
-	fmt.Printf("DEBUG: Typesense search successful. Total found: %d\n", *searchRes.Found)
-	fmt.Printf("DEBUG: Number of hits returned: %d\n", len(*searchRes.Hits))
-
-	if searchRes.Hits == nil || len(*searchRes.Hits) == 0 {
-		fmt.Println("DEBUG: searchRes.Hits is nil or empty, returning empty response.")
-		pagination = provider.GeneratePaginationData(int(*searchRes.Found), page, limit, c.Request().URL.Path, c.QueryParams())
-		zero := 0
-		pagination.To = &zero
-		return c.JSON(http.StatusOK, dto.ListExerciseResponse{
-			Data:               []dto.ExerciseResponse{},
-			PaginationResponse: pagination,
-		})
+	// The muscle mapping isn't part of the search documents, so it is loaded for the page.
+	hitIDs := make([]uuid.UUID, 0, len(searchRes.Hits))
+	for _, hit := range searchRes.Hits {
+		hitIDs = append(hitIDs, hit.ID)
 	}
-
-	var exercisesResponse []dto.ExerciseResponse
-	for i, hit := range *searchRes.Hits {
-		fmt.Printf("DEBUG: Processing hit %d. Raw document: %+v\n", i, *hit.Document)
-
-		document := *hit.Document
-
-		// --- UUID Extraction ---
-		uuidStr, ok := document["uuid"].(string)
-		if !ok {
-			fmt.Printf("WARN: Hit %d: 'uuid' field missing or not a string. Value: %+v\n", i, document["uuid"])
-			continue
-		}
-		uid, err := uuid.Parse(uuidStr)
-		if err != nil {
-			fmt.Printf("WARN: Hit %d: Invalid UUID string '%s'. Error: %v\n", i, uuidStr, err)
-			continue
-		}
-
-		// --- Name Extraction ---
-		name, ok := document["name"].(string)
-		if !ok {
-			fmt.Printf("WARN: Hit %d (UUID: %s): 'name' missing or not string. Value: %+v\n", i, uuidStr, document["name"])
-			continue
-		}
-
-		// --- NEW FIELD EXTRACTION ---
-		// Use empty string as default if field is missing or not a string
-		description, _ := document["description"].(string)
-		position, _ := document["position"].(string)
-		forceType, _ := document["force_type"].(string)
-		difficulty, _ := document["difficulty"].(string)
-		movementType, _ := document["movement_type"].(string)
-		muscleGroup, _ := document["muscle_group"].(string)
-		equipment, _ := document["equipment"].(string)
-		bodypart, _ := document["bodypart"].(string)
-
-		// --- Timestamps ---
-		createdAtUnix, ok := document["created_at"].(float64)
-		var createdAt time.Time
-		if ok {
-			createdAt = time.Unix(int64(createdAtUnix), 0)
-		} else {
-			if val, exists := document["created_at"]; exists {
-				fmt.Printf("WARN: Hit %d (UUID: %s): 'created_at' not float64. Actual type: %T, Value: %+v\n", i, uuidStr, val, val)
-			} else {
-				fmt.Printf("WARN: Hit %d (UUID: %s): 'created_at' missing.\n", i, uuidStr)
-			}
-		}
-
-		updatedAtUnix, ok := document["updated_at"].(float64)
-		var updatedAt time.Time
-		if ok {
-			updatedAt = time.Unix(int64(updatedAtUnix), 0)
-		} else {
-			if val, exists := document["updated_at"]; exists {
-				fmt.Printf("WARN: Hit %d (UUID: %s): 'updated_at' not float64. Actual type: %T, Value: %+v\n", i, uuidStr, val, val)
-			} else {
-				fmt.Printf("WARN: Hit %d (UUID: %s): 'updated_at' missing.\n", i, uuidStr)
-			}
-		}
-
-		var deletedAt *time.Time
-		if deletedAtUnix, ok := document["deleted_at"].(float64); ok && deletedAtUnix > 0 {
-			t := time.Unix(int64(deletedAtUnix), 0)
-			deletedAt = &t
-		} else {
-			if val, exists := document["deleted_at"]; exists {
-				fmt.Printf("DEBUG: Hit %d (UUID: %s): 'deleted_at' not float64 or 0. Actual type: %T, Value: %+v\n", i, uuidStr, val, val)
-			} else {
-				fmt.Printf("DEBUG: Hit %d (UUID: %s): 'deleted_at' missing or nil.\n", i, uuidStr)
-			}
-		}
-
-		exercisesResponse = append(exercisesResponse, dto.ExerciseResponse{
-			ID:           uid,
-			Name:         name,
-			Description:  description,  // <--- ASSIGN THIS
-			Position:     position,     // <--- ASSIGN THIS
-			ForceType:    forceType,    // <--- ASSIGN THIS
-			Difficulty:   difficulty,   // <--- ASSIGN THIS
-			MovementType: movementType, // <--- ASSIGN THIS
-			MuscleGroup:  muscleGroup,  // <--- ASSIGN THIS
-			Equipment:    equipment,    // <--- ASSIGN THIS
-			Bodypart:     bodypart,     // <--- ASSIGN THIS
-			CreatedAt:    createdAt,
-			UpdatedAt:    updatedAt,
-			DeletedAt:    deletedAt,
-		})
-		fmt.Printf("DEBUG: Successfully added doc %d (UUID: %s) to response slice.\n", i, uuidStr)
+	muscles, err := provider.FetchExerciseMuscles(c.Request().Context(), h.DB, h.sq, hitIDs)
+	if err != nil {
+		c.Logger().Errorf("IndexExercise: Failed to fetch muscles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to search exercises")
 	}
 
-	totalCount := 0
-	if searchRes.Found != nil {
-		totalCount = int(*searchRes.Found)
+	exercisesResponse := make([]dto.ExerciseResponse, 0, len(searchRes.Hits))
+	for _, hit := range searchRes.Hits {
+		res := toExerciseSearchResponse(hit)
+		res.Muscles = toExerciseMuscleResponses(muscles[hit.ID])
+		exercisesResponse = append(exercisesResponse, res)
 	}
-	pagination = provider.GeneratePaginationData(totalCount, page, limit, c.Request().URL.Path, c.QueryParams())
+
+	pagination := provider.GeneratePaginationData(searchRes.Found, page, limit, c.Request().URL.Path, c.QueryParams())
 
 	if len(exercisesResponse) > 0 {
 		tempTo := offset + len(exercisesResponse)
@@ -176,6 +95,66 @@ func (h *ExerciseHandler) IndexExercise(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, dto.ListExerciseResponse{
 		Data:               exercisesResponse,
+		Facets:             toExerciseFacetResponses(searchRes.Facets),
 		PaginationResponse: pagination,
 	})
 }
+
+// parseExerciseFilters reads the facet filters from the query string. A field may be repeated
+// or hold comma-separated values; every value must belong to the field's vocabulary.
+func parseExerciseFilters(params url.Values) (map[string][]string, error) {
+	filters := map[string][]string{}
+	for _, field := range provider.ExerciseFilterFields {
+		for _, param := range params[field] {
+			for _, value := range strings.Split(param, ",") {
+				value = strings.TrimSpace(value)
+				if value == "" {
+					continue
+				}
+				if !model.IsExerciseVocabularyValue(field, value) {
+					return nil, fmt.Errorf("Invalid %s filter value '%s'", field, value)
+				}
+				filters[field] = append(filters[field], value)
+			}
+		}
+	}
+	return filters, nil
+}
+
+// toExerciseFacetResponses maps the search facets onto the API response.
+func toExerciseFacetResponses(facets []provider.ExerciseFacet) []dto.ExerciseFacetResponse {
+	responses := make([]dto.ExerciseFacetResponse, 0, len(facets))
+	for _, facet := range facets {
+		counts := make([]dto.ExerciseFacetCountResponse, 0, len(facet.Counts))
+		for _, count := range facet.Counts {
+			counts = append(counts, dto.ExerciseFacetCountResponse{Value: count.Value, Count: count.Count})
+		}
+		responses = append(responses, dto.ExerciseFacetResponse{Field: facet.Field, Counts: counts})
+	}
+	return responses
+}
+
+// toExerciseSearchResponse maps a search hit onto the API response.
+func toExerciseSearchResponse(hit provider.ExerciseSearchHit) dto.ExerciseResponse {
+	return dto.ExerciseResponse{
+		ID:           hit.ID,
+		Name:         hit.Name,
+		OwnerID:      hit.OwnerID,
+		IsCustom:     hit.OwnerID != nil,
+		Description:  hit.Description,
+		Position:     hit.Position,
+		ForceType:    hit.ForceType,
+		Difficulty:   hit.Difficulty,
+		MovementType: hit.MovementType,
+		MuscleGroup:  hit.MuscleGroup,
+		Equipment:    hit.Equipment,
+		Bodypart:     hit.Bodypart,
+		TrackingType: hit.TrackingType,
+		CreatedAt:    hit.CreatedAt,
+		UpdatedAt:    hit.UpdatedAt,
+		DeletedAt:    hit.DeletedAt,
+		Aliases:      hit.Aliases,
+		MatchedAlias: hit.MatchedAlias,
+		Highlights:   hit.Highlights,
+	}
+}
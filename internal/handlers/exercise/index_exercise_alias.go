@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// IndexExerciseAlias lists the aliases of an exercise (admin only).
+func (h *ExerciseHandler) IndexExerciseAlias(c echo.Context) error {
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	query, args, err := h.sq.Select("id", "exercise_id", "alias", "created_at", "updated_at").
+		From("exercise_aliases").
+		Where(squirrel.Eq{"exercise_id": exerciseID}).
+		OrderBy("LOWER(alias)").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("IndexExerciseAlias: Failed to build select query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve aliases")
+	}
+	rows, err := h.DB.QueryContext(c.Request().Context(), query, args...)
+	if err != nil {
+		c.Logger().Errorf("IndexExerciseAlias: Failed to query aliases: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve aliases")
+	}
+	defer rows.Close()
+
+	aliases := make([]dto.ExerciseAliasResponse, 0)
+	for rows.Next() {
+		var alias model.ExerciseAlias
+		if err := rows.Scan(&alias.ID, &alias.ExerciseID, &alias.Alias, &alias.CreatedAt, &alias.UpdatedAt); err != nil {
+			c.Logger().Errorf("IndexExerciseAlias: Failed to scan alias: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve aliases")
+		}
+		aliases = append(aliases, toExerciseAliasResponse(&alias))
+	}
+	if err := rows.Err(); err != nil {
+		c.Logger().Errorf("IndexExerciseAlias: Rows error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve aliases")
+	}
+
+	return c.JSON(http.StatusOK, dto.ListExerciseAliasResponse{Data: aliases})
+}
+
+func toExerciseAliasResponse(alias *model.ExerciseAlias) dto.ExerciseAliasResponse {
+	return dto.ExerciseAliasResponse{
+		ID:         alias.ID,
+		ExerciseID: alias.ExerciseID,
+		Alias:      alias.Alias,
+		CreatedAt:  alias.CreatedAt,
+		UpdatedAt:  alias.UpdatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// IndexExerciseTranslation lists the translations of an exercise visible to the user.
+func (h *ExerciseHandler) IndexExerciseTranslation(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	ctx := c.Request().Context()
+	if err := h.ensureVisibleExercise(ctx, c, userID, exerciseID); err != nil {
+		return err
+	}
+
+	translations, err := provider.FetchExerciseTranslations(ctx, h.DB, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("IndexExerciseTranslation: Failed to fetch translations: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve translations")
+	}
+
+	data := make([]dto.ExerciseTranslationResponse, 0, len(translations[exerciseID]))
+	for i := range translations[exerciseID] {
+		data = append(data, toExerciseTranslationResponse(&translations[exerciseID][i]))
+	}
+	return c.JSON(http.StatusOK, dto.ListExerciseTranslationResponse{Data: data})
+}
+
+func toExerciseTranslationResponse(t *model.ExerciseTranslation) dto.ExerciseTranslationResponse {
+	res := dto.ExerciseTranslationResponse{
+		Locale:         t.Locale,
+		Name:           t.Name,
+		Description:    t.Description,
+		Steps:          t.Steps,
+		FormCues:       t.FormCues,
+		CommonMistakes: t.CommonMistakes,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+	if res.Steps == nil {
+		res.Steps = []string{}
+	}
+	if res.FormCues == nil {
+		res.FormCues = []string{}
+	}
+	if res.CommonMistakes == nil {
+		res.CommonMistakes = []string{}
+	}
+	return res
+}
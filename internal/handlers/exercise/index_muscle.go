@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/labstack/echo/v4"
+)
+
+// muscleSlugPattern is the format of muscle slugs, e.g. "front_delts".
+var muscleSlugPattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// IndexMuscle lists the muscle catalog, grouped by muscle group.
+func (h *ExerciseHandler) IndexMuscle(c echo.Context) error {
+	query, args, err := h.sq.Select(provider.MuscleColumns...).
+		From("muscles").
+		OrderBy("muscle_group NULLS LAST", "name").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("IndexMuscle: Failed to build select query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve muscles")
+	}
+	rows, err := h.DB.QueryContext(c.Request().Context(), query, args...)
+	if err != nil {
+		c.Logger().Errorf("IndexMuscle: Failed to query muscles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve muscles")
+	}
+	defer rows.Close()
+
+	muscles := make([]dto.MuscleResponse, 0)
+	for rows.Next() {
+		muscle, err := provider.ScanMuscle(rows)
+		if err != nil {
+			c.Logger().Errorf("IndexMuscle: Failed to scan muscle: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve muscles")
+		}
+		muscles = append(muscles, toMuscleResponse(&muscle))
+	}
+	if err := rows.Err(); err != nil {
+		c.Logger().Errorf("IndexMuscle: Rows error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve muscles")
+	}
+
+	return c.JSON(http.StatusOK, dto.ListMuscleResponse{Data: muscles})
+}
+
+func toMuscleResponse(m *model.Muscle) dto.MuscleResponse {
+	return dto.MuscleResponse{
+		ID:          m.ID,
+		Slug:        m.Slug,
+		Name:        m.Name,
+		MuscleGroup: m.MuscleGroup,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func toExerciseMuscleResponses(muscles []model.ExerciseMuscle) []dto.ExerciseMuscleResponse {
+	if len(muscles) == 0 {
+		return nil
+	}
+	responses := make([]dto.ExerciseMuscleResponse, 0, len(muscles))
+	for _, em := range muscles {
+		responses = append(responses, dto.ExerciseMuscleResponse{
+			MuscleID:    em.MuscleID,
+			Slug:        em.Muscle.Slug,
+			Name:        em.Muscle.Name,
+			MuscleGroup: em.Muscle.MuscleGroup,
+			Role:        em.Role,
+			Weight:      em.Weight,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// exerciseReferenceTables are the tables whose exercise_id is re-pointed when exercises are merged.
+var exerciseReferenceTables = []string{
+	"workout_exercises",
+	"exercise_instances",
+	"logged_exercise_instances",
+	"exercise_sets",
+}
+
+// MergeExercise merges a duplicate exercise into another one (admin only). In one transaction
+// the history of the source is re-pointed to the target, the source name and aliases become
+// aliases of the target, user notes and the content the target lacks are carried over, the
+// source is soft deleted and both are re-synced to the search index.
+func (h *ExerciseHandler) MergeExercise(c echo.Context) error {
+	sourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	var req dto.MergeExerciseRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if req.IntoID == sourceID {
+		return echo.NewHTTPError(http.StatusBadRequest, "An exercise can't be merged into itself")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("MergeExercise: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	// Both rows are locked in id order so concurrent merges can't deadlock.
+	query, args, err := h.sq.Select(provider.ExerciseColumns...).
+		From("exercises").
+		Where(squirrel.Eq{"id": []uuid.UUID{sourceID, req.IntoID}, "deleted_at": nil}).
+		OrderBy("id").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("MergeExercise: Failed to build select query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		c.Logger().Errorf("MergeExercise: Failed to fetch exercises: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+	var source, target *model.Exercise
+	for rows.Next() {
+		ex, err := provider.ScanExercise(rows)
+		if err != nil {
+			rows.Close()
+			c.Logger().Errorf("MergeExercise: Failed to scan exercise: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+		if ex.ID == sourceID {
+			source = &ex
+		} else {
+			target = &ex
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		c.Logger().Errorf("MergeExercise: Rows error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+	if source == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+	}
+	if target == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Target exercise not found")
+	}
+	// A custom target is only visible to its owner, so it may only absorb that owner's exercises.
+	if target.IsCustom() && (!source.IsCustom() || *source.OwnerID != *target.OwnerID) {
+		return echo.NewHTTPError(http.StatusConflict, "Exercises can only be merged into a global exercise or a custom exercise of the same owner")
+	}
+
+	moved := make(map[string]int64, len(exerciseReferenceTables))
+	now := time.Now()
+	for _, table := range exerciseReferenceTables {
+		query, args, err := h.sq.Update(table).
+			Set("exercise_id", target.ID).
+			Set("updated_at", now).
+			Where(squirrel.Eq{"exercise_id": source.ID}).
+			ToSql()
+		if err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to build %s update: %v", table, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to re-point %s: %v", table, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+		moved[table], _ = res.RowsAffected()
+	}
+
+	// Carry over what the target doesn't have yet. Where both exercises have content the target's
+	// wins, except user notes, which are concatenated so nobody loses what they wrote.
+	for _, content := range mergeExerciseContent(h.sq, source.ID, target.ID, now) {
+		query, args, err := content.statement.ToSql()
+		if err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to build %s merge: %v", content.table, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to merge %s: %v", content.table, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+		moved[content.table], _ = res.RowsAffected()
+	}
+
+	// The source name and aliases stay searchable as aliases of the target. Aliases the target
+	// already has are skipped through the unique index.
+	names := []string{}
+	if !strings.EqualFold(source.Name, target.Name) {
+		names = append(names, source.Name)
+	}
+	sourceAliases, err := provider.FetchExerciseAliases(ctx, tx, h.sq, []uuid.UUID{source.ID})
+	if err != nil {
+		c.Logger().Errorf("MergeExercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+	for _, alias := range sourceAliases[source.ID] {
+		if !strings.EqualFold(alias, target.Name) {
+			names = append(names, alias)
+		}
+	}
+	for _, name := range names {
+		query, args, err := h.sq.Insert("exercise_aliases").
+			Columns("id", "exercise_id", "alias", "created_at", "updated_at").
+			Values(uuid.New(), target.ID, name, now, now).
+			Suffix("ON CONFLICT DO NOTHING").
+			ToSql()
+		if err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to build alias insert: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to insert alias %q: %v", name, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+	}
+
+	statements := []squirrel.Sqlizer{
+		h.sq.Delete("exercise_aliases").Where(squirrel.Eq{"exercise_id": source.ID}),
+		h.sq.Delete("exercise_notes").Where(squirrel.Eq{"exercise_id": source.ID}), // Copied onto the target above
+		h.sq.Update("exercises").Set("deleted_at", now).Set("updated_at", now).Where(squirrel.Eq{"id": source.ID}),
+		h.sq.Update("exercises").Set("updated_at", now).Where(squirrel.Eq{"id": target.ID}),
+	}
+	for _, statement := range statements {
+		query, args, err := statement.ToSql()
+		if err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to build query: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			c.Logger().Errorf("MergeExercise: Failed to execute %q: %v", query, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+		}
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationDelete, source.ID); err != nil {
+		c.Logger().Errorf("MergeExercise: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, target.ID); err != nil {
+		c.Logger().Errorf("MergeExercise: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+
+	targetAliases, err := provider.FetchExerciseAliases(ctx, tx, h.sq, []uuid.UUID{target.ID})
+	if err != nil {
+		c.Logger().Errorf("MergeExercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+	target.Aliases = targetAliases[target.ID]
+	target.UpdatedAt = now
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("MergeExercise: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to merge exercises")
+	}
+
+	return c.JSON(http.StatusOK, dto.MergeExerciseResponse{
+		Message:  "Exercises merged successfully",
+		Exercise: toExerciseResponse(target),
+		Moved:    moved,
+	})
+}
+
+// mergeStatement is one step of carrying an exercise's own content over to the merge target.
+type mergeStatement struct {
+	table     string
+	statement squirrel.Sqlizer
+}
+
+// mergeExerciseContent returns the statements that carry the source's notes, muscles,
+// instructions, translations and media over to the target. Catalog content is only moved when
+// the target has none for the same key (all muscles count as one key since their weights are
+// relative to each other); media is appended after the target's own. Rows the target already
+// covers stay with the soft-deleted source.
+func mergeExerciseContent(sq squirrel.StatementBuilderType, sourceID, targetID uuid.UUID, now time.Time) []mergeStatement {
+	// Inner selects use "?" placeholders; the outer builder numbers them.
+	sourceNotes := squirrel.Select().
+		Column("gen_random_uuid()").
+		Column("user_id").
+		Column(squirrel.Expr("?::uuid", targetID)).
+		Columns("note", "pinned_cues", "created_at").
+		Column(squirrel.Expr("?::timestamptz", now)).
+		From("exercise_notes").
+		Where(squirrel.Eq{"exercise_id": sourceID})
+
+	return []mergeStatement{
+		{"exercise_notes", sq.Insert("exercise_notes").
+			Columns("id", "user_id", "exercise_id", "note", "pinned_cues", "created_at", "updated_at").
+			Select(sourceNotes).
+			Suffix("ON CONFLICT (user_id, exercise_id) DO UPDATE SET " +
+				"note = CASE WHEN exercise_notes.note = '' THEN EXCLUDED.note " +
+				"WHEN EXCLUDED.note = '' OR EXCLUDED.note = exercise_notes.note THEN exercise_notes.note " +
+				"ELSE exercise_notes.note || E'\\n\\n' || EXCLUDED.note END, " +
+				"pinned_cues = CASE WHEN cardinality(exercise_notes.pinned_cues) = 0 THEN EXCLUDED.pinned_cues ELSE exercise_notes.pinned_cues END, " +
+				"updated_at = EXCLUDED.updated_at")},
+		{"exercise_muscles", sq.Update("exercise_muscles").
+			Set("exercise_id", targetID).
+			Set("updated_at", now).
+			Where(squirrel.Eq{"exercise_id": sourceID}).
+			Where(squirrel.Expr("NOT EXISTS (SELECT 1 FROM exercise_muscles m WHERE m.exercise_id = ?)", targetID))},
+		{"exercise_instructions", sq.Update("exercise_instructions").
+			Set("exercise_id", targetID).
+			Set("updated_at", now).
+			Where(squirrel.Eq{"exercise_id": sourceID}).
+			Where(squirrel.Expr("NOT EXISTS (SELECT 1 FROM exercise_instructions i WHERE i.exercise_id = ?)", targetID))},
+		{"exercise_translations", sq.Update("exercise_translations").
+			Set("exercise_id", targetID).
+			Set("updated_at", now).
+			Where(squirrel.Eq{"exercise_id": sourceID}).
+			Where(squirrel.Expr("NOT EXISTS (SELECT 1 FROM exercise_translations t WHERE t.exercise_id = ? AND t.locale = exercise_translations.locale)", targetID))},
+		{"exercise_media", sq.Update("exercise_media").
+			Set("exercise_id", targetID).
+			Set("position", squirrel.Expr("position + (SELECT COALESCE(MAX(m.position) + 1, 0) FROM exercise_media m WHERE m.exercise_id = ?)", targetID)).
+			Set("updated_at", now).
+			Where(squirrel.Eq{"exercise_id": sourceID})},
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// PersonalizeExercises fills in the per-user parts of exercises nested in other responses: their
+// names translated to locale and the user's notes. It is exported for the workout and workout log
+// handlers (dto imports provider, so it can't live there). It is best-effort: whatever could be
+// fetched is applied and the failures are returned for the caller to log.
+func PersonalizeExercises(ctx context.Context, db provider.SQLQuerier, sq squirrel.StatementBuilderType, userID uuid.UUID, locale string, exercises []*dto.ExerciseResponse) error {
+	if len(exercises) == 0 {
+		return nil
+	}
+
+	exerciseIDs := make([]uuid.UUID, 0, len(exercises))
+	for _, ex := range exercises {
+		exerciseIDs = append(exerciseIDs, ex.ID)
+	}
+	var errs []error
+	names, err := provider.FetchLocalizedExerciseNames(ctx, db, sq, exerciseIDs, locale)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch translated names: %w", err))
+	}
+	notes, err := provider.FetchExerciseNotes(ctx, db, sq, userID, exerciseIDs)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to fetch notes: %w", err))
+	}
+	for _, ex := range exercises {
+		if name, ok := names[ex.ID]; ok {
+			ex.Name = name
+		}
+		if note := notes[ex.ID]; note != nil {
+			pinnedCues := note.PinnedCues
+			if pinnedCues == nil {
+				pinnedCues = []string{}
+			}
+			ex.Note = &dto.ExerciseNoteResponse{Note: note.Note, PinnedCues: pinnedCues, UpdatedAt: note.UpdatedAt}
+		}
+	}
+	return errors.Join(errs...)
+}
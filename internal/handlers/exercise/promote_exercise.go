@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// PromoteExercise moves a user's custom exercise into the global catalog (admin only).
+// Workouts and logs keep referencing the same row, so nothing else has to change.
+func (h *ExerciseHandler) PromoteExercise(c echo.Context) error {
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("PromoteExercise: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to promote exercise")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	query, args, err := h.sq.Select(provider.ExerciseColumns...).
+		From("exercises").
+		Where(squirrel.Eq{"id": exerciseID, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("PromoteExercise: Failed to build select query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to promote exercise")
+	}
+	ex, err := provider.ScanExercise(tx.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+		}
+		c.Logger().Errorf("PromoteExercise: Failed to fetch exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to promote exercise")
+	}
+	if !ex.IsCustom() {
+		return echo.NewHTTPError(http.StatusConflict, "Exercise is already part of the global catalog")
+	}
+
+	now := time.Now()
+	query, args, err = h.sq.Update("exercises").
+		Set("owner_id", nil).
+		Set("updated_at", now).
+		Where(squirrel.Eq{"id": exerciseID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("PromoteExercise: Failed to build update query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to promote exercise")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "A global exercise with this name already exists")
+		}
+		c.Logger().Errorf("PromoteExercise: Failed to update exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to promote exercise")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseID); err != nil {
+		c.Logger().Errorf("PromoteExercise: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to promote exercise")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("PromoteExercise: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to promote exercise")
+	}
+
+	ex.OwnerID = nil
+	ex.UpdatedAt = now
+	return c.JSON(http.StatusOK, dto.PromoteExerciseResponse{
+		Message:  "Exercise promoted to the global catalog",
+		Exercise: toExerciseResponse(&ex),
+	})
+}
@@ -3,13 +3,23 @@ package handlers
 import (
 	"net/http"
 	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
+// StoreExercise creates custom exercises owned by the authenticated user. They are only visible
+// to that user until an admin promotes them to the global catalog.
 func (h *ExerciseHandler) StoreExercise(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
 	var req dto.CreateExerciseRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
@@ -17,17 +27,49 @@ func (h *ExerciseHandler) StoreExercise(c echo.Context) error {
 	if len(req.Exercises) == 0 {
 		return echo.NewHTTPError(http.StatusBadRequest, "No exercises provided")
 	}
+	for i := range req.Exercises {
+		normalizeCreateExerciseItem(&req.Exercises[i])
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 
 	ctx := c.Request().Context()
 	now := time.Now()
 	builder := h.sq.Insert("exercises").
-		Columns("id", "name", "created_at", "updated_at")
+		Columns(
+			"id", "name", "owner_id", "description", "position", "force_type", "difficulty", "movement_type",
+			"muscle_group", "equipment", "bodypart", "tracking_type", "created_at", "updated_at",
+		)
 
+	exercises := make([]model.Exercise, 0, len(req.Exercises))
 	exerciseIDs := make([]uuid.UUID, 0, len(req.Exercises))
-	for _, ex := range req.Exercises {
-		id := uuid.New()
-		exerciseIDs = append(exerciseIDs, id)
-		builder = builder.Values(id, ex.Name, now, now)
+	for _, item := range req.Exercises {
+		ex := model.Exercise{
+			ID:           uuid.New(),
+			Name:         item.Name,
+			OwnerID:      &userID,
+			Description:  item.Description,
+			Position:     item.Position,
+			ForceType:    item.ForceType,
+			Difficulty:   item.Difficulty,
+			MovementType: item.MovementType,
+			MuscleGroup:  item.MuscleGroup,
+			Equipment:    item.Equipment,
+			Bodypart:     item.Bodypart,
+			TrackingType: model.ExerciseTrackingWeightReps,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		if item.TrackingType != nil {
+			ex.TrackingType = *item.TrackingType
+		}
+		exercises = append(exercises, ex)
+		exerciseIDs = append(exerciseIDs, ex.ID)
+		builder = builder.Values(
+			ex.ID, ex.Name, ex.OwnerID, ex.Description, ex.Position, ex.ForceType, ex.Difficulty, ex.MovementType,
+			ex.MuscleGroup, ex.Equipment, ex.Bodypart, ex.TrackingType, ex.CreatedAt, ex.UpdatedAt,
+		)
 	}
 
 	query, args, err := builder.ToSql()
@@ -36,22 +78,38 @@ func (h *ExerciseHandler) StoreExercise(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create exercises")
 	}
 
-	_, err = h.DB.ExecContext(ctx, query, args...)
+	// The search outbox row is written in the same transaction, so the new exercises
+	// reach the Typesense index even if it is unavailable right now.
+	tx, err := h.DB.BeginTx(ctx, nil)
 	if err != nil {
+		c.Logger().Errorf("StoreExercise: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create exercises")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	_, err = tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "You already have an exercise with this name")
+		}
 		c.Logger().Errorf("StoreExercise: Failed to execute insert: %v", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create exercises")
 	}
 
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseIDs...); err != nil {
+		c.Logger().Errorf("StoreExercise: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create exercises")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("StoreExercise: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create exercises")
+	}
+
 	// Build response DTOs
-	exerciseResponses := make([]dto.ExerciseResponse, 0, len(req.Exercises))
-	for i, id := range exerciseIDs {
-		exerciseResponses = append(exerciseResponses, dto.ExerciseResponse{
-			ID:        id,
-			Name:      req.Exercises[i].Name,
-			CreatedAt: now,
-			UpdatedAt: now,
-			DeletedAt: nil,
-		})
+	exerciseResponses := make([]dto.ExerciseResponse, 0, len(exercises))
+	for i := range exercises {
+		exerciseResponses = append(exerciseResponses, toExerciseResponse(&exercises[i]))
 	}
 
 	return c.JSON(http.StatusCreated, dto.CreateExerciseResponse{
@@ -59,3 +117,22 @@ func (h *ExerciseHandler) StoreExercise(c echo.Context) error {
 		Exercises: exerciseResponses,
 	})
 }
+
+// normalizeCreateExerciseItem trims the text fields and treats blank optional values as unset.
+func normalizeCreateExerciseItem(item *dto.CreateExerciseItem) {
+	item.Name = strings.TrimSpace(item.Name)
+	for _, field := range []**string{
+		&item.Description, &item.Position, &item.ForceType, &item.Difficulty,
+		&item.MovementType, &item.MuscleGroup, &item.Equipment, &item.Bodypart, &item.TrackingType,
+	} {
+		if *field == nil {
+			continue
+		}
+		trimmed := strings.TrimSpace(**field)
+		if trimmed == "" {
+			*field = nil
+		} else {
+			*field = &trimmed
+		}
+	}
+}
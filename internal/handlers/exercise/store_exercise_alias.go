@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// StoreExerciseAlias adds an alias to an exercise (admin only). The exercise is re-synced to
+// the search index in the same transaction, so the alias becomes searchable.
+func (h *ExerciseHandler) StoreExerciseAlias(c echo.Context) error {
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	var req dto.CreateExerciseAliasRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Alias = strings.TrimSpace(req.Alias)
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseAlias: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create alias")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	query, args, err := h.sq.Select("id").
+		From("exercises").
+		Where(squirrel.Eq{"id": exerciseID, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseAlias: Failed to build exercise query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create alias")
+	}
+	var existingID uuid.UUID
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&existingID); err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+		}
+		c.Logger().Errorf("StoreExerciseAlias: Failed to fetch exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create alias")
+	}
+
+	now := time.Now()
+	alias := model.ExerciseAlias{
+		ID:         uuid.New(),
+		ExerciseID: exerciseID,
+		Alias:      req.Alias,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	query, args, err = h.sq.Insert("exercise_aliases").
+		Columns("id", "exercise_id", "alias", "created_at", "updated_at").
+		Values(alias.ID, alias.ExerciseID, alias.Alias, alias.CreatedAt, alias.UpdatedAt).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseAlias: Failed to build insert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create alias")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "The exercise already has this alias")
+		}
+		c.Logger().Errorf("StoreExerciseAlias: Failed to insert alias: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create alias")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseID); err != nil {
+		c.Logger().Errorf("StoreExerciseAlias: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create alias")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("StoreExerciseAlias: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create alias")
+	}
+
+	return c.JSON(http.StatusCreated, toExerciseAliasResponse(&alias))
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/gif"
+	"io"
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// exerciseMediaMaxUploadSize is the largest accepted upload; GIFs are stored as they are.
+	exerciseMediaMaxUploadSize = 10 << 20
+	// exerciseMediaMaxSide is the longest side of stored images; larger ones are scaled down.
+	exerciseMediaMaxSide = 1920
+	// exerciseMediaJPEGQuality is the quality of re-encoded images.
+	exerciseMediaJPEGQuality = 85
+	// exerciseMediaMaxPerExercise caps the attachments of one exercise.
+	exerciseMediaMaxPerExercise = 20
+)
+
+// exerciseMediaUpload is a processed upload ready to be stored.
+type exerciseMediaUpload struct {
+	kind        string
+	contentType string
+	ext         string
+	data        []byte
+}
+
+// StoreExerciseMedia attaches an image, GIF or video to an exercise. A multipart upload (field
+// "file", optional "caption" and "position") stores the file through the storage backend:
+// JPEG, PNG and WebP images are re-encoded as JPEG without metadata, GIFs are kept as they are
+// so they stay animated. A JSON body attaches an external URL instead, e.g. a YouTube video.
+// Owners manage their custom exercises and admins every exercise.
+func (h *ExerciseHandler) StoreExerciseMedia(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	media := model.ExerciseMedia{
+		ID:         uuid.New(),
+		ExerciseID: exerciseID,
+	}
+	var (
+		upload   *exerciseMediaUpload
+		position *int
+	)
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		if h.Storage == nil {
+			c.Logger().Error("StoreExerciseMedia: No storage backend configured")
+			return echo.NewHTTPError(http.StatusServiceUnavailable, "Media uploads are not available")
+		}
+		if upload, err = readExerciseMediaUpload(c); err != nil {
+			return err
+		}
+		media.Kind = upload.kind
+		media.ContentType = &upload.contentType
+		if caption := strings.TrimSpace(c.FormValue("caption")); caption != "" {
+			if len(caption) > 255 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Caption is too long (max 255 characters)")
+			}
+			media.Caption = &caption
+		}
+		if raw := c.FormValue("position"); raw != "" {
+			p, err := strconv.Atoi(raw)
+			if err != nil || p < 0 {
+				return echo.NewHTTPError(http.StatusBadRequest, "Position must be a non-negative integer")
+			}
+			position = &p
+		}
+	} else {
+		var req dto.CreateExerciseMediaRequest
+		if err := c.Bind(&req); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		}
+		req.URL = strings.TrimSpace(req.URL)
+		if err := c.Validate(&req); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		media.Kind = req.Kind
+		media.URL = req.URL
+		media.Caption = req.Caption
+		position = req.Position
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add media")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	if _, err := h.lockManageableExercise(ctx, c, tx, userID, exerciseID); err != nil {
+		return err
+	}
+
+	query, args, err := h.sq.Select("COUNT(*)", "COALESCE(MAX(position) + 1, 0)").
+		From("exercise_media").
+		Where(squirrel.Eq{"exercise_id": exerciseID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to build count query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add media")
+	}
+	var count, nextPosition int
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&count, &nextPosition); err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to count media: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add media")
+	}
+	if count >= exerciseMediaMaxPerExercise {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, fmt.Sprintf("An exercise can have at most %d media attachments", exerciseMediaMaxPerExercise))
+	}
+	media.Position = nextPosition
+	if position != nil {
+		media.Position = *position
+	}
+
+	// The file is stored last, once the exercise is known to be manageable, and removed again
+	// when the row can't be saved.
+	if upload != nil {
+		key := fmt.Sprintf("exercises/%s/media/%s%s", exerciseID, media.ID, upload.ext)
+		url, err := h.Storage.Put(ctx, key, bytes.NewReader(upload.data), upload.contentType)
+		if err != nil {
+			c.Logger().Errorf("StoreExerciseMedia: Failed to store file: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to store media")
+		}
+		media.URL = url
+		media.StorageKey = &key
+	}
+	stored := false
+	defer func() {
+		if upload != nil && !stored {
+			if err := h.Storage.Delete(ctx, *media.StorageKey); err != nil {
+				c.Logger().Warnf("StoreExerciseMedia: Failed to delete orphaned file: %v", err)
+			}
+		}
+	}()
+
+	now := time.Now()
+	media.CreatedAt = now
+	media.UpdatedAt = now
+	query, args, err = h.sq.Insert("exercise_media").
+		Columns(provider.ExerciseMediaColumns...).
+		Values(media.ID, media.ExerciseID, media.Kind, media.URL, media.StorageKey, media.ContentType, media.Caption, media.Position, media.CreatedAt, media.UpdatedAt).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to build insert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add media")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to insert media: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add media")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to add media")
+	}
+	stored = true
+
+	return c.JSON(http.StatusCreated, toExerciseMediaResponse(&media))
+}
+
+// readExerciseMediaUpload reads the "file" field and prepares it for storage. The type is
+// sniffed from the bytes, never taken from the client.
+func readExerciseMediaUpload(c echo.Context) (*exerciseMediaUpload, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Media file is required in the 'file' field")
+	}
+	if fileHeader.Size > exerciseMediaMaxUploadSize {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "File is too large (max 10 MB)")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to open uploaded file: %v", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to read uploaded file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, exerciseMediaMaxUploadSize+1))
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to read uploaded file: %v", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to read uploaded file")
+	}
+	if len(data) > exerciseMediaMaxUploadSize {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "File is too large (max 10 MB)")
+	}
+
+	if http.DetectContentType(data) == "image/gif" {
+		cfg, err := gif.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid GIF file")
+		}
+		if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > provider.MaxImagePixels {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Image dimensions are too large")
+		}
+		return &exerciseMediaUpload{kind: model.ExerciseMediaGIF, contentType: "image/gif", ext: ".gif", data: data}, nil
+	}
+
+	img, err := provider.DecodeImage(data)
+	if err != nil {
+		if errors.Is(err, provider.ErrUnsupportedImageType) {
+			return nil, echo.NewHTTPError(http.StatusUnsupportedMediaType, "Only JPEG, PNG, WebP and GIF files can be uploaded; link videos by URL")
+		}
+		if errors.Is(err, provider.ErrImageTooLarge) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Image dimensions are too large")
+		}
+		c.Logger().Warnf("StoreExerciseMedia: Failed to decode image: %v", err)
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid image file")
+	}
+	encoded, err := provider.EncodeJPEG(provider.ResizeToFit(img, exerciseMediaMaxSide), exerciseMediaJPEGQuality)
+	if err != nil {
+		c.Logger().Errorf("StoreExerciseMedia: Failed to encode image: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to process image")
+	}
+	return &exerciseMediaUpload{kind: model.ExerciseMediaImage, contentType: "image/jpeg", ext: ".jpg", data: encoded}, nil
+}
+
+func toExerciseMediaResponse(m *model.ExerciseMedia) dto.ExerciseMediaResponse {
+	return dto.ExerciseMediaResponse{
+		ID:          m.ID,
+		Kind:        m.Kind,
+		URL:         m.URL,
+		ContentType: m.ContentType,
+		Caption:     m.Caption,
+		Position:    m.Position,
+		CreatedAt:   m.CreatedAt,
+	}
+}
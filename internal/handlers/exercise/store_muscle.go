@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// StoreMuscle adds a muscle to the catalog (admin only).
+func (h *ExerciseHandler) StoreMuscle(c echo.Context) error {
+	req, err := bindMuscleRequest(c)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	muscle := model.Muscle{
+		ID:          uuid.New(),
+		Slug:        req.Slug,
+		Name:        req.Name,
+		MuscleGroup: req.MuscleGroup,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	query, args, err := h.sq.Insert("muscles").
+		Columns(provider.MuscleColumns...).
+		Values(muscle.ID, muscle.Slug, muscle.Name, muscle.MuscleGroup, muscle.CreatedAt, muscle.UpdatedAt).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("StoreMuscle: Failed to build insert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create muscle")
+	}
+	if _, err := h.DB.ExecContext(c.Request().Context(), query, args...); err != nil {
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "A muscle with this slug already exists")
+		}
+		c.Logger().Errorf("StoreMuscle: Failed to insert muscle: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create muscle")
+	}
+
+	return c.JSON(http.StatusCreated, toMuscleResponse(&muscle))
+}
+
+// bindMuscleRequest binds, normalizes and validates the body of StoreMuscle and UpdateMuscle.
+func bindMuscleRequest(c echo.Context) (*dto.MuscleRequest, error) {
+	var req dto.MuscleRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Slug = strings.TrimSpace(req.Slug)
+	req.Name = strings.TrimSpace(req.Name)
+	if err := c.Validate(&req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if !muscleSlugPattern.MatchString(req.Slug) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Slug must be lowercase snake_case, e.g. front_delts")
+	}
+	return &req, nil
+}
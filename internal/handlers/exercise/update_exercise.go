@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// UpdateExercise replaces the editable fields of an exercise. Users may edit their own custom
+// exercises; global exercises can only be edited by admins. The tracking type is locked once
+// sets have been logged or workouts planned with the exercise.
+func (h *ExerciseHandler) UpdateExercise(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	var req dto.UpdateExerciseRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	// Same fields and rules as a created exercise.
+	normalizeCreateExerciseItem((*dto.CreateExerciseItem)(&req))
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("UpdateExercise: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	ex, err := h.lockManageableExercise(ctx, c, tx, userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	// Existing sets and template targets were recorded in the current tracking type.
+	if req.TrackingType != nil && *req.TrackingType != ex.TrackingType {
+		inUse, err := provider.ExerciseHasTrackedValues(ctx, tx, h.sq, exerciseID)
+		if err != nil {
+			c.Logger().Errorf("UpdateExercise: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+		}
+		if inUse {
+			return echo.NewHTTPError(http.StatusConflict, "The tracking type can't be changed once the exercise has logged sets or is used in a workout")
+		}
+	}
+
+	ex.Name = req.Name
+	ex.Description = req.Description
+	ex.Position = req.Position
+	ex.ForceType = req.ForceType
+	ex.Difficulty = req.Difficulty
+	ex.MovementType = req.MovementType
+	ex.MuscleGroup = req.MuscleGroup
+	ex.Equipment = req.Equipment
+	ex.Bodypart = req.Bodypart
+	if req.TrackingType != nil {
+		ex.TrackingType = *req.TrackingType
+	}
+	ex.UpdatedAt = time.Now()
+
+	query, args, err := h.sq.Update("exercises").
+		SetMap(map[string]any{
+			"name":          ex.Name,
+			"description":   ex.Description,
+			"position":      ex.Position,
+			"force_type":    ex.ForceType,
+			"difficulty":    ex.Difficulty,
+			"movement_type": ex.MovementType,
+			"muscle_group":  ex.MuscleGroup,
+			"equipment":     ex.Equipment,
+			"bodypart":      ex.Bodypart,
+			"tracking_type": ex.TrackingType,
+			"updated_at":    ex.UpdatedAt,
+		}).
+		Where(squirrel.Eq{"id": exerciseID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateExercise: Failed to build update query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "An exercise with this name already exists")
+		}
+		c.Logger().Errorf("UpdateExercise: Failed to update exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseID); err != nil {
+		c.Logger().Errorf("UpdateExercise: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+	}
+
+	aliases, err := provider.FetchExerciseAliases(ctx, tx, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("UpdateExercise: Failed to fetch aliases: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+	}
+	ex.Aliases = aliases[exerciseID]
+
+	muscles, err := provider.FetchExerciseMuscles(ctx, tx, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("UpdateExercise: Failed to fetch muscles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+	}
+	ex.Muscles = muscles[exerciseID]
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("UpdateExercise: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise")
+	}
+
+	return c.JSON(http.StatusOK, toExerciseResponse(ex))
+}
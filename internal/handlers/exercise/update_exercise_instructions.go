@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+)
+
+// UpdateExerciseInstructions replaces the steps, form cues and common mistakes of an exercise.
+// Owners manage their custom exercises and admins every exercise. The steps are indexed for
+// search, so the exercise is re-synced in the same transaction.
+func (h *ExerciseHandler) UpdateExerciseInstructions(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	var req dto.UpdateExerciseInstructionsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Steps = trimInstructionList(req.Steps)
+	req.FormCues = trimInstructionList(req.FormCues)
+	req.CommonMistakes = trimInstructionList(req.CommonMistakes)
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseInstructions: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update instructions")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	if _, err := h.lockManageableExercise(ctx, c, tx, userID, exerciseID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	query, args, err := h.sq.Insert("exercise_instructions").
+		Columns("exercise_id", "steps", "form_cues", "common_mistakes", "created_at", "updated_at").
+		Values(exerciseID, pq.Array(req.Steps), pq.Array(req.FormCues), pq.Array(req.CommonMistakes), now, now).
+		Suffix("ON CONFLICT (exercise_id) DO UPDATE SET steps = EXCLUDED.steps, form_cues = EXCLUDED.form_cues, common_mistakes = EXCLUDED.common_mistakes, updated_at = EXCLUDED.updated_at").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseInstructions: Failed to build upsert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update instructions")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		c.Logger().Errorf("UpdateExerciseInstructions: Failed to upsert instructions: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update instructions")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseID); err != nil {
+		c.Logger().Errorf("UpdateExerciseInstructions: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update instructions")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("UpdateExerciseInstructions: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update instructions")
+	}
+
+	return c.JSON(http.StatusOK, toExerciseInstructionsResponse(&model.ExerciseInstructions{
+		ExerciseID:     exerciseID,
+		Steps:          req.Steps,
+		FormCues:       req.FormCues,
+		CommonMistakes: req.CommonMistakes,
+		UpdatedAt:      now,
+	}))
+}
+
+// trimInstructionList trims every entry and drops the empty ones.
+func trimInstructionList(items []string) []string {
+	trimmed := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			trimmed = append(trimmed, item)
+		}
+	}
+	return trimmed
+}
+
+// toExerciseInstructionsResponse converts instructions, which may be nil, to their response.
+func toExerciseInstructionsResponse(in *model.ExerciseInstructions) dto.ExerciseInstructionsResponse {
+	res := dto.ExerciseInstructionsResponse{
+		Steps:          []string{},
+		FormCues:       []string{},
+		CommonMistakes: []string{},
+	}
+	if in == nil {
+		return res
+	}
+	if in.Steps != nil {
+		res.Steps = in.Steps
+	}
+	if in.FormCues != nil {
+		res.FormCues = in.FormCues
+	}
+	if in.CommonMistakes != nil {
+		res.CommonMistakes = in.CommonMistakes
+	}
+	res.UpdatedAt = &in.UpdatedAt
+	return res
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// UpdateExerciseMuscles replaces the muscles an exercise works (admin only). A non-empty
+// mapping needs at least one primary muscle and may list each muscle once.
+func (h *ExerciseHandler) UpdateExerciseMuscles(c echo.Context) error {
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	var req dto.UpdateExerciseMusclesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	seen := make(map[uuid.UUID]bool, len(req.Muscles))
+	hasPrimary := false
+	for _, item := range req.Muscles {
+		if seen[item.MuscleID] {
+			return echo.NewHTTPError(http.StatusBadRequest, "Each muscle can only be listed once")
+		}
+		seen[item.MuscleID] = true
+		hasPrimary = hasPrimary || item.Role == model.ExerciseMuscleRolePrimary
+	}
+	if len(req.Muscles) > 0 && !hasPrimary {
+		return echo.NewHTTPError(http.StatusBadRequest, "At least one muscle must be primary")
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseMuscles: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	query, args, err := h.sq.Select("id").
+		From("exercises").
+		Where(squirrel.Eq{"id": exerciseID, "deleted_at": nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseMuscles: Failed to build exercise query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+	}
+	var existingID uuid.UUID
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&existingID); err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Exercise not found")
+		}
+		c.Logger().Errorf("UpdateExerciseMuscles: Failed to fetch exercise: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+	}
+
+	query, args, err = h.sq.Delete("exercise_muscles").Where(squirrel.Eq{"exercise_id": exerciseID}).ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseMuscles: Failed to build delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		c.Logger().Errorf("UpdateExerciseMuscles: Failed to delete muscles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+	}
+
+	if len(req.Muscles) > 0 {
+		now := time.Now()
+		insert := h.sq.Insert("exercise_muscles").Columns("exercise_id", "muscle_id", "role", "weight", "created_at", "updated_at")
+		for _, item := range req.Muscles {
+			weight := model.DefaultExerciseMuscleWeight(item.Role)
+			if item.Weight != nil {
+				weight = *item.Weight
+			}
+			insert = insert.Values(exerciseID, item.MuscleID, item.Role, weight, now, now)
+		}
+		query, args, err = insert.ToSql()
+		if err != nil {
+			c.Logger().Errorf("UpdateExerciseMuscles: Failed to build insert query: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			if provider.IsForeignKeyViolation(err) {
+				return echo.NewHTTPError(http.StatusBadRequest, "Unknown muscle ID")
+			}
+			c.Logger().Errorf("UpdateExerciseMuscles: Failed to insert muscles: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+		}
+	}
+
+	muscles, err := provider.FetchExerciseMuscles(ctx, tx, h.sq, []uuid.UUID{exerciseID})
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseMuscles: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("UpdateExerciseMuscles: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update exercise muscles")
+	}
+
+	data := toExerciseMuscleResponses(muscles[exerciseID])
+	if data == nil {
+		data = []dto.ExerciseMuscleResponse{}
+	}
+	return c.JSON(http.StatusOK, dto.ListExerciseMuscleResponse{Data: data})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+)
+
+// UpdateExerciseNote creates or replaces the user's note on an exercise visible to them. The
+// note belongs to the user alone, so global exercises can be annotated without touching them.
+func (h *ExerciseHandler) UpdateExerciseNote(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+
+	var req dto.UpdateExerciseNoteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Note = strings.TrimSpace(req.Note)
+	req.PinnedCues = trimInstructionList(req.PinnedCues)
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	if err := h.ensureVisibleExercise(ctx, c, userID, exerciseID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	query, args, err := h.sq.Insert("exercise_notes").
+		Columns("id", "user_id", "exercise_id", "note", "pinned_cues", "created_at", "updated_at").
+		Values(uuid.New(), userID, exerciseID, req.Note, pq.Array(req.PinnedCues), now, now).
+		Suffix("ON CONFLICT (user_id, exercise_id) DO UPDATE SET note = EXCLUDED.note, pinned_cues = EXCLUDED.pinned_cues, updated_at = EXCLUDED.updated_at " +
+			"RETURNING " + strings.Join(provider.ExerciseNoteColumns, ", ")).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseNote: Failed to build upsert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update note")
+	}
+	note, err := provider.ScanExerciseNote(h.DB.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseNote: Failed to upsert note: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update note")
+	}
+
+	return c.JSON(http.StatusOK, toExerciseNoteResponse(&note))
+}
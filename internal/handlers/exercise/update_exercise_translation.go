@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"rtglabs-go/dto"
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
+)
+
+// UpdateExerciseTranslation creates or replaces the translation of an exercise to the locale in
+// the URL. Owners manage their custom exercises and admins every exercise. Translated names
+// are indexed for search, so the exercise is re-synced in the same transaction.
+func (h *ExerciseHandler) UpdateExerciseTranslation(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid exercise ID format")
+	}
+	locale, err := parseTranslationLocale(c.Param("locale"))
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpsertExerciseTranslationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Description != nil {
+		if description := strings.TrimSpace(*req.Description); description != "" {
+			req.Description = &description
+		} else {
+			req.Description = nil
+		}
+	}
+	req.Steps = trimInstructionList(req.Steps)
+	req.FormCues = trimInstructionList(req.FormCues)
+	req.CommonMistakes = trimInstructionList(req.CommonMistakes)
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseTranslation: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update translation")
+	}
+	defer tx.Rollback() // Rollback if not committed
+
+	if _, err := h.lockManageableExercise(ctx, c, tx, userID, exerciseID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	query, args, err := h.sq.Insert("exercise_translations").
+		Columns("exercise_id", "locale", "name", "description", "steps", "form_cues", "common_mistakes", "created_at", "updated_at").
+		Values(exerciseID, locale, req.Name, req.Description, pq.Array(req.Steps), pq.Array(req.FormCues), pq.Array(req.CommonMistakes), now, now).
+		Suffix("ON CONFLICT (exercise_id, locale) DO UPDATE SET name = EXCLUDED.name, description = EXCLUDED.description, steps = EXCLUDED.steps, form_cues = EXCLUDED.form_cues, common_mistakes = EXCLUDED.common_mistakes, updated_at = EXCLUDED.updated_at " +
+			"RETURNING " + strings.Join(provider.ExerciseTranslationColumns, ", ")).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseTranslation: Failed to build upsert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update translation")
+	}
+	translation, err := provider.ScanExerciseTranslation(tx.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		c.Logger().Errorf("UpdateExerciseTranslation: Failed to upsert translation: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update translation")
+	}
+
+	if err := provider.EnqueueSearchSync(ctx, tx, h.sq, model.SearchEntityExercise, model.SearchOperationUpsert, exerciseID); err != nil {
+		c.Logger().Errorf("UpdateExerciseTranslation: Failed to enqueue search sync: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update translation")
+	}
+
+	if err := tx.Commit(); err != nil {
+		c.Logger().Errorf("UpdateExerciseTranslation: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update translation")
+	}
+
+	return c.JSON(http.StatusOK, toExerciseTranslationResponse(&translation))
+}
+
+// parseTranslationLocale validates the locale of a translation URL. The default locale is
+// edited on the exercise itself.
+func parseTranslationLocale(locale string) (string, error) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if locale == model.DefaultLocale {
+		return "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("'%s' is the default locale, update the exercise instead", locale))
+	}
+	if !model.IsSupportedLocale(locale) {
+		return "", echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unsupported locale '%s'", locale))
+	}
+	return locale, nil
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"rtglabs-go/provider"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// UpdateMuscle replaces the slug, name and muscle group of a catalog muscle (admin only).
+func (h *ExerciseHandler) UpdateMuscle(c echo.Context) error {
+	muscleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid muscle ID format")
+	}
+	req, err := bindMuscleRequest(c)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := h.sq.Update("muscles").
+		SetMap(map[string]any{
+			"slug":         req.Slug,
+			"name":         req.Name,
+			"muscle_group": req.MuscleGroup,
+			"updated_at":   time.Now(),
+		}).
+		Where(squirrel.Eq{"id": muscleID}).
+		Suffix("RETURNING " + strings.Join(provider.MuscleColumns, ", ")).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateMuscle: Failed to build update query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update muscle")
+	}
+	muscle, err := provider.ScanMuscle(h.DB.QueryRowContext(c.Request().Context(), query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Muscle not found")
+		}
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "A muscle with this slug already exists")
+		}
+		c.Logger().Errorf("UpdateMuscle: Failed to update muscle: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update muscle")
+	}
+
+	return c.JSON(http.StatusOK, toMuscleResponse(&muscle))
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"rtglabs-go/dto"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ArrangeWorkouts moves workout templates into a folder (or to the top level) and sets their
+// order there. The listed templates take positions 0..n-1; templates already in the target that
+// were not listed keep their relative order after them.
+func (h *WorkoutHandler) ArrangeWorkouts(c echo.Context) (err error) {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		c.Logger().Error("ArrangeWorkouts: User ID not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found")
+	}
+
+	var req dto.ArrangeWorkoutsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body format. Please check JSON syntax.")
+	}
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err.Error()))
+	}
+	seen := make(map[uuid.UUID]bool, len(req.WorkoutIDs))
+	for _, id := range req.WorkoutIDs {
+		if seen[id] {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Workout %s is listed more than once", id))
+		}
+		seen[id] = true
+	}
+
+	ctx := c.Request().Context()
+	tx, err := h.DB.BeginTx(ctx, nil)
+	if err != nil {
+		c.Logger().Errorf("ArrangeWorkouts: Failed to begin transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: Could not start transaction.")
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = h.ensureWorkoutFolder(ctx, c, tx, userID, req.FolderID); err != nil {
+		return err
+	}
+
+	// Make sure every listed template belongs to the user.
+	ownedQuery, ownedArgs, err := h.sq.Select("COUNT(*)").From("workouts").
+		Where(squirrel.Eq{"id": req.WorkoutIDs, "user_id": userID, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("ArrangeWorkouts: Failed to build ownership query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to arrange workouts")
+	}
+	var owned int
+	if err = tx.QueryRowContext(ctx, ownedQuery, ownedArgs...).Scan(&owned); err != nil {
+		c.Logger().Errorf("ArrangeWorkouts: Failed to check workout ownership: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to arrange workouts")
+	}
+	if owned != len(req.WorkoutIDs) {
+		err = echo.NewHTTPError(http.StatusNotFound, "One or more workouts not found")
+		return err
+	}
+
+	now := time.Now()
+	for i, id := range req.WorkoutIDs {
+		query, args, buildErr := h.sq.Update("workouts").
+			Set("folder_id", req.FolderID).
+			Set("position", i).
+			Set("updated_at", now).
+			Where(squirrel.Eq{"id": id}).
+			ToSql()
+		if buildErr != nil {
+			err = buildErr
+			c.Logger().Errorf("ArrangeWorkouts: Failed to build update query: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to arrange workouts")
+		}
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			c.Logger().Errorf("ArrangeWorkouts: Failed to move workout %s: %v", id, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to arrange workouts")
+		}
+	}
+
+	// Renumber the remaining templates in the target after the listed ones.
+	restQuery, restArgs, err := h.sq.Select("id", fmt.Sprintf("ROW_NUMBER() OVER (ORDER BY position, created_at, id) + %d - 1 AS new_position", len(req.WorkoutIDs))).
+		From("workouts").
+		Where(squirrel.Eq{"user_id": userID, "deleted_at": nil}).
+		Where(folderCondition(req.FolderID)).
+		Where(squirrel.NotEq{"id": req.WorkoutIDs}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("ArrangeWorkouts: Failed to build renumber query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to arrange workouts")
+	}
+	renumberQuery := fmt.Sprintf("UPDATE workouts SET position = r.new_position FROM (%s) AS r WHERE workouts.id = r.id", restQuery)
+	if _, err = tx.ExecContext(ctx, renumberQuery, restArgs...); err != nil {
+		c.Logger().Errorf("ArrangeWorkouts: Failed to renumber workouts: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to arrange workouts")
+	}
+
+	if err = tx.Commit(); err != nil {
+		c.Logger().Errorf("ArrangeWorkouts: Failed to commit transaction: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to arrange workouts")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Workouts arranged successfully"})
+}
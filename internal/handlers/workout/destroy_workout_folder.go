@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DestroyWorkoutFolder deletes a workout template folder. The templates in it are not
+// deleted; the folder_id foreign key moves them back to the top level.
+func (h *WorkoutHandler) DestroyWorkoutFolder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		c.Logger().Error("DestroyWorkoutFolder: User ID not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found")
+	}
+	folderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid folder ID")
+	}
+
+	query, args, err := h.sq.Delete("workout_folders").
+		Where(squirrel.Eq{"id": folderID, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("DestroyWorkoutFolder: Failed to build delete query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete workout folder")
+	}
+	result, err := h.DB.ExecContext(c.Request().Context(), query, args...)
+	if err != nil {
+		c.Logger().Errorf("DestroyWorkoutFolder: Failed to delete folder %s: %v", folderID, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete workout folder")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Workout folder not found")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Workout folder deleted successfully. Its workouts were moved to the top level.",
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Masterminds/squirrel" // Import squirrel
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
 	"rtglabs-go/dto"
 	"rtglabs-go/model"    // Import your model package (Workout, WorkoutExercise, Exercise, ExerciseInstance)
 	"rtglabs-go/provider" // Import provider for helper functions
@@ -43,6 +44,8 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 		WESets               sql.NullInt64   // workout_exercises.sets
 		WEWeight             sql.NullFloat64 // workout_exercises.weight
 		WEReps               sql.NullInt64   // workout_exercises.reps
+		WEDurationSeconds    sql.NullInt64   // workout_exercises.duration_seconds
+		WEDistanceMeters     sql.NullFloat64 // workout_exercises.distance_meters
 		WECreatedAt          sql.NullTime    // workout_exercises.created_at
 		WEUpdatedAt          sql.NullTime    // workout_exercises.updated_at
 		WEDeletedAt          sql.NullTime    // workout_exercises.deleted_at
@@ -50,6 +53,7 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 		// Fields for Exercise (aliased in query, related to workout_exercise)
 		ExID        uuid.NullUUID  // exercises.id
 		ExName      sql.NullString // exercises.name
+		ExTracking  sql.NullString // exercises.tracking_type
 		ExCreatedAt sql.NullTime   // exercises.created_at
 		ExUpdatedAt sql.NullTime   // exercises.updated_at
 		ExDeletedAt sql.NullTime   // exercises.deleted_at
@@ -67,12 +71,14 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 	selectBuilder := h.sq.Select(
 		// Workout fields (aliased as w)
 		"w.id", "w.user_id", "w.name", "w.created_at", "w.updated_at", "w.deleted_at",
+		"w.folder_id", "w.position", "w.tags",
 		// WorkoutExercise fields (aliased as we)
 		"we.id AS we_id", "we.workout_id AS we_workout_id", "we.exercise_id AS we_exercise_id", "we.exercise_instance_id AS we_exercise_instance_id",
 		"we.workout_order AS we_order", "we.sets AS we_sets", "we.weight AS we_weight", "we.reps AS we_reps",
+		"we.duration_seconds AS we_duration_seconds", "we.distance_meters AS we_distance_meters",
 		"we.created_at AS we_created_at", "we.updated_at AS we_updated_at", "we.deleted_at AS we_deleted_at",
 		// Exercise fields (aliased as e, related to we.exercise_id)
-		"e.id AS ex_id", "e.name AS ex_name", "e.created_at AS ex_created_at", "e.updated_at AS ex_updated_at", "e.deleted_at AS ex_deleted_at",
+		"e.id AS ex_id", "e.name AS ex_name", "e.tracking_type AS ex_tracking_type", "e.created_at AS ex_created_at", "e.updated_at AS ex_updated_at", "e.deleted_at AS ex_deleted_at",
 		// ExerciseInstance fields (aliased as ei, related to we.exercise_instance_id)
 		"ei.id AS ei_id", "ei.workout_log_id AS ei_workout_log_id", "ei.exercise_id AS ei_exercise_id", "ei.created_at AS ei_created_at", "ei.updated_at AS ei_updated_at", "ei.deleted_at AS ei_deleted_at",
 	).
@@ -112,12 +118,13 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 		err := rows.Scan(
 			// Workout fields (w)
 			&jwr.ID, &jwr.UserID, &jwr.Name, &jwr.CreatedAt, &jwr.UpdatedAt, &workoutDeletedAt,
+			&jwr.FolderID, &jwr.Position, pq.Array(&jwr.Tags),
 			// WorkoutExercise fields (we)
 			&jwr.WEID, &jwr.WEWorkoutID, &jwr.WEExerciseID, &jwr.WEExerciseInstanceID,
-			&jwr.WEOrder, &jwr.WESets, &jwr.WEWeight, &jwr.WEReps,
+			&jwr.WEOrder, &jwr.WESets, &jwr.WEWeight, &jwr.WEReps, &jwr.WEDurationSeconds, &jwr.WEDistanceMeters,
 			&jwr.WECreatedAt, &jwr.WEUpdatedAt, &jwr.WEDeletedAt,
 			// Exercise fields (e)
-			&jwr.ExID, &jwr.ExName, &jwr.ExCreatedAt, &jwr.ExUpdatedAt, &jwr.ExDeletedAt,
+			&jwr.ExID, &jwr.ExName, &jwr.ExTracking, &jwr.ExCreatedAt, &jwr.ExUpdatedAt, &jwr.ExDeletedAt,
 			// ExerciseInstance fields (ei)
 			&jwr.EiID, &jwr.EiWorkoutLogID, &jwr.EiExerciseID, &jwr.EiCreatedAt, &jwr.EiUpdatedAt, &jwr.EiDeletedAt,
 		)
@@ -142,6 +149,9 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 					return nil
 				}(),
 				WorkoutExercises: []dto.WorkoutExerciseResponse{}, // Initialize slice
+				FolderID:         jwr.FolderID,
+				Position:         jwr.Position,
+				Tags:             tagsOrEmpty(jwr.Tags),
 			}
 		}
 
@@ -161,6 +171,8 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 			weModel.Sets = provider.NullInt64ToIntPtr(jwr.WESets)           // Fixed
 			weModel.Weight = provider.NullFloat64ToFloat64Ptr(jwr.WEWeight) // Already correct, but using provider for consistency
 			weModel.Reps = provider.NullInt64ToIntPtr(jwr.WEReps)           // Fixed
+			weModel.DurationSeconds = provider.NullInt64ToIntPtr(jwr.WEDurationSeconds)
+			weModel.DistanceMeters = provider.NullFloat64ToFloat64Ptr(jwr.WEDistanceMeters)
 			// --- FIX END ---
 
 			weModel.CreatedAt = jwr.WECreatedAt.Time
@@ -183,6 +195,7 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 			if jwr.ExID.Valid {
 				exModel.ID = jwr.ExID.UUID
 				exModel.Name = jwr.ExName.String
+				exModel.TrackingType = jwr.ExTracking.String
 				exModel.CreatedAt = jwr.ExCreatedAt.Time
 				exModel.UpdatedAt = jwr.ExUpdatedAt.Time
 				if jwr.ExDeletedAt.Valid {
@@ -241,5 +254,13 @@ func (h *WorkoutHandler) GetWorkout(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Workout not found")
 	}
 
+	if groupErr := h.attachExerciseGroups(c, workoutDTO); groupErr != nil {
+		return groupErr
+	}
+	if prescriptionErr := h.attachSetPrescriptions(c, workoutDTO); prescriptionErr != nil {
+		return prescriptionErr
+	}
+	h.personalizeWorkouts(c, workoutDTO)
+
 	return c.JSON(http.StatusOK, workoutDTO) // Return the single WorkoutResponse DTO
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/Masterminds/squirrel" // Import squirrel
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
 )
 
 // IndexWorkout retrieves a paginated list of workouts for a specific user, with optional filtering and sorting.
@@ -54,6 +55,28 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 		baseWhere = append(baseWhere, squirrel.ILike{"w.name": "%" + searchName + "%"})
 	}
 
+	// --- Add Query Param Filtering for 'folder_id' ('none' lists unfiled templates) ---
+	folderFilter := c.QueryParam("folder_id")
+	if folderFilter == "none" {
+		baseWhere = append(baseWhere, squirrel.Expr("w.folder_id IS NULL"))
+	} else if folderFilter != "" {
+		folderID, err := uuid.Parse(folderFilter)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid folder_id. Use a folder ID or 'none'.")
+		}
+		baseWhere = append(baseWhere, squirrel.Eq{"w.folder_id": folderID})
+	}
+
+	// --- Add Query Param Filtering for 'tag' (repeatable or comma-separated, all must match) ---
+	var tagFilter []string
+	for _, raw := range c.QueryParams()["tag"] {
+		tagFilter = append(tagFilter, strings.Split(raw, ",")...)
+	}
+	tagFilter = normalizeWorkoutTags(tagFilter)
+	if len(tagFilter) > 0 {
+		baseWhere = append(baseWhere, squirrel.Expr("w.tags @> ?", pq.Array(tagFilter)))
+	}
+
 	// --- Add Query Param Sorting ---
 	sortBy := c.QueryParam("sort")
 	orderBy := c.QueryParam("order")
@@ -64,6 +87,12 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 		"name":       "w.name",
 		"created_at": "w.created_at",
 		"updated_at": "w.updated_at", // Added updated_at as a sortable column
+		"position":   "w.position",
+	}
+
+	// Within a folder, templates are listed in their arranged order unless asked otherwise
+	if sortBy == "" && folderFilter != "" {
+		sortBy = "position"
 	}
 
 	// Default sorting for SQL query
@@ -105,12 +134,15 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 		WESets               sql.NullInt64   // workout_exercises.sets
 		WEWeight             sql.NullFloat64 // workout_exercises.weight
 		WEReps               sql.NullInt64   // workout_exercises.reps
+		WEDurationSeconds    sql.NullInt64   // workout_exercises.duration_seconds
+		WEDistanceMeters     sql.NullFloat64 // workout_exercises.distance_meters
 		WECreatedAt          sql.NullTime    // workout_exercises.created_at
 		WEUpdatedAt          sql.NullTime    // workout_exercises.updated_at
 		WEDeletedAt          sql.NullTime    // workout_exercises.deleted_at
 
 		ExID        uuid.NullUUID  // exercises.id
 		ExName      sql.NullString // exercises.name
+		ExTracking  sql.NullString // exercises.tracking_type
 		ExCreatedAt sql.NullTime   // exercises.created_at
 		ExUpdatedAt sql.NullTime   // exercises.updated_at
 		ExDeletedAt sql.NullTime   // exercises.deleted_at
@@ -126,12 +158,14 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 	selectBuilder := h.sq.Select(
 		// Workout fields (aliased as w)
 		"w.id", "w.user_id", "w.name", "w.created_at", "w.updated_at", "w.deleted_at",
+		"w.folder_id", "w.position", "w.tags",
 		// WorkoutExercise fields (aliased as we)
 		"we.id AS we_id", "we.workout_id AS we_workout_id", "we.exercise_id AS we_exercise_id", "we.exercise_instance_id AS we_exercise_instance_id",
 		"we.workout_order AS we_order", "we.sets AS we_sets", "we.weight AS we_weight", "we.reps AS we_reps",
+		"we.duration_seconds AS we_duration_seconds", "we.distance_meters AS we_distance_meters",
 		"we.created_at AS we_created_at", "we.updated_at AS we_updated_at", "we.deleted_at AS we_deleted_at",
 		// Exercise fields (aliased as e)
-		"e.id AS ex_id", "e.name AS ex_name", "e.created_at AS ex_created_at", "e.updated_at AS ex_updated_at", "e.deleted_at AS ex_deleted_at",
+		"e.id AS ex_id", "e.name AS ex_name", "e.tracking_type AS ex_tracking_type", "e.created_at AS ex_created_at", "e.updated_at AS ex_updated_at", "e.deleted_at AS ex_deleted_at",
 		// ExerciseInstance fields (aliased as ei)
 		"ei.id AS ei_id", "ei.workout_log_id AS ei_workout_log_id", "ei.exercise_id AS ei_exercise_id", "ei.created_at AS ei_created_at", "ei.updated_at AS ei_updated_at", "ei.deleted_at AS ei_deleted_at",
 	).
@@ -167,12 +201,13 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 		err := rows.Scan(
 			// Workout fields
 			&jwr.ID, &jwr.UserID, &jwr.Name, &jwr.CreatedAt, &jwr.UpdatedAt, &workoutDeletedAt,
+			&jwr.FolderID, &jwr.Position, pq.Array(&jwr.Tags),
 			// WorkoutExercise fields
 			&jwr.WEID, &jwr.WEWorkoutID, &jwr.WEExerciseID, &jwr.WEExerciseInstanceID,
-			&jwr.WEOrder, &jwr.WESets, &jwr.WEWeight, &jwr.WEReps,
+			&jwr.WEOrder, &jwr.WESets, &jwr.WEWeight, &jwr.WEReps, &jwr.WEDurationSeconds, &jwr.WEDistanceMeters,
 			&jwr.WECreatedAt, &jwr.WEUpdatedAt, &jwr.WEDeletedAt,
 			// Exercise fields
-			&jwr.ExID, &jwr.ExName, &jwr.ExCreatedAt, &jwr.ExUpdatedAt, &jwr.ExDeletedAt,
+			&jwr.ExID, &jwr.ExName, &jwr.ExTracking, &jwr.ExCreatedAt, &jwr.ExUpdatedAt, &jwr.ExDeletedAt,
 			// ExerciseInstance fields
 			&jwr.EiID, &jwr.EiWorkoutLogID, &jwr.EiExerciseID, &jwr.EiCreatedAt, &jwr.EiUpdatedAt, &jwr.EiDeletedAt,
 		)
@@ -195,6 +230,9 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 				UpdatedAt:        jwr.UpdatedAt,
 				DeletedAt:        jwr.DeletedAt,
 				WorkoutExercises: []dto.WorkoutExerciseResponse{}, // Initialize slice
+				FolderID:         jwr.FolderID,
+				Position:         jwr.Position,
+				Tags:             tagsOrEmpty(jwr.Tags),
 			}
 			workoutsMap[jwr.ID] = workoutDTO
 		}
@@ -211,6 +249,8 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 			weModel.Sets = provider.NullInt64ToIntPtr(jwr.WESets)
 			weModel.Weight = provider.NullFloat64ToFloat64Ptr(jwr.WEWeight)
 			weModel.Reps = provider.NullInt64ToIntPtr(jwr.WEReps)
+			weModel.DurationSeconds = provider.NullInt64ToIntPtr(jwr.WEDurationSeconds)
+			weModel.DistanceMeters = provider.NullFloat64ToFloat64Ptr(jwr.WEDistanceMeters)
 
 			weModel.CreatedAt = jwr.WECreatedAt.Time
 			// UpdatedAt for WorkoutExercise can be null in DB, ensure it's handled properly
@@ -226,6 +266,7 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 			if jwr.ExID.Valid {
 				exModel.ID = jwr.ExID.UUID
 				exModel.Name = jwr.ExName.String
+				exModel.TrackingType = jwr.ExTracking.String
 				exModel.CreatedAt = jwr.ExCreatedAt.Time
 				exModel.UpdatedAt = jwr.ExUpdatedAt.Time
 				exModel.DeletedAt = provider.NullTimeToTimePtr(jwr.ExDeletedAt)
@@ -295,6 +336,14 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 				return a.CreatedAt.After(b.CreatedAt)
 			}
 			return a.CreatedAt.Before(b.CreatedAt)
+		} else if sortBy == "position" {
+			if a.Position != b.Position {
+				if orderBy == "desc" {
+					return a.Position > b.Position
+				}
+				return a.Position < b.Position
+			}
+			return a.ID.String() < b.ID.String()
 		} else if sortBy == "updated_at" {
 			// Handle nulls for updated_at carefully.
 			// If both are null, consider them equal.
@@ -353,6 +402,18 @@ func (h *WorkoutHandler) IndexWorkout(c echo.Context) error {
 		paginationData.To = &zero
 	}
 
+	personalized := make([]*dto.WorkoutResponse, 0, len(dtoWorkouts))
+	for i := range dtoWorkouts {
+		personalized = append(personalized, &dtoWorkouts[i])
+	}
+	if groupErr := h.attachExerciseGroups(c, personalized...); groupErr != nil {
+		return groupErr
+	}
+	if prescriptionErr := h.attachSetPrescriptions(c, personalized...); prescriptionErr != nil {
+		return prescriptionErr
+	}
+	h.personalizeWorkouts(c, personalized...)
+
 	return c.JSON(http.StatusOK, dto.ListWorkoutResponse{
 		Data:               dtoWorkouts,
 		PaginationResponse: paginationData, // Embed the pagination data
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"rtglabs-go/dto"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// workoutFolderSelect selects the user's folders with the number of templates in each.
+func (h *WorkoutHandler) workoutFolderSelect(userID uuid.UUID) squirrel.SelectBuilder {
+	return h.sq.Select(
+		"f.id", "f.name", "f.position", "f.created_at", "f.updated_at", "COUNT(w.id)",
+	).
+		From("workout_folders AS f").
+		LeftJoin("workouts AS w ON w.folder_id = f.id AND w.deleted_at IS NULL").
+		Where(squirrel.Eq{"f.user_id": userID}).
+		GroupBy("f.id")
+}
+
+// scanWorkoutFolder scans a row selected by workoutFolderSelect.
+func scanWorkoutFolder(row interface{ Scan(...any) error }) (dto.WorkoutFolderResponse, error) {
+	var f dto.WorkoutFolderResponse
+	err := row.Scan(&f.ID, &f.Name, &f.Position, &f.CreatedAt, &f.UpdatedAt, &f.WorkoutCount)
+	return f, err
+}
+
+// fetchWorkoutFolder loads a single folder owned by the user.
+func (h *WorkoutHandler) fetchWorkoutFolder(ctx context.Context, userID, folderID uuid.UUID) (dto.WorkoutFolderResponse, error) {
+	query, args, err := h.workoutFolderSelect(userID).Where(squirrel.Eq{"f.id": folderID}).ToSql()
+	if err != nil {
+		return dto.WorkoutFolderResponse{}, err
+	}
+	return scanWorkoutFolder(h.DB.QueryRowContext(ctx, query, args...))
+}
+
+// IndexWorkoutFolder lists the user's workout template folders in display order.
+func (h *WorkoutHandler) IndexWorkoutFolder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		c.Logger().Error("IndexWorkoutFolder: User ID not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found")
+	}
+
+	query, args, err := h.workoutFolderSelect(userID).OrderBy("f.position ASC", "f.name ASC").ToSql()
+	if err != nil {
+		c.Logger().Errorf("IndexWorkoutFolder: Failed to build query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch workout folders")
+	}
+	rows, err := h.DB.QueryContext(c.Request().Context(), query, args...)
+	if err != nil {
+		c.Logger().Errorf("IndexWorkoutFolder: Failed to query folders: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch workout folders")
+	}
+	defer rows.Close()
+
+	folders := []dto.WorkoutFolderResponse{}
+	for rows.Next() {
+		folder, err := scanWorkoutFolder(rows)
+		if err != nil {
+			c.Logger().Errorf("IndexWorkoutFolder: Failed to scan folder: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch workout folders")
+		}
+		folders = append(folders, folder)
+	}
+	if err := rows.Err(); err != nil {
+		c.Logger().Errorf("IndexWorkoutFolder: Rows iteration error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch workout folders")
+	}
+
+	return c.JSON(http.StatusOK, dto.ListWorkoutFolderResponse{Data: folders})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
 )
 
 // StoreWorkout creates a new workout record and its associated workout exercises.
@@ -30,12 +31,42 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 		c.Logger().Warnf("StoreWorkout: Invalid request body received: %v", err) // Use Warn for client errors
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body format. Please check JSON syntax.")
 	}
+	req.Tags = normalizeWorkoutTags(req.Tags)
 	if err := c.Validate(&req); err != nil {
 		c.Logger().Warnf("StoreWorkout: Request validation failed: %v", err)
 		// Assuming c.Validate returns a user-friendly error message from validator
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err.Error()))
 	}
 
+	exerciseIDs := make([]uuid.UUID, 0, len(req.Exercises))
+	targets := make([]model.SetMetrics, 0, len(req.Exercises))
+	for _, exReq := range req.Exercises {
+		exerciseIDs = append(exerciseIDs, exReq.ExerciseID)
+		targets = append(targets, model.SetMetrics{
+			Weight:          exReq.Weight,
+			Reps:            toIntPtr(exReq.Reps),
+			DurationSeconds: toIntPtr(exReq.DurationSeconds),
+			DistanceMeters:  exReq.DistanceMeters,
+		})
+	}
+	if err := h.validateExerciseTargets(c, exerciseIDs, targets); err != nil {
+		return err
+	}
+	groupClientIDs := make([]*string, 0, len(req.Exercises))
+	for _, exReq := range req.Exercises {
+		groupClientIDs = append(groupClientIDs, exReq.GroupClientID)
+	}
+	if err := validateExerciseGroups(req.ExerciseGroups, groupClientIDs); err != nil {
+		return err
+	}
+	prescriptions := make([][]dto.SetPrescriptionRequest, 0, len(req.Exercises))
+	for _, exReq := range req.Exercises {
+		prescriptions = append(prescriptions, exReq.SetPrescriptions)
+	}
+	if err := h.validateSetPrescriptions(c, exerciseIDs, prescriptions); err != nil {
+		return err
+	}
+
 	ctx := c.Request().Context()
 
 	// Start a SQL transaction
@@ -66,9 +97,16 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 	now := time.Now().UTC()
 	createdWorkoutID = uuid.New()
 
+	// New templates go to the end of their folder.
+	position, posErr := h.nextWorkoutPosition(ctx, c, tx, userID, req.FolderID)
+	if posErr != nil {
+		err = posErr
+		return err
+	}
+
 	insertWorkoutBuilder := h.sq.Insert("workouts").
-		Columns("id", "user_id", "name", "created_at", "updated_at").
-		Values(createdWorkoutID, userID, req.Name, now, now)
+		Columns("id", "user_id", "name", "folder_id", "position", "tags", "created_at", "updated_at").
+		Values(createdWorkoutID, userID, req.Name, req.FolderID, position, pq.Array(req.Tags), now, now)
 
 	insertWorkoutQuery, insertWorkoutArgs, buildErr := insertWorkoutBuilder.ToSql()
 	if buildErr != nil {
@@ -84,6 +122,13 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 		return err
 	}
 
+	// Create the supersets, giant sets and circuits the exercises refer to
+	groupIDs, groupErr := h.insertExerciseGroups(ctx, c, tx, createdWorkoutID, req.ExerciseGroups, now)
+	if groupErr != nil {
+		err = groupErr
+		return err
+	}
+
 	// --- 2. Process Workout Exercises and Exercise Instances ---
 	var workoutExerciseColumns []string
 	var workoutExerciseValues [][]interface{}
@@ -91,9 +136,11 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 	workoutExerciseColumns = []string{
 		"id", "workout_id", "exercise_id", "exercise_instance_id",
 		"workout_order", "sets", "weight", "reps",
+		"duration_seconds", "distance_meters", "group_id",
 		"created_at", "updated_at",
 	}
 
+	prescribedWorkoutExerciseIDs := make(map[int]uuid.UUID) // Request index -> workout exercise with set prescriptions
 	for i, exReq := range req.Exercises {
 		var actualInstanceID uuid.UUID
 		var createInstance bool
@@ -118,7 +165,7 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 		if createInstance {
 			// Check if ExerciseID exists (important for foreign key integrity and user feedback)
 			checkExQuery, checkExArgs, buildErr := h.sq.Select("id").From("exercises").
-				Where(squirrel.Eq{"id": exReq.ExerciseID}).ToSql()
+				Where(squirrel.And{squirrel.Eq{"id": exReq.ExerciseID, "deleted_at": nil}, provider.ExerciseVisibleTo("", userID)}).ToSql()
 			if buildErr != nil {
 				err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Internal error: Could not build exercise check query for exercise #%d.", i+1))
 				return err
@@ -127,7 +174,7 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 			checkErr := tx.QueryRowContext(ctx, checkExQuery, checkExArgs...).Scan(&existsID)
 			if checkErr != nil {
 				if checkErr == sql.ErrNoRows {
-					err = echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid exercise ID '%s' found for exercise #%d. Exercise does not exist.", exReq.ExerciseID, i+1))
+					err = echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid exercise ID '%s' found for exercise #%d. Exercise does not exist or is not visible to you.", exReq.ExerciseID, i+1))
 					return err
 				}
 				c.Logger().Errorf("StoreWorkout: Database error checking exercise existence for ID %s: %v", exReq.ExerciseID, checkErr)
@@ -160,8 +207,8 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 		}
 
 		weID := uuid.New()
-		var order, sets, reps sql.NullInt64
-		var weight sql.NullFloat64
+		var order, sets, reps, duration sql.NullInt64
+		var weight, distance sql.NullFloat64
 
 		if exReq.WorkoutOrder != nil {
 			order.Valid = true
@@ -171,6 +218,11 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 			sets.Valid = true
 			sets.Int64 = int64(*exReq.Sets)
 		}
+		if len(exReq.SetPrescriptions) > 0 {
+			sets.Valid = true
+			sets.Int64 = int64(len(exReq.SetPrescriptions))
+			prescribedWorkoutExerciseIDs[i] = weID
+		}
 		if exReq.Weight != nil {
 			weight.Valid = true
 			weight.Float64 = *exReq.Weight
@@ -179,10 +231,19 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 			reps.Valid = true
 			reps.Int64 = int64(*exReq.Reps)
 		}
+		if exReq.DurationSeconds != nil {
+			duration.Valid = true
+			duration.Int64 = int64(*exReq.DurationSeconds)
+		}
+		if exReq.DistanceMeters != nil {
+			distance.Valid = true
+			distance.Float64 = *exReq.DistanceMeters
+		}
 
 		workoutExerciseValues = append(workoutExerciseValues, []interface{}{
 			weID, createdWorkoutID, exReq.ExerciseID, actualInstanceID,
 			order, sets, weight, reps,
+			duration, distance, exerciseGroupID(groupIDs, exReq.GroupClientID),
 			now, now,
 		})
 	}
@@ -205,6 +266,12 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 		}
 	}
 
+	for i, weID := range prescribedWorkoutExerciseIDs {
+		if err = h.insertSetPrescriptions(ctx, c, tx, weID, req.Exercises[i].SetPrescriptions, now); err != nil {
+			return err
+		}
+	}
+
 	// --- 3. Commit the transaction ---
 	if err = tx.Commit(); err != nil {
 		c.Logger().Errorf("StoreWorkout: Failed to commit transaction: %v", err)
@@ -215,6 +282,7 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 	workoutModel := &model.Workout{}
 	workoutSelectQuery, workoutSelectArgs, buildErr := h.sq.Select(
 		"id", "user_id", "name", "created_at", "updated_at", "deleted_at",
+		"folder_id", "position", "tags",
 	).From("workouts").Where(squirrel.Eq{"id": createdWorkoutID}).ToSql()
 	if buildErr != nil {
 		err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Internal error: Could not build final workout select query: %v", buildErr))
@@ -225,6 +293,7 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 	queryRowErr := h.DB.QueryRowContext(ctx, workoutSelectQuery, workoutSelectArgs...).Scan(
 		&workoutModel.ID, &workoutModel.UserID, &workoutModel.Name,
 		&workoutModel.CreatedAt, &workoutModel.UpdatedAt, &nullDeletedAt,
+		&workoutModel.FolderID, &workoutModel.Position, pq.Array(&workoutModel.Tags),
 	)
 	if queryRowErr != nil {
 		c.Logger().Errorf("StoreWorkout: Failed to fetch final workout: %v", queryRowErr)
@@ -249,8 +318,9 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 	selectJoinedQuery, selectJoinedArgs, buildErr := h.sq.Select(
 		"we.id", "we.workout_id", "we.exercise_id", "we.exercise_instance_id",
 		"we.workout_order", "we.sets", "we.weight", "we.reps",
+		"we.duration_seconds", "we.distance_meters",
 		"we.created_at", "we.updated_at", "we.deleted_at",
-		"e.id", "e.name", "e.created_at", "e.updated_at", "e.deleted_at",
+		"e.id", "e.name", "e.tracking_type", "e.created_at", "e.updated_at", "e.deleted_at",
 		"ei.id", "ei.workout_log_id", "ei.exercise_id", "ei.created_at", "ei.updated_at", "ei.deleted_at",
 	).
 		From("workout_exercises AS we").
@@ -275,8 +345,8 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 	for joinedRows.Next() {
 		var jwe model.WorkoutExercise // Scan directly into the model struct where possible
 		var weDeletedAt, exDeletedAt, eiDeletedAt sql.NullTime
-		var weOrder, weSets, weReps sql.NullInt64
-		var weWeight sql.NullFloat64
+		var weOrder, weSets, weReps, weDurationSeconds sql.NullInt64
+		var weWeight, weDistanceMeters sql.NullFloat64
 		var eiWorkoutLogID sql.Null[uuid.UUID]
 		var weExerciseInstanceID sql.Null[uuid.UUID]
 
@@ -287,8 +357,9 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 		scanErr := joinedRows.Scan(
 			&jwe.ID, &jwe.WorkoutID, &jwe.ExerciseID, &weExerciseInstanceID,
 			&weOrder, &weSets, &weWeight, &weReps,
+			&weDurationSeconds, &weDistanceMeters,
 			&jwe.CreatedAt, &jwe.UpdatedAt, &weDeletedAt,
-			&exModel.ID, &exModel.Name, &exModel.CreatedAt, &exModel.UpdatedAt, &exDeletedAt,
+			&exModel.ID, &exModel.Name, &exModel.TrackingType, &exModel.CreatedAt, &exModel.UpdatedAt, &exDeletedAt,
 			&eiModel.ID, &eiWorkoutLogID, &eiModel.ExerciseID, &eiModel.CreatedAt, &eiModel.UpdatedAt, &eiDeletedAt,
 		)
 		if scanErr != nil {
@@ -303,6 +374,8 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 		jwe.Sets = provider.NullInt64ToIntPtr(weSets)
 		jwe.Weight = provider.NullFloat64ToFloat64Ptr(weWeight)
 		jwe.Reps = provider.NullInt64ToIntPtr(weReps)
+		jwe.DurationSeconds = provider.NullInt64ToIntPtr(weDurationSeconds)
+		jwe.DistanceMeters = provider.NullFloat64ToFloat64Ptr(weDistanceMeters)
 
 		if weExerciseInstanceID.Valid {
 			jwe.ExerciseInstanceID = &weExerciseInstanceID.V
@@ -342,6 +415,13 @@ func (h *WorkoutHandler) StoreWorkout(c echo.Context) error {
 	}
 
 	finalWorkoutResponse := toWorkoutResponse(workoutModel, finalWorkoutExercisesDTO)
+	if groupErr := h.attachExerciseGroups(c, &finalWorkoutResponse); groupErr != nil {
+		return groupErr
+	}
+	if prescriptionErr := h.attachSetPrescriptions(c, &finalWorkoutResponse); prescriptionErr != nil {
+		return prescriptionErr
+	}
+	h.personalizeWorkouts(c, &finalWorkoutResponse)
 
 	return c.JSON(http.StatusCreated, dto.CreateWorkoutResponse{
 		Message: "Workout created successfully.",
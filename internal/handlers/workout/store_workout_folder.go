@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// StoreWorkoutFolder creates a workout template folder after the user's existing folders.
+func (h *WorkoutHandler) StoreWorkoutFolder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		c.Logger().Error("StoreWorkoutFolder: User ID not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found")
+	}
+
+	var req dto.CreateWorkoutFolderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body format. Please check JSON syntax.")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err.Error()))
+	}
+
+	now := time.Now()
+	folder := dto.WorkoutFolderResponse{ID: uuid.New(), Name: req.Name, CreatedAt: now, UpdatedAt: now}
+	query, args, err := h.sq.Insert("workout_folders").
+		Columns("id", "user_id", "name", "position", "created_at", "updated_at").
+		Values(folder.ID, userID, folder.Name,
+			squirrel.Expr("(SELECT COALESCE(MAX(position) + 1, 0) FROM workout_folders WHERE user_id = ?)", userID),
+			now, now).
+		Suffix("RETURNING position").
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("StoreWorkoutFolder: Failed to build insert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create workout folder")
+	}
+	if err := h.DB.QueryRowContext(c.Request().Context(), query, args...).Scan(&folder.Position); err != nil {
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "A folder with this name already exists")
+		}
+		c.Logger().Errorf("StoreWorkoutFolder: Failed to insert folder: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create workout folder")
+	}
+
+	return c.JSON(http.StatusCreated, folder)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/lib/pq"
 	"rtglabs-go/dto"
 	"rtglabs-go/model"
 	"rtglabs-go/provider" // Import the provider package for helper functions
@@ -33,11 +34,41 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		c.Logger().Warnf("UpdateWorkout: Invalid request body received: %v", err)
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body format. Please check JSON syntax.")
 	}
+	req.Tags = normalizeWorkoutTags(req.Tags)
 	if err := c.Validate(&req); err != nil {
 		c.Logger().Warnf("UpdateWorkout: Request validation failed: %v", err)
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err.Error()))
 	}
 
+	exerciseIDs := make([]uuid.UUID, 0, len(req.Exercises))
+	targets := make([]model.SetMetrics, 0, len(req.Exercises))
+	for _, exReq := range req.Exercises {
+		exerciseIDs = append(exerciseIDs, exReq.ExerciseID)
+		targets = append(targets, model.SetMetrics{
+			Weight:          exReq.Weight,
+			Reps:            toIntPtr(exReq.Reps),
+			DurationSeconds: toIntPtr(exReq.DurationSeconds),
+			DistanceMeters:  exReq.DistanceMeters,
+		})
+	}
+	if err := h.validateExerciseTargets(c, exerciseIDs, targets); err != nil {
+		return err
+	}
+	groupClientIDs := make([]*string, 0, len(req.Exercises))
+	for _, exReq := range req.Exercises {
+		groupClientIDs = append(groupClientIDs, exReq.GroupClientID)
+	}
+	if err := validateExerciseGroups(req.ExerciseGroups, groupClientIDs); err != nil {
+		return err
+	}
+	prescriptions := make([][]dto.SetPrescriptionRequest, 0, len(req.Exercises))
+	for _, exReq := range req.Exercises {
+		prescriptions = append(prescriptions, exReq.SetPrescriptions)
+	}
+	if err := h.validateSetPrescriptions(c, exerciseIDs, prescriptions); err != nil {
+		return err
+	}
+
 	ctx := c.Request().Context()
 
 	// --- 1. Fetch existing Workout and its WorkoutExercises to validate ownership and diff ---
@@ -157,6 +188,9 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		Set("name", req.Name).
 		Set("updated_at", now).
 		Where(squirrel.Eq{"id": workoutID})
+	if req.Tags != nil {
+		updateWorkoutBuilder = updateWorkoutBuilder.Set("tags", pq.Array(req.Tags))
+	}
 	updateWorkoutQuery, updateWorkoutArgs, buildErr := updateWorkoutBuilder.ToSql()
 	if buildErr != nil {
 		err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Internal error: Could not build workout name update query: %v", buildErr))
@@ -169,10 +203,33 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		return err
 	}
 
+	// Replace the exercise groups when they are sent; deleting the old ones ungroups their exercises
+	var groupIDs map[string]uuid.UUID
+	if req.ExerciseGroups != nil {
+		deleteGroupsQuery, deleteGroupsArgs, buildErr := h.sq.Delete("workout_exercise_groups").
+			Where(squirrel.Eq{"workout_id": workoutID}).ToSql()
+		if buildErr != nil {
+			err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Internal error: Could not build exercise groups delete query: %v", buildErr))
+			return err
+		}
+		if _, execErr = tx.ExecContext(ctx, deleteGroupsQuery, deleteGroupsArgs...); execErr != nil {
+			c.Logger().Errorf("UpdateWorkout: Database error deleting exercise groups for workout %s: %v", workoutID, execErr)
+			err = echo.NewHTTPError(http.StatusInternalServerError, "Database error: Failed to replace exercise groups.")
+			return err
+		}
+		var groupErr error
+		if groupIDs, groupErr = h.insertExerciseGroups(ctx, c, tx, workoutID, req.ExerciseGroups, now); groupErr != nil {
+			err = groupErr
+			return err
+		}
+	}
+
 	// --- 3. Diff workout_exercises and handle updates/deletes/creations ---
 	existingWEIDs := make(map[uuid.UUID]model.WorkoutExercise)
+	templateExerciseIDs := make(map[uuid.UUID]struct{})
 	for _, we := range existingWorkoutExercises {
 		existingWEIDs[we.ID] = we
+		templateExerciseIDs[we.ExerciseID] = struct{}{}
 	}
 
 	incomingWEsMap := make(map[uuid.UUID]dto.UpdateWorkoutExerciseRequest) // For quick lookup of incoming by ID
@@ -223,8 +280,13 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 			err = echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid exercise ID format for exercise #%d: %v", i+1, parseErr))
 			return err
 		}
-		checkExQuery, checkExArgs, buildErr := h.sq.Select("id").From("exercises").
-			Where(squirrel.Eq{"id": exReq.ExerciseID}).ToSql()
+		// Exercises deleted since they were added stay in the template, but can't be added anew
+		checkExBuilder := h.sq.Select("id").From("exercises").
+			Where(squirrel.And{squirrel.Eq{"id": exReq.ExerciseID}, provider.ExerciseVisibleTo("", userID)})
+		if _, inTemplate := templateExerciseIDs[exReq.ExerciseID]; !inTemplate {
+			checkExBuilder = checkExBuilder.Where(squirrel.Eq{"deleted_at": nil})
+		}
+		checkExQuery, checkExArgs, buildErr := checkExBuilder.ToSql()
 		if buildErr != nil {
 			err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Internal error: Could not build exercise existence check query for exercise #%d.", i+1))
 			return err
@@ -233,7 +295,7 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		checkErr := tx.QueryRowContext(ctx, checkExQuery, checkExArgs...).Scan(&existsID)
 		if checkErr != nil {
 			if checkErr == sql.ErrNoRows {
-				err = echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid exercise ID '%s' found for exercise #%d. Exercise does not exist.", exReq.ExerciseID, i+1))
+				err = echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid exercise ID '%s' found for exercise #%d. Exercise does not exist or is not visible to you.", exReq.ExerciseID, i+1))
 				return err
 			}
 			c.Logger().Errorf("UpdateWorkout: Database error checking exercise existence for ID %s (exercise #%d): %v", exReq.ExerciseID, i+1, checkErr)
@@ -329,6 +391,9 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		} else {
 			weValues["sets"] = nil
 		}
+		if len(exReq.SetPrescriptions) > 0 {
+			weValues["sets"] = len(exReq.SetPrescriptions)
+		}
 		if exReq.Weight != nil {
 			weValues["weight"] = *exReq.Weight
 		} else {
@@ -339,8 +404,23 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		} else { // Typo fix: exReq.Rps should be exReq.Reps
 			weValues["reps"] = nil
 		}
+		if exReq.DurationSeconds != nil {
+			weValues["duration_seconds"] = *exReq.DurationSeconds
+		} else {
+			weValues["duration_seconds"] = nil
+		}
+		if exReq.DistanceMeters != nil {
+			weValues["distance_meters"] = *exReq.DistanceMeters
+		} else {
+			weValues["distance_meters"] = nil
+		}
+		if req.ExerciseGroups != nil {
+			weValues["group_id"] = exerciseGroupID(groupIDs, exReq.GroupClientID)
+		}
 
+		var workoutExerciseID uuid.UUID
 		if exReq.ID != nil && existingWEIDs[*exReq.ID].ID != uuid.Nil {
+			workoutExerciseID = *exReq.ID
 			// This is an update to an existing WorkoutExercise
 			// Ensure the ID being updated actually belongs to THIS workout to prevent tampering
 			if existingWEIDs[*exReq.ID].WorkoutID != workoutID {
@@ -365,7 +445,8 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 			}
 		} else {
 			// This is a new WorkoutExercise (no ID provided in the request body for this specific WE)
-			weValues["id"] = uuid.New()
+			workoutExerciseID = uuid.New()
+			weValues["id"] = workoutExerciseID
 			weValues["created_at"] = now // Set creation time for new records
 
 			insertWEBuilder := h.sq.Insert("workout_exercises").SetMap(weValues)
@@ -381,6 +462,24 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 				return err
 			}
 		}
+
+		// Replace the per-set targets when they are sent
+		if exReq.SetPrescriptions != nil {
+			deletePrescriptionsQuery, deletePrescriptionsArgs, buildErr := h.sq.Delete("set_prescriptions").
+				Where(squirrel.Eq{"workout_exercise_id": workoutExerciseID}).ToSql()
+			if buildErr != nil {
+				err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Internal error: Could not build set prescriptions delete query for exercise #%d: %v", i+1, buildErr))
+				return err
+			}
+			if _, execErr = tx.ExecContext(ctx, deletePrescriptionsQuery, deletePrescriptionsArgs...); execErr != nil {
+				c.Logger().Errorf("UpdateWorkout: Database error deleting set prescriptions of workout exercise %s: %v", workoutExerciseID, execErr)
+				err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Database error: Failed to replace set prescriptions for exercise #%d.", i+1))
+				return err
+			}
+			if err = h.insertSetPrescriptions(ctx, c, tx, workoutExerciseID, exReq.SetPrescriptions, now); err != nil {
+				return err
+			}
+		}
 	}
 
 	// --- Commit the transaction ---
@@ -393,6 +492,7 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 	updatedWorkoutModel := &model.Workout{}
 	workoutSelectQuery, workoutSelectArgs, buildErr = h.sq.Select(
 		"id", "user_id", "name", "created_at", "updated_at", "deleted_at",
+		"folder_id", "position", "tags",
 	).From("workouts").Where(squirrel.Eq{"id": workoutID}).ToSql()
 	if buildErr != nil {
 		err = echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Internal error: Could not build final workout select query for workout %s: %v", workoutID, buildErr))
@@ -403,6 +503,7 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 	queryRowErr = h.DB.QueryRowContext(ctx, workoutSelectQuery, workoutSelectArgs...).Scan(
 		&updatedWorkoutModel.ID, &updatedWorkoutModel.UserID, &updatedWorkoutModel.Name,
 		&updatedWorkoutModel.CreatedAt, &updatedWorkoutModel.UpdatedAt, &finalNullWorkoutDeletedAt,
+		&updatedWorkoutModel.FolderID, &updatedWorkoutModel.Position, pq.Array(&updatedWorkoutModel.Tags),
 	)
 	if queryRowErr != nil {
 		c.Logger().Errorf("UpdateWorkout: Database error fetching final workout %s after update: %v", workoutID, queryRowErr)
@@ -425,9 +526,10 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		// WorkoutExercise fields (aliased as we)
 		"we.id", "we.workout_id", "we.exercise_id", "we.exercise_instance_id",
 		"we.workout_order", "we.sets", "we.weight", "we.reps",
+		"we.duration_seconds", "we.distance_meters",
 		"we.created_at", "we.updated_at", "we.deleted_at",
 		// Exercise fields (aliased as e) - Ensure these aliases match the Scan order
-		"e.id", "e.name", "e.created_at", "e.updated_at", "e.deleted_at",
+		"e.id", "e.name", "e.tracking_type", "e.created_at", "e.updated_at", "e.deleted_at",
 		// ExerciseInstance fields (aliased as ei) - Ensure these aliases match the Scan order
 		"ei.id", "ei.workout_log_id", "ei.exercise_id", "ei.created_at", "ei.updated_at", "ei.deleted_at",
 	).
@@ -459,16 +561,17 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		var eiModel model.ExerciseInstance // Scan directly into a model struct
 
 		var weDeletedAt, exDeletedAt, eiDeletedAt sql.NullTime
-		var weOrder, weSets, weReps sql.NullInt64
-		var weWeight sql.NullFloat64
+		var weOrder, weSets, weReps, weDurationSeconds sql.NullInt64
+		var weWeight, weDistanceMeters sql.NullFloat64
 		var eiWorkoutLogID sql.Null[uuid.UUID]
 		var weExerciseInstanceID sql.Null[uuid.UUID]
 
 		scanErr := joinedRows.Scan(
 			&weModel.ID, &weModel.WorkoutID, &weModel.ExerciseID, &weExerciseInstanceID,
 			&weOrder, &weSets, &weWeight, &weReps,
+			&weDurationSeconds, &weDistanceMeters,
 			&weModel.CreatedAt, &weModel.UpdatedAt, &weDeletedAt,
-			&exModel.ID, &exModel.Name, &exModel.CreatedAt, &exModel.UpdatedAt, &exDeletedAt,
+			&exModel.ID, &exModel.Name, &exModel.TrackingType, &exModel.CreatedAt, &exModel.UpdatedAt, &exDeletedAt,
 			&eiModel.ID, &eiWorkoutLogID, &eiModel.ExerciseID, &eiModel.CreatedAt, &eiModel.UpdatedAt, &eiDeletedAt,
 		)
 		if scanErr != nil {
@@ -482,6 +585,8 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 		weModel.Sets = provider.NullInt64ToIntPtr(weSets)
 		weModel.Weight = provider.NullFloat64ToFloat64Ptr(weWeight)
 		weModel.Reps = provider.NullInt64ToIntPtr(weReps)
+		weModel.DurationSeconds = provider.NullInt64ToIntPtr(weDurationSeconds)
+		weModel.DistanceMeters = provider.NullFloat64ToFloat64Ptr(weDistanceMeters)
 		// --- FIX END ---
 
 		if weExerciseInstanceID.Valid {
@@ -523,6 +628,13 @@ func (h *WorkoutHandler) UpdateWorkout(c echo.Context) error {
 
 	// Create the final workout response using the populated DTOs
 	finalWorkoutResponse := toWorkoutResponse(updatedWorkoutModel, finalWorkoutExercisesDTO)
+	if groupErr := h.attachExerciseGroups(c, &finalWorkoutResponse); groupErr != nil {
+		return groupErr
+	}
+	if prescriptionErr := h.attachSetPrescriptions(c, &finalWorkoutResponse); prescriptionErr != nil {
+		return prescriptionErr
+	}
+	h.personalizeWorkouts(c, &finalWorkoutResponse)
 
 	return c.JSON(http.StatusOK, dto.CreateWorkoutResponse{ // Changed to StatusOK as it's an update
 		Message: "Workout updated successfully.",
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"rtglabs-go/dto"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// UpdateWorkoutFolder renames a workout template folder and optionally moves it to a new position.
+func (h *WorkoutHandler) UpdateWorkoutFolder(c echo.Context) error {
+	userID, ok := c.Get("user_id").(uuid.UUID)
+	if !ok {
+		c.Logger().Error("UpdateWorkoutFolder: User ID not found in context")
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found")
+	}
+	folderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid folder ID")
+	}
+
+	var req dto.UpdateWorkoutFolderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body format. Please check JSON syntax.")
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Validation error: %v", err.Error()))
+	}
+
+	ctx := c.Request().Context()
+	builder := h.sq.Update("workout_folders").
+		Set("name", req.Name).
+		Set("updated_at", time.Now()).
+		Where(squirrel.Eq{"id": folderID, "user_id": userID})
+	if req.Position != nil {
+		builder = builder.Set("position", *req.Position)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		c.Logger().Errorf("UpdateWorkoutFolder: Failed to build update query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workout folder")
+	}
+	result, err := h.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		if provider.IsUniqueViolation(err) {
+			return echo.NewHTTPError(http.StatusConflict, "A folder with this name already exists")
+		}
+		c.Logger().Errorf("UpdateWorkoutFolder: Failed to update folder %s: %v", folderID, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workout folder")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, "Workout folder not found")
+	}
+
+	folder, err := h.fetchWorkoutFolder(ctx, userID, folderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusNotFound, "Workout folder not found")
+		}
+		c.Logger().Errorf("UpdateWorkoutFolder: Failed to reload folder %s: %v", folderID, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workout folder")
+	}
+
+	return c.JSON(http.StatusOK, folder)
+}
@@ -1,12 +1,21 @@
 package handler
 
 import (
+	"context"
 	"database/sql" // For *sql.DB, sql.Null* types
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Masterminds/squirrel" // Import squirrel
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 	"rtglabs-go/dto"
+	ex_handlers "rtglabs-go/internal/handlers/exercise" // Shared exercise personalization
+	"rtglabs-go/internal/middleware"
 	"rtglabs-go/model" // Import your model package (Workout, WorkoutExercise etc.)
+	"rtglabs-go/provider"
 )
 
 // WorkoutHandler holds the database client and squirrel statement builder.
@@ -35,6 +44,36 @@ func toUintPtr(i *int) *uint {
 	return &u
 }
 
+// toIntPtr converts an *uint to an *int. It returns nil if the input is nil.
+func toIntPtr(u *uint) *int {
+	if u == nil {
+		return nil
+	}
+	i := int(*u)
+	return &i
+}
+
+// validateExerciseTargets checks the targets of each workout exercise against the tracking type
+// of its exercise, e.g. a plank can have a target duration but no target weight. targets[i]
+// belongs to exerciseIDs[i]; unknown exercises are left to the existence checks.
+func (h *WorkoutHandler) validateExerciseTargets(c echo.Context, exerciseIDs []uuid.UUID, targets []model.SetMetrics) error {
+	trackingTypes, err := provider.FetchExerciseTrackingTypes(c.Request().Context(), h.DB, h.sq, exerciseIDs)
+	if err != nil {
+		c.Logger().Errorf("validateExerciseTargets: Failed to fetch exercise tracking types: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: Could not check exercise tracking types.")
+	}
+	for i, exerciseID := range exerciseIDs {
+		trackingType, found := trackingTypes[exerciseID]
+		if !found {
+			continue
+		}
+		if err := model.ValidateSetMetrics(trackingType, targets[i], false); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Validation error: exercise #%d: %v", i+1, err))
+		}
+	}
+	return nil
+}
+
 // toWorkoutResponse converts a model.Workout entity to a dto.WorkoutResponse DTO.
 // It will need to fetch WorkoutExercises separately, as SQL won't load edges automatically.
 
@@ -52,6 +91,9 @@ func toWorkoutResponse(w *model.Workout, workoutExercisesDTO []dto.WorkoutExerci
 		UpdatedAt:        w.UpdatedAt,
 		DeletedAt:        deletedAt,
 		WorkoutExercises: workoutExercisesDTO, // <--- DIRECTLY USE THE PASSED DTO SLICE
+		FolderID:         w.FolderID,
+		Position:         w.Position,
+		Tags:             tagsOrEmpty(w.Tags),
 	}
 }
 
@@ -93,6 +135,8 @@ func toWorkoutExerciseResponse(
 		Sets:             toUintPtr(we.Sets),
 		Weight:           we.Weight, // Weight is *float64 in both, so no change needed
 		Reps:             toUintPtr(we.Reps),
+		DurationSeconds:  toUintPtr(we.DurationSeconds),
+		DistanceMeters:   we.DistanceMeters,
 		CreatedAt:        we.CreatedAt,
 		UpdatedAt:        we.UpdatedAt,
 		DeletedAt:        deletedAt,
@@ -108,11 +152,12 @@ func toExerciseResponse(ex *model.Exercise) dto.ExerciseResponse {
 		deletedAt = ex.DeletedAt
 	}
 	return dto.ExerciseResponse{
-		ID:        ex.ID,
-		Name:      ex.Name,
-		CreatedAt: ex.CreatedAt,
-		UpdatedAt: ex.UpdatedAt,
-		DeletedAt: deletedAt,
+		ID:           ex.ID,
+		Name:         ex.Name,
+		TrackingType: ex.TrackingType,
+		CreatedAt:    ex.CreatedAt,
+		UpdatedAt:    ex.UpdatedAt,
+		DeletedAt:    deletedAt,
 	}
 }
 
@@ -135,3 +180,319 @@ func toExerciseInstanceResponse(ei *model.ExerciseInstance) dto.ExerciseInstance
 		DeletedAt:    deletedAt,
 	}
 }
+
+// personalizeWorkouts personalizes the exercises nested in the workouts, see
+// ex_handlers.PersonalizeExercises.
+func (h *WorkoutHandler) personalizeWorkouts(c echo.Context, workouts ...*dto.WorkoutResponse) {
+	var exercises []*dto.ExerciseResponse
+	for _, w := range workouts {
+		for _, we := range w.WorkoutExercises {
+			if we.Exercise != nil {
+				exercises = append(exercises, we.Exercise)
+			}
+		}
+	}
+	userID, _ := c.Get("user_id").(uuid.UUID)
+	locale := middleware.RequestLocale(c)
+	if err := ex_handlers.PersonalizeExercises(c.Request().Context(), h.DB, h.sq, userID, locale, exercises); err != nil {
+		c.Logger().Warnf("personalizeWorkouts: %v", err)
+	}
+}
+
+// tagsOrEmpty returns tags, or an empty slice when tags is nil so it serializes as [].
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// normalizeWorkoutTags trims and lowercases tags, dropping empty and duplicate ones.
+// A nil slice stays nil so updates can tell "not provided" apart from "clear all tags".
+func normalizeWorkoutTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// folderCondition matches workouts in the given folder, or unfiled workouts when folderID is nil.
+func folderCondition(folderID *uuid.UUID) squirrel.Sqlizer {
+	if folderID == nil {
+		return squirrel.Expr("folder_id IS NULL")
+	}
+	return squirrel.Eq{"folder_id": *folderID}
+}
+
+// ensureWorkoutFolder checks that the folder exists and belongs to the user.
+// A nil folderID (top level) is always valid.
+func (h *WorkoutHandler) ensureWorkoutFolder(ctx context.Context, c echo.Context, q rowQuerier, userID uuid.UUID, folderID *uuid.UUID) error {
+	if folderID == nil {
+		return nil
+	}
+	query, args, err := h.sq.Select("1").From("workout_folders").
+		Where(squirrel.Eq{"id": *folderID, "user_id": userID}).ToSql()
+	if err != nil {
+		c.Logger().Errorf("ensureWorkoutFolder: Failed to build query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+	var exists int
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "Workout folder not found.")
+		}
+		c.Logger().Errorf("ensureWorkoutFolder: Failed to check folder %s: %v", *folderID, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error")
+	}
+	return nil
+}
+
+// nextWorkoutPosition validates the folder and returns the position after the last
+// template in it, so new templates are appended at the end.
+func (h *WorkoutHandler) nextWorkoutPosition(ctx context.Context, c echo.Context, q rowQuerier, userID uuid.UUID, folderID *uuid.UUID) (int, error) {
+	if err := h.ensureWorkoutFolder(ctx, c, q, userID, folderID); err != nil {
+		return 0, err
+	}
+	query, args, err := h.sq.Select("COALESCE(MAX(position) + 1, 0)").From("workouts").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(folderCondition(folderID)).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		c.Logger().Errorf("nextWorkoutPosition: Failed to build query: %v", err)
+		return 0, echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+	}
+	var position int
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&position); err != nil {
+		c.Logger().Errorf("nextWorkoutPosition: Failed to compute position: %v", err)
+		return 0, echo.NewHTTPError(http.StatusInternalServerError, "Database error")
+	}
+	return position, nil
+}
+
+// validateExerciseGroups checks the exercise groups of a template request against the exercises
+// referencing them. groupClientIDs holds each exercise's group_client_id, in request order.
+func validateExerciseGroups(groups []dto.ExerciseGroupRequest, groupClientIDs []*string) error {
+	members := make(map[string]int, len(groups))
+	for _, g := range groups {
+		if _, dup := members[g.ClientID]; dup {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Exercise group client_id '%s' is used more than once.", g.ClientID))
+		}
+		members[g.ClientID] = 0
+	}
+	for i, clientID := range groupClientIDs {
+		if clientID == nil || *clientID == "" {
+			continue
+		}
+		if _, ok := members[*clientID]; !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Exercise #%d references unknown exercise group '%s'.", i+1, *clientID))
+		}
+		members[*clientID]++
+	}
+	for _, g := range groups {
+		if err := model.ValidateExerciseGroupSize(g.GroupType, members[g.ClientID]); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Exercise group '%s': %v.", g.ClientID, err))
+		}
+	}
+	return nil
+}
+
+// insertExerciseGroups creates the groups of a workout template and returns their IDs keyed by client_id.
+func (h *WorkoutHandler) insertExerciseGroups(ctx context.Context, c echo.Context, tx *sql.Tx, workoutID uuid.UUID, groups []dto.ExerciseGroupRequest, now time.Time) (map[string]uuid.UUID, error) {
+	groupIDs := make(map[string]uuid.UUID, len(groups))
+	if len(groups) == 0 {
+		return groupIDs, nil
+	}
+	builder := h.sq.Insert("workout_exercise_groups").Columns(
+		"id", "workout_id", "group_type", "rounds",
+		"rest_between_exercises_seconds", "rest_between_rounds_seconds", "created_at", "updated_at",
+	)
+	for _, g := range groups {
+		rounds := g.Rounds
+		if rounds < 1 {
+			rounds = 1
+		}
+		groupIDs[g.ClientID] = uuid.New()
+		builder = builder.Values(
+			groupIDs[g.ClientID], workoutID, g.GroupType, rounds,
+			g.RestBetweenExercisesSeconds, g.RestBetweenRoundsSeconds, now, now,
+		)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		c.Logger().Errorf("insertExerciseGroups: Failed to build insert query: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Internal error: Could not build exercise groups insert query.")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		c.Logger().Errorf("insertExerciseGroups: Failed to insert groups for workout %s: %v", workoutID, err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Database error: Could not save exercise groups.")
+	}
+	return groupIDs, nil
+}
+
+// exerciseGroupID resolves an exercise's group_client_id to the group created for it, nil for none.
+func exerciseGroupID(groupIDs map[string]uuid.UUID, clientID *string) *uuid.UUID {
+	if clientID == nil {
+		return nil
+	}
+	if id, ok := groupIDs[*clientID]; ok {
+		return &id
+	}
+	return nil
+}
+
+// attachExerciseGroups loads the exercise groups of the workouts and sets the group_id of their exercises.
+func (h *WorkoutHandler) attachExerciseGroups(c echo.Context, workouts ...*dto.WorkoutResponse) error {
+	workoutIDs := make([]uuid.UUID, 0, len(workouts))
+	for _, w := range workouts {
+		workoutIDs = append(workoutIDs, w.ID)
+	}
+	groups, err := provider.FetchWorkoutExerciseGroups(c.Request().Context(), h.DB, h.sq, workoutIDs)
+	if err != nil {
+		c.Logger().Errorf("attachExerciseGroups: Failed to fetch exercise groups: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch exercise groups")
+	}
+	for _, w := range workouts {
+		w.ExerciseGroups = make([]dto.ExerciseGroupResponse, 0, len(groups[w.ID]))
+		groupOf := make(map[uuid.UUID]uuid.UUID)
+		for _, g := range groups[w.ID] {
+			w.ExerciseGroups = append(w.ExerciseGroups, dto.ExerciseGroupResponse{
+				ID:                          g.ID,
+				GroupType:                   g.GroupType,
+				Rounds:                      g.Rounds,
+				RestBetweenExercisesSeconds: g.RestBetweenExercisesSeconds,
+				RestBetweenRoundsSeconds:    g.RestBetweenRoundsSeconds,
+			})
+			for _, memberID := range g.MemberIDs {
+				groupOf[memberID] = g.ID
+			}
+		}
+		for i := range w.WorkoutExercises {
+			if groupID, ok := groupOf[w.WorkoutExercises[i].ID]; ok {
+				w.WorkoutExercises[i].GroupID = &groupID
+			}
+		}
+	}
+	return nil
+}
+
+// validateSetPrescriptions checks the per-set targets of each workout exercise against the tracking
+// type of its exercise. prescriptions[i] belongs to exerciseIDs[i]; unknown exercises are left to the
+// existence checks.
+func (h *WorkoutHandler) validateSetPrescriptions(c echo.Context, exerciseIDs []uuid.UUID, prescriptions [][]dto.SetPrescriptionRequest) error {
+	trackingTypes, err := provider.FetchExerciseTrackingTypes(c.Request().Context(), h.DB, h.sq, exerciseIDs)
+	if err != nil {
+		c.Logger().Errorf("validateSetPrescriptions: Failed to fetch exercise tracking types: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: Could not check exercise tracking types.")
+	}
+	for i, exerciseID := range exerciseIDs {
+		for j, p := range prescriptions[i] {
+			if problem := setPrescriptionProblem(trackingTypes[exerciseID], p); problem != "" {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Validation error: exercise #%d, set #%d: %s", i+1, j+1, problem))
+			}
+		}
+	}
+	return nil
+}
+
+// setPrescriptionProblem describes what is wrong with p for an exercise of trackingType, or returns "".
+func setPrescriptionProblem(trackingType string, p dto.SetPrescriptionRequest) string {
+	if p.TargetRepsMax != nil {
+		if p.TargetReps == nil {
+			return "a rep range needs target_reps as its lower end"
+		}
+		if *p.TargetRepsMax < *p.TargetReps {
+			return "target_reps_max can't be below target_reps"
+		}
+	}
+	if p.TargetWeight != nil && p.TargetPercent1RM != nil {
+		return "use either target_weight or target_percent_1rm, not both"
+	}
+	if trackingType == "" {
+		return ""
+	}
+	if p.TargetPercent1RM != nil && !model.TrackingMetrics(trackingType).Weight {
+		return fmt.Sprintf("%s exercises don't record weight", trackingType)
+	}
+	if err := model.ValidateSetMetrics(trackingType, model.SetMetrics{Weight: p.TargetWeight, Reps: p.TargetReps}, false); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// insertSetPrescriptions stores the per-set targets of a workout exercise, numbering the sets from 1.
+func (h *WorkoutHandler) insertSetPrescriptions(ctx context.Context, c echo.Context, tx *sql.Tx, workoutExerciseID uuid.UUID, prescriptions []dto.SetPrescriptionRequest, now time.Time) error {
+	if len(prescriptions) == 0 {
+		return nil
+	}
+	builder := h.sq.Insert("set_prescriptions").Columns(
+		"id", "workout_exercise_id", "set_number", "set_type", "target_reps", "target_reps_max",
+		"target_weight", "target_percent_1rm", "target_rpe", "rest_seconds", "created_at", "updated_at",
+	)
+	for i, p := range prescriptions {
+		builder = builder.Values(
+			uuid.New(), workoutExerciseID, i+1, p.SetType, p.TargetReps, p.TargetRepsMax,
+			p.TargetWeight, p.TargetPercent1RM, p.TargetRPE, p.RestSeconds, now, now,
+		)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		c.Logger().Errorf("insertSetPrescriptions: Failed to build insert query: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal error: Could not build set prescriptions insert query.")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		c.Logger().Errorf("insertSetPrescriptions: Failed to insert set prescriptions for workout exercise %s: %v", workoutExerciseID, err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Database error: Could not save set prescriptions.")
+	}
+	return nil
+}
+
+// attachSetPrescriptions loads the per-set targets of the workouts' exercises.
+func (h *WorkoutHandler) attachSetPrescriptions(c echo.Context, workouts ...*dto.WorkoutResponse) error {
+	var workoutExerciseIDs []uuid.UUID
+	for _, w := range workouts {
+		for _, we := range w.WorkoutExercises {
+			workoutExerciseIDs = append(workoutExerciseIDs, we.ID)
+		}
+	}
+	prescriptions, err := provider.FetchSetPrescriptions(c.Request().Context(), h.DB, h.sq, workoutExerciseIDs)
+	if err != nil {
+		c.Logger().Errorf("attachSetPrescriptions: Failed to fetch set prescriptions: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch set prescriptions")
+	}
+	for _, w := range workouts {
+		for i := range w.WorkoutExercises {
+			we := &w.WorkoutExercises[i]
+			we.SetPrescriptions = make([]dto.SetPrescriptionResponse, 0, len(prescriptions[we.ID]))
+			for _, p := range prescriptions[we.ID] {
+				we.SetPrescriptions = append(we.SetPrescriptions, dto.SetPrescriptionResponse{
+					ID:               p.ID,
+					SetNumber:        p.SetNumber,
+					SetType:          p.SetType,
+					TargetReps:       p.TargetReps,
+					TargetRepsMax:    p.TargetRepsMax,
+					TargetWeight:     p.TargetWeight,
+					TargetPercent1RM: p.TargetPercent1RM,
+					TargetRPE:        p.TargetRPE,
+					RestSeconds:      p.RestSeconds,
+				})
+			}
+		}
+	}
+	return nil
+}
@@ -33,14 +33,15 @@ func (h *WorkoutLogHandler) ShowWorkoutLog(c echo.Context) error {
 			w.id AS w_id, w.user_id AS w_user_id, w.name AS w_name, w.created_at AS w_created_at, w.updated_at AS w_updated_at, w.deleted_at AS w_deleted_at,
 			lei.id AS lei_id, lei.workout_log_id AS lei_workout_log_id, lei.exercise_id AS lei_exercise_id,
 			lei.created_at AS lei_created_at, lei.updated_at AS lei_updated_at, lei.deleted_at AS lei_deleted_at,
-			e.id AS e_id, e.name AS e_name, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at,
+			e.id AS e_id, e.name AS e_name, e.tracking_type AS e_tracking_type, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at,
 			es.id AS es_id, es.workout_log_id AS es_workout_log_id, es.exercise_id AS es_exercise_id,
-			es.logged_exercise_instance_id AS es_lei_id, es.set_number, es.weight, es.reps,
-			es.finished_at AS es_finished_at, es.status AS es_status, es.created_at AS es_created_at, es.updated_at AS es_updated_at, es.deleted_at AS es_deleted_at
+			es.logged_exercise_instance_id AS es_lei_id, es.set_number, es.weight, es.reps, es.duration_seconds, es.distance_meters,
+			es.finished_at AS es_finished_at, es.status AS es_status, es.created_at AS es_created_at, es.updated_at AS es_updated_at, es.deleted_at AS es_deleted_at,
+			es.set_type AS es_set_type, es.target_reps_max AS es_target_reps_max, es.target_rpe AS es_target_rpe, es.rest_seconds AS es_rest_seconds
 		FROM workout_logs AS wl
 		LEFT JOIN workouts AS w ON wl.workout_id = w.id
 		LEFT JOIN logged_exercise_instances AS lei ON wl.id = lei.workout_log_id AND lei.deleted_at IS NULL
-		LEFT JOIN exercises AS e ON lei.exercise_id = e.id AND e.deleted_at IS NULL
+		LEFT JOIN exercises AS e ON lei.exercise_id = e.id
 		LEFT JOIN exercise_sets AS es ON lei.id = es.logged_exercise_instance_id AND es.deleted_at IS NULL
 		WHERE wl.id = $1 AND wl.user_id = $2 AND wl.deleted_at IS NULL
 		ORDER BY lei.created_at ASC, es.set_number ASC;
@@ -82,15 +83,22 @@ func (h *WorkoutLogHandler) ShowWorkoutLog(c echo.Context) error {
 
 			eID                                sql.Null[uuid.UUID]
 			eName                              sql.NullString
+			eTrackingType                      sql.NullString
 			eCreatedAt, eUpdatedAt, eDeletedAt sql.NullTime
 
 			esID, esWorkoutLogID, esExerciseID, esLeiID sql.Null[uuid.UUID]
 			esSetNumber                                 sql.NullInt64
 			esWeight                                    sql.NullFloat64
 			esReps                                      sql.NullInt64
+			esDurationSeconds                           sql.NullInt64
+			esDistanceMeters                            sql.NullFloat64
 			esFinishedAt                                sql.NullTime
 			esStatus                                    sql.NullInt64
 			esCreatedAt, esUpdatedAt, esDeletedAt       sql.NullTime
+			esSetType                                   sql.NullString
+			esTargetRepsMax                             sql.NullInt64
+			esTargetRPE                                 sql.NullFloat64
+			esRestSeconds                               sql.NullInt64
 		)
 
 		scanErr := rows.Scan(
@@ -99,9 +107,10 @@ func (h *WorkoutLogHandler) ShowWorkoutLog(c echo.Context) error {
 			&wlCreatedAt, &wlUpdatedAt, &wlDeletedAt,
 			&wID, &wUserID, &wName, &wCreatedAt, &wUpdatedAt, &wDeletedAt,
 			&leiID, &leiWorkoutLogID, &leiExerciseID, &leiCreatedAt, &leiUpdatedAt, &leiDeletedAt,
-			&eID, &eName, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
-			&esID, &esWorkoutLogID, &esExerciseID, &esLeiID, &esSetNumber, &esWeight, &esReps,
+			&eID, &eName, &eTrackingType, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
+			&esID, &esWorkoutLogID, &esExerciseID, &esLeiID, &esSetNumber, &esWeight, &esReps, &esDurationSeconds, &esDistanceMeters,
 			&esFinishedAt, &esStatus, &esCreatedAt, &esUpdatedAt, &esDeletedAt,
+			&esSetType, &esTargetRepsMax, &esTargetRPE, &esRestSeconds,
 		)
 		if scanErr != nil {
 			c.Logger().Errorf("ShowWorkoutLog: Failed to scan workout log row: %v", scanErr)
@@ -164,11 +173,12 @@ func (h *WorkoutLogHandler) ShowWorkoutLog(c echo.Context) error {
 					UpdatedAt:    leiUpdatedAt.Time,
 					DeletedAt:    provider.NullTimeToTimePtr(leiDeletedAt),
 					Exercise: dto.ExerciseResponse{
-						ID:        eID.V,
-						Name:      eName.String,
-						CreatedAt: eCreatedAt.Time,
-						UpdatedAt: eUpdatedAt.Time,
-						DeletedAt: provider.NullTimeToTimePtr(eDeletedAt),
+						ID:           eID.V,
+						Name:         eName.String,
+						TrackingType: eTrackingType.String,
+						CreatedAt:    eCreatedAt.Time,
+						UpdatedAt:    eUpdatedAt.Time,
+						DeletedAt:    provider.NullTimeToTimePtr(eDeletedAt),
 					},
 					ExerciseSets: []dto.ExerciseSetResponse{},
 				}
@@ -190,11 +200,17 @@ func (h *WorkoutLogHandler) ShowWorkoutLog(c echo.Context) error {
 						SetNumber:                provider.NullInt64ToIntPtr(esSetNumber),
 						Weight:                   provider.NullFloat64ToFloat64(esWeight),
 						Reps:                     provider.NullInt64ToIntPtr(esReps),
+						DurationSeconds:          provider.NullInt64ToIntPtr(esDurationSeconds),
+						DistanceMeters:           provider.NullFloat64ToFloat64Ptr(esDistanceMeters),
 						FinishedAt:               provider.NullTimeToTimePtr(esFinishedAt),
 						Status:                   provider.NullInt64ToInt(esStatus),
 						CreatedAt:                esCreatedAt.Time,
 						UpdatedAt:                esUpdatedAt.Time,
 						DeletedAt:                provider.NullTimeToTimePtr(esDeletedAt),
+						SetType:                  provider.NullStringToStringPtr(esSetType),
+						TargetRepsMax:            provider.NullInt64ToIntPtr(esTargetRepsMax),
+						TargetRPE:                provider.NullFloat64ToFloat64Ptr(esTargetRPE),
+						RestSeconds:              provider.NullInt64ToIntPtr(esRestSeconds),
 					})
 			}
 		}
@@ -211,6 +227,10 @@ func (h *WorkoutLogHandler) ShowWorkoutLog(c echo.Context) error {
 
 	// Assign the ordered slice to the workout log
 	workoutLog.LoggedExerciseInstances = exerciseInstances
+	if groupErr := h.attachExerciseGroups(c, &workoutLog); groupErr != nil {
+		return groupErr
+	}
+	h.personalizeWorkoutLogs(c, &workoutLog)
 
 	return c.JSON(http.StatusOK, workoutLog)
 }
@@ -207,6 +207,7 @@ func (h *WorkoutLogHandler) IndexWorkoutLog(c echo.Context) error {
 
 		ExID        uuid.NullUUID
 		ExName      sql.NullString
+		ExTracking  sql.NullString
 		ExCreatedAt sql.NullTime
 		ExUpdatedAt sql.NullTime
 		ExDeletedAt sql.NullTime
@@ -217,12 +218,18 @@ func (h *WorkoutLogHandler) IndexWorkoutLog(c echo.Context) error {
 		ESLoggedExerciseInstanceID uuid.NullUUID
 		ESWeight                   sql.NullFloat64
 		ESReps                     sql.NullInt64
+		ESDurationSeconds          sql.NullInt64
+		ESDistanceMeters           sql.NullFloat64
 		ESSetNumber                sql.NullInt64
 		ESFinishedAt               sql.NullTime
 		ESStatus                   sql.NullInt64
 		ESCreatedAt                sql.NullTime
 		ESUpdatedAt                sql.NullTime
 		ESDeletedAt                sql.NullTime
+		ESSetType                  sql.NullString
+		ESTargetRepsMax            sql.NullInt64
+		ESTargetRPE                sql.NullFloat64
+		ESRestSeconds              sql.NullInt64
 	}
 
 	// Build the main data query using the fetched workoutLogIDs
@@ -235,15 +242,16 @@ func (h *WorkoutLogHandler) IndexWorkoutLog(c echo.Context) error {
 		"w.created_at AS w_created_at", "w.updated_at AS w_updated_at", "w.deleted_at AS w_deleted_at",
 		"lei.id AS lei_id", "lei.workout_log_id AS lei_workout_log_id", "lei.exercise_id AS lei_exercise_id",
 		"lei.created_at AS lei_created_at", "lei.updated_at AS lei_updated_at", "lei.deleted_at AS lei_deleted_at",
-		"ex.id AS ex_id", "ex.name AS ex_name", "ex.created_at AS ex_created_at", "ex.updated_at AS ex_updated_at", "ex.deleted_at AS ex_deleted_at",
+		"ex.id AS ex_id", "ex.name AS ex_name", "ex.tracking_type AS ex_tracking_type", "ex.created_at AS ex_created_at", "ex.updated_at AS ex_updated_at", "ex.deleted_at AS ex_deleted_at",
 		"es.id AS es_id", "es.workout_log_id AS es_workout_log_id", "es.exercise_id AS es_exercise_id", "es.logged_exercise_instance_id AS es_logged_exercise_instance_id",
-		"es.weight AS es_weight", "es.reps AS es_reps", "es.set_number AS es_set_number", "es.finished_at AS es_finished_at", "es.status AS es_status",
+		"es.weight AS es_weight", "es.reps AS es_reps", "es.duration_seconds AS es_duration_seconds", "es.distance_meters AS es_distance_meters", "es.set_number AS es_set_number", "es.finished_at AS es_finished_at", "es.status AS es_status",
 		"es.created_at AS es_created_at", "es.updated_at AS es_updated_at", "es.deleted_at AS es_deleted_at",
+		"es.set_type AS es_set_type", "es.target_reps_max AS es_target_reps_max", "es.target_rpe AS es_target_rpe", "es.rest_seconds AS es_rest_seconds",
 	).
 		From("workout_logs AS wl").
 		LeftJoin("workouts AS w ON wl.workout_id = w.id AND w.deleted_at IS NULL"). // Always join for main data query
 		LeftJoin("logged_exercise_instances AS lei ON wl.id = lei.workout_log_id AND lei.deleted_at IS NULL").
-		LeftJoin("exercises AS ex ON lei.exercise_id = ex.id AND ex.deleted_at IS NULL").
+		LeftJoin("exercises AS ex ON lei.exercise_id = ex.id").
 		LeftJoin("exercise_sets AS es ON lei.id = es.logged_exercise_instance_id AND es.deleted_at IS NULL").
 		// Crucially, filter by the IDs we just paginated
 		Where(squirrel.Eq{"wl.id": workoutLogIDs}).
@@ -275,10 +283,11 @@ func (h *WorkoutLogHandler) IndexWorkoutLog(c echo.Context) error {
 			&jwlr.CreatedAt, &jwlr.UpdatedAt, &jwlr.DeletedAt,
 			&jwlr.WID, &jwlr.WUserID, &jwlr.WName, &jwlr.WCreatedAt, &jwlr.WUpdatedAt, &jwlr.WDeletedAt, // <--- Scan w.name here
 			&jwlr.LEIID, &jwlr.LEIWorkoutLogID, &jwlr.LEIExerciseID, &jwlr.LEICreatedAt, &jwlr.LEIUpdatedAt, &jwlr.LEIDeletedAt,
-			&jwlr.ExID, &jwlr.ExName, &jwlr.ExCreatedAt, &jwlr.ExUpdatedAt, &jwlr.ExDeletedAt,
+			&jwlr.ExID, &jwlr.ExName, &jwlr.ExTracking, &jwlr.ExCreatedAt, &jwlr.ExUpdatedAt, &jwlr.ExDeletedAt,
 			&jwlr.ESID, &jwlr.ESWorkoutLogID, &jwlr.ESExerciseID, &jwlr.ESLoggedExerciseInstanceID,
-			&jwlr.ESWeight, &jwlr.ESReps, &jwlr.ESSetNumber, &jwlr.ESFinishedAt, &jwlr.ESStatus,
+			&jwlr.ESWeight, &jwlr.ESReps, &jwlr.ESDurationSeconds, &jwlr.ESDistanceMeters, &jwlr.ESSetNumber, &jwlr.ESFinishedAt, &jwlr.ESStatus,
 			&jwlr.ESCreatedAt, &jwlr.ESUpdatedAt, &jwlr.ESDeletedAt,
+			&jwlr.ESSetType, &jwlr.ESTargetRepsMax, &jwlr.ESTargetRPE, &jwlr.ESRestSeconds,
 		)
 		if err != nil {
 			c.Logger().Errorf("IndexWorkoutLog: Failed to scan row: %v", err)
@@ -359,11 +368,12 @@ func (h *WorkoutLogHandler) IndexWorkoutLog(c echo.Context) error {
 				// Populate Exercise field
 				if jwlr.ExID.Valid {
 					newLei.Exercise = dto.ExerciseResponse{
-						ID:        jwlr.ExID.UUID,
-						Name:      jwlr.ExName.String,
-						CreatedAt: jwlr.ExCreatedAt.Time,
-						UpdatedAt: jwlr.ExUpdatedAt.Time,
-						DeletedAt: provider.NullTimeToTimePtr(jwlr.ExDeletedAt),
+						ID:           jwlr.ExID.UUID,
+						Name:         jwlr.ExName.String,
+						TrackingType: jwlr.ExTracking.String,
+						CreatedAt:    jwlr.ExCreatedAt.Time,
+						UpdatedAt:    jwlr.ExUpdatedAt.Time,
+						DeletedAt:    provider.NullTimeToTimePtr(jwlr.ExDeletedAt),
 					}
 				}
 				workoutLogDTO.LoggedExerciseInstances = append(workoutLogDTO.LoggedExerciseInstances, newLei)
@@ -385,9 +395,15 @@ func (h *WorkoutLogHandler) IndexWorkoutLog(c echo.Context) error {
 				// Handle nullable fields from DB scan results, converting to *int
 				esDTO.SetNumber = provider.NullInt64ToIntPtr(jwlr.ESSetNumber)
 				esDTO.Reps = provider.NullInt64ToIntPtr(jwlr.ESReps)
+				esDTO.DurationSeconds = provider.NullInt64ToIntPtr(jwlr.ESDurationSeconds)
+				esDTO.DistanceMeters = provider.NullFloat64ToFloat64Ptr(jwlr.ESDistanceMeters)
 				esDTO.Weight = provider.NullFloat64ToFloat64(jwlr.ESWeight) // Non-nullable float64
 				esDTO.FinishedAt = provider.NullTimeToTimePtr(jwlr.ESFinishedAt)
 				esDTO.DeletedAt = provider.NullTimeToTimePtr(jwlr.ESDeletedAt)
+				esDTO.SetType = provider.NullStringToStringPtr(jwlr.ESSetType)
+				esDTO.TargetRepsMax = provider.NullInt64ToIntPtr(jwlr.ESTargetRepsMax)
+				esDTO.TargetRPE = provider.NullFloat64ToFloat64Ptr(jwlr.ESTargetRPE)
+				esDTO.RestSeconds = provider.NullInt64ToIntPtr(jwlr.ESRestSeconds)
 
 				leiDTO.ExerciseSets = append(leiDTO.ExerciseSets, esDTO)
 			}
@@ -430,6 +446,15 @@ func (h *WorkoutLogHandler) IndexWorkoutLog(c echo.Context) error {
 
 	paginationData := provider.GeneratePaginationData(totalCount, page, limit, baseURL, queryParams)
 
+	personalized := make([]*dto.WorkoutLogResponse, 0, len(dtoWorkoutLogs))
+	for i := range dtoWorkoutLogs {
+		personalized = append(personalized, &dtoWorkoutLogs[i])
+	}
+	if groupErr := h.attachExerciseGroups(c, personalized...); groupErr != nil {
+		return groupErr
+	}
+	h.personalizeWorkoutLogs(c, personalized...)
+
 	return c.JSON(http.StatusOK, dto.ListWorkoutLogResponse{
 		Data:               dtoWorkoutLogs,
 		PaginationResponse: paginationData,
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 	"time"
@@ -68,11 +69,11 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 				w.id, w.user_id, w.name, w.created_at, w.updated_at, w.deleted_at,
 				we.id AS we_id, we.workout_id AS we_workout_id, we.exercise_id AS we_exercise_id,
 				we.exercise_instance_id AS we_exercise_instance_id,
-				we.workout_order, we.sets, we.weight, we.reps, we.created_at AS we_created_at, we.updated_at AS we_updated_at, we.deleted_at AS we_deleted_at,
-				e.id AS e_id, e.name AS e_name, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at
+				we.workout_order, we.sets, we.weight, we.reps, we.duration_seconds, we.distance_meters, we.created_at AS we_created_at, we.updated_at AS we_updated_at, we.deleted_at AS we_deleted_at,
+				e.id AS e_id, e.name AS e_name, e.tracking_type AS e_tracking_type, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at
 			FROM workouts AS w
 			LEFT JOIN workout_exercises AS we ON w.id = we.workout_id AND we.deleted_at IS NULL
-			LEFT JOIN exercises AS e ON we.exercise_id = e.id AND e.deleted_at IS NULL
+			LEFT JOIN exercises AS e ON we.exercise_id = e.id
 			WHERE w.id = $1 AND w.user_id = $2 AND w.deleted_at IS NULL
 			ORDER BY we.workout_order ASC;
 		`
@@ -96,18 +97,21 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 				weExerciseInstanceID                  sql.Null[uuid.UUID]
 				weWorkoutOrder, weSets, weReps        sql.NullInt64
 				weWeight                              sql.NullFloat64
+				weDurationSeconds                     sql.NullInt64
+				weDistanceMeters                      sql.NullFloat64
 				weCreatedAt, weUpdatedAt, weDeletedAt sql.NullTime
 
 				eID                                sql.Null[uuid.UUID]
 				eName                              sql.NullString
+				eTrackingType                      sql.NullString
 				eCreatedAt, eUpdatedAt, eDeletedAt sql.NullTime
 			)
 
 			scanErr := rows.Scan(
 				&wID, &wUserID, &wName, &wCreatedAt, &wUpdatedAt, &wDeletedAt,
 				&weID, &weWorkoutID, &weExerciseID, &weExerciseInstanceID, &weWorkoutOrder,
-				&weSets, &weWeight, &weReps, &weCreatedAt, &weUpdatedAt, &weDeletedAt,
-				&eID, &eName, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
+				&weSets, &weWeight, &weReps, &weDurationSeconds, &weDistanceMeters, &weCreatedAt, &weUpdatedAt, &weDeletedAt,
+				&eID, &eName, &eTrackingType, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
 			)
 			if scanErr != nil {
 				c.Logger().Errorf("StoreWorkoutLog: Failed to scan workout template row: %v", scanErr)
@@ -128,16 +132,18 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 			// Process workout exercises from the template
 			if weID.Valid {
 				we := model.WorkoutExercise{
-					ID:           weID.V,
-					WorkoutID:    weWorkoutID.V,
-					ExerciseID:   weExerciseID.V,
-					WorkoutOrder: provider.NullInt64ToIntPtr(weWorkoutOrder),
-					Sets:         provider.NullInt64ToIntPtr(weSets),
-					Weight:       provider.NullFloat64ToFloat64Ptr(weWeight),
-					Reps:         provider.NullInt64ToIntPtr(weReps),
-					CreatedAt:    weCreatedAt.Time,
-					UpdatedAt:    weUpdatedAt.Time,
-					DeletedAt:    provider.NullTimeToTimePtr(weDeletedAt),
+					ID:              weID.V,
+					WorkoutID:       weWorkoutID.V,
+					ExerciseID:      weExerciseID.V,
+					WorkoutOrder:    provider.NullInt64ToIntPtr(weWorkoutOrder),
+					Sets:            provider.NullInt64ToIntPtr(weSets),
+					Weight:          provider.NullFloat64ToFloat64Ptr(weWeight),
+					Reps:            provider.NullInt64ToIntPtr(weReps),
+					DurationSeconds: provider.NullInt64ToIntPtr(weDurationSeconds),
+					DistanceMeters:  provider.NullFloat64ToFloat64Ptr(weDistanceMeters),
+					CreatedAt:       weCreatedAt.Time,
+					UpdatedAt:       weUpdatedAt.Time,
+					DeletedAt:       provider.NullTimeToTimePtr(weDeletedAt),
 				}
 				if weExerciseInstanceID.Valid {
 					we.ExerciseInstanceID = &weExerciseInstanceID.V
@@ -148,11 +154,12 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 			// Populate exercises map
 			if eID.Valid {
 				exercisesMap[eID.V] = model.Exercise{
-					ID:        eID.V,
-					Name:      eName.String,
-					CreatedAt: eCreatedAt.Time,
-					UpdatedAt: eUpdatedAt.Time,
-					DeletedAt: provider.NullTimeToTimePtr(eDeletedAt),
+					ID:           eID.V,
+					Name:         eName.String,
+					TrackingType: eTrackingType.String,
+					CreatedAt:    eCreatedAt.Time,
+					UpdatedAt:    eUpdatedAt.Time,
+					DeletedAt:    provider.NullTimeToTimePtr(eDeletedAt),
 				}
 			}
 		}
@@ -212,6 +219,13 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 
 	// 3. Create logged_exercise_instances and ExerciseSets ONLY if WorkoutID was provided and exercises were found
 	if len(workoutExercises) > 0 {
+		// Copy the template's supersets and circuits into the log, keyed by template workout exercise
+		loggedGroupOf, groupErr := h.copyExerciseGroups(ctx, c, tx, *req.WorkoutID, newWorkoutLogID, now)
+		if groupErr != nil {
+			err = groupErr
+			return err
+		}
+
 		loggedExerciseInstances := make([]model.LoggedExerciseInstance, 0, len(workoutExercises))
 		for _, we := range workoutExercises {
 			newLoggedExerciseInstanceID := uuid.New()
@@ -223,15 +237,18 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 				UpdatedAt:    now,
 				DeletedAt:    nil,
 			}
+			if groupID, ok := loggedGroupOf[we.ID]; ok {
+				loggedInstance.GroupID = &groupID
+			}
 			loggedExerciseInstances = append(loggedExerciseInstances, loggedInstance)
 		}
 
 		insertLEIBuilder := h.sq.Insert("logged_exercise_instances").Columns(
-			"id", "workout_log_id", "exercise_id", "created_at", "updated_at", "deleted_at",
+			"id", "workout_log_id", "exercise_id", "created_at", "updated_at", "deleted_at", "group_id",
 		)
 		for _, lei := range loggedExerciseInstances {
 			insertLEIBuilder = insertLEIBuilder.Values(
-				lei.ID, lei.WorkoutLogID, lei.ExerciseID, lei.CreatedAt, lei.UpdatedAt, lei.DeletedAt,
+				lei.ID, lei.WorkoutLogID, lei.ExerciseID, lei.CreatedAt, lei.UpdatedAt, lei.DeletedAt, lei.GroupID,
 			)
 		}
 		insertLEIQuery, leiArgs, buildErr := insertLEIBuilder.ToSql()
@@ -254,44 +271,32 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 			workoutExerciseMapByExerciseID[we.ExerciseID] = we
 		}
 
+		// Per-set prescriptions take precedence over the flat sets/reps/weight defaults
+		prescriptions, oneRepMaxes, prescriptionErr := h.fetchTemplateSetPrescriptions(ctx, tx, userID, workoutExercises)
+		if prescriptionErr != nil {
+			c.Logger().Errorf("StoreWorkoutLog: Failed to fetch set prescriptions: %v", prescriptionErr)
+			err = echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve workout template")
+			return err
+		}
+
 		exerciseSetsToInsert := make([]model.ExerciseSet, 0)
 		for _, lei := range loggedExerciseInstances {
 			if we, found := workoutExerciseMapByExerciseID[lei.ExerciseID]; found {
-				numSets := 0
-				if we.Sets != nil {
-					numSets = *we.Sets
-				}
-
-				for i := 1; i <= numSets; i++ {
-					newExerciseSetID := uuid.New()
-					exerciseSet := model.ExerciseSet{
-						ID:                       newExerciseSetID,
-						WorkoutLogID:             lei.WorkoutLogID,
-						ExerciseID:               lei.ExerciseID,
-						LoggedExerciseInstanceID: lei.ID,
-						SetNumber:                i,
-						Weight:                   we.Weight,
-						Reps:                     we.Reps,
-						FinishedAt:               nil,
-						Status:                   0,
-						CreatedAt:                now,
-						UpdatedAt:                now,
-						DeletedAt:                nil,
-					}
-					exerciseSetsToInsert = append(exerciseSetsToInsert, exerciseSet)
-				}
+				exerciseSetsToInsert = append(exerciseSetsToInsert, expandExerciseSets(lei, we, prescriptions[we.ID], oneRepMaxes[lei.ExerciseID], now)...)
 			}
 		}
 
 		if len(exerciseSetsToInsert) > 0 {
 			insertESBuilder := h.sq.Insert("exercise_sets").Columns(
 				"id", "workout_log_id", "exercise_id", "logged_exercise_instance_id", "set_number",
-				"weight", "reps", "finished_at", "status", "created_at", "updated_at", "deleted_at",
+				"weight", "reps", "duration_seconds", "distance_meters", "finished_at", "status", "created_at", "updated_at", "deleted_at",
+				"set_type", "target_reps_max", "target_rpe", "rest_seconds",
 			)
 			for _, es := range exerciseSetsToInsert {
 				insertESBuilder = insertESBuilder.Values(
 					es.ID, es.WorkoutLogID, es.ExerciseID, es.LoggedExerciseInstanceID, es.SetNumber,
-					es.Weight, es.Reps, es.FinishedAt, es.Status, es.CreatedAt, es.UpdatedAt, es.DeletedAt,
+					es.Weight, es.Reps, es.DurationSeconds, es.DistanceMeters, es.FinishedAt, es.Status, es.CreatedAt, es.UpdatedAt, es.DeletedAt,
+					es.SetType, es.TargetRepsMax, es.TargetRPE, es.RestSeconds,
 				)
 			}
 			insertESQuery, esArgs, buildErr := insertESBuilder.ToSql()
@@ -325,14 +330,15 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 			w.id AS w_id, w.user_id AS w_user_id, w.name AS w_name, w.created_at AS w_created_at, w.updated_at AS w_updated_at, w.deleted_at AS w_deleted_at,
 			lei.id AS lei_id, lei.workout_log_id AS lei_workout_log_id, lei.exercise_id AS lei_exercise_id,
 			lei.created_at AS lei_created_at, lei.updated_at AS lei_updated_at, lei.deleted_at AS lei_deleted_at,
-			e.id AS e_id, e.name AS e_name, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at,
+			e.id AS e_id, e.name AS e_name, e.tracking_type AS e_tracking_type, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at,
 			es.id AS es_id, es.workout_log_id AS es_workout_log_id, es.exercise_id AS es_exercise_id,
-			es.logged_exercise_instance_id AS es_lei_id, es.set_number, es.weight, es.reps,
-			es.finished_at AS es_finished_at, es.status AS es_status, es.created_at AS es_created_at, es.updated_at AS es_updated_at, es.deleted_at AS es_deleted_at
+			es.logged_exercise_instance_id AS es_lei_id, es.set_number, es.weight, es.reps, es.duration_seconds, es.distance_meters,
+			es.finished_at AS es_finished_at, es.status AS es_status, es.created_at AS es_created_at, es.updated_at AS es_updated_at, es.deleted_at AS es_deleted_at,
+			es.set_type AS es_set_type, es.target_reps_max AS es_target_reps_max, es.target_rpe AS es_target_rpe, es.rest_seconds AS es_rest_seconds
 		FROM workout_logs AS wl
 		LEFT JOIN workouts AS w ON wl.workout_id = w.id
 		LEFT JOIN logged_exercise_instances AS lei ON wl.id = lei.workout_log_id AND lei.deleted_at IS NULL
-		LEFT JOIN exercises AS e ON lei.exercise_id = e.id AND e.deleted_at IS NULL
+		LEFT JOIN exercises AS e ON lei.exercise_id = e.id
 		LEFT JOIN exercise_sets AS es ON lei.id = es.logged_exercise_instance_id AND es.deleted_at IS NULL
 		WHERE wl.id = $1
 		ORDER BY lei.created_at ASC, es.set_number ASC;
@@ -367,15 +373,22 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 
 			eID                                sql.Null[uuid.UUID]
 			eName                              sql.NullString
+			eTrackingType                      sql.NullString
 			eCreatedAt, eUpdatedAt, eDeletedAt sql.NullTime
 
 			esID, esWorkoutLogID, esExerciseID, esLeiID sql.Null[uuid.UUID]
 			esSetNumber                                 sql.NullInt64
 			esWeight                                    sql.NullFloat64
 			esReps                                      sql.NullInt64
+			esDurationSeconds                           sql.NullInt64
+			esDistanceMeters                            sql.NullFloat64
 			esFinishedAt                                sql.NullTime
 			esStatus                                    sql.NullInt64
 			esCreatedAt, esUpdatedAt, esDeletedAt       sql.NullTime
+			esSetType                                   sql.NullString
+			esTargetRepsMax                             sql.NullInt64
+			esTargetRPE                                 sql.NullFloat64
+			esRestSeconds                               sql.NullInt64
 		)
 
 		scanErr := finalRows.Scan(
@@ -384,9 +397,10 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 			&wlCreatedAt, &wlUpdatedAt, &wlDeletedAt,
 			&wID, &wUserID, &wName, &wCreatedAt, &wUpdatedAt, &wDeletedAt,
 			&leiID, &leiWorkoutLogID, &leiExerciseID, &leiCreatedAt, &leiUpdatedAt, &leiDeletedAt,
-			&eID, &eName, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
-			&esID, &esWorkoutLogID, &esExerciseID, &esLeiID, &esSetNumber, &esWeight, &esReps,
+			&eID, &eName, &eTrackingType, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
+			&esID, &esWorkoutLogID, &esExerciseID, &esLeiID, &esSetNumber, &esWeight, &esReps, &esDurationSeconds, &esDistanceMeters,
 			&esFinishedAt, &esStatus, &esCreatedAt, &esUpdatedAt, &esDeletedAt,
+			&esSetType, &esTargetRepsMax, &esTargetRPE, &esRestSeconds,
 		)
 		if scanErr != nil {
 			c.Logger().Errorf("StoreWorkoutLog: Failed to scan final workout log row: %v", scanErr)
@@ -464,11 +478,12 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 				}
 				if eID.Valid {
 					newLei.Exercise = dto.ExerciseResponse{
-						ID:        eID.V,
-						Name:      eName.String,
-						CreatedAt: eCreatedAt.Time,
-						UpdatedAt: eUpdatedAt.Time,
-						DeletedAt: provider.NullTimeToTimePtr(eDeletedAt),
+						ID:           eID.V,
+						Name:         eName.String,
+						TrackingType: eTrackingType.String,
+						CreatedAt:    eCreatedAt.Time,
+						UpdatedAt:    eUpdatedAt.Time,
+						DeletedAt:    provider.NullTimeToTimePtr(eDeletedAt),
 					}
 				}
 				finalWorkoutLog.LoggedExerciseInstances = append(finalWorkoutLog.LoggedExerciseInstances, newLei)
@@ -485,11 +500,17 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 					SetNumber:                provider.NullInt64ToIntPtr(esSetNumber),
 					Weight:                   provider.NullFloat64ToFloat64(esWeight),
 					Reps:                     provider.NullInt64ToIntPtr(esReps),
+					DurationSeconds:          provider.NullInt64ToIntPtr(esDurationSeconds),
+					DistanceMeters:           provider.NullFloat64ToFloat64Ptr(esDistanceMeters),
 					FinishedAt:               provider.NullTimeToTimePtr(esFinishedAt),
 					Status:                   provider.NullInt64ToInt(esStatus),
 					CreatedAt:                esCreatedAt.Time,
 					UpdatedAt:                esUpdatedAt.Time,
 					DeletedAt:                provider.NullTimeToTimePtr(esDeletedAt),
+					SetType:                  provider.NullStringToStringPtr(esSetType),
+					TargetRepsMax:            provider.NullInt64ToIntPtr(esTargetRepsMax),
+					TargetRPE:                provider.NullFloat64ToFloat64Ptr(esTargetRPE),
+					RestSeconds:              provider.NullInt64ToIntPtr(esRestSeconds),
 				}
 				leiPointer.ExerciseSets = append(leiPointer.ExerciseSets, esDTO)
 			}
@@ -501,8 +522,137 @@ func (h *WorkoutLogHandler) StoreWorkoutLog(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to process created workout log details.")
 	}
 
+	if groupErr := h.attachExerciseGroups(c, &finalWorkoutLog); groupErr != nil {
+		return groupErr
+	}
+	h.personalizeWorkoutLogs(c, &finalWorkoutLog)
+
 	return c.JSON(http.StatusCreated, dto.CreateWorkoutLogResponse{
 		Message:    "Workout log created successfully!",
 		WorkoutLog: finalWorkoutLog,
 	})
 }
+
+// copyExerciseGroups copies the exercise groups of a workout template into a new workout log and
+// returns the copied group IDs keyed by the template workout exercise IDs in each group.
+func (h *WorkoutLogHandler) copyExerciseGroups(ctx context.Context, c echo.Context, tx *sql.Tx, workoutID, workoutLogID uuid.UUID, now time.Time) (map[uuid.UUID]uuid.UUID, error) {
+	templateGroups, err := provider.FetchWorkoutExerciseGroups(ctx, tx, h.sq, []uuid.UUID{workoutID})
+	if err != nil {
+		c.Logger().Errorf("StoreWorkoutLog: Failed to fetch template exercise groups: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve workout template")
+	}
+	loggedGroupOf := make(map[uuid.UUID]uuid.UUID)
+	if len(templateGroups[workoutID]) == 0 {
+		return loggedGroupOf, nil
+	}
+
+	builder := h.sq.Insert("logged_exercise_groups").Columns(
+		"id", "workout_log_id", "group_type", "rounds",
+		"rest_between_exercises_seconds", "rest_between_rounds_seconds", "created_at", "updated_at",
+	)
+	for _, g := range templateGroups[workoutID] {
+		loggedGroupID := uuid.New()
+		builder = builder.Values(
+			loggedGroupID, workoutLogID, g.GroupType, g.Rounds,
+			g.RestBetweenExercisesSeconds, g.RestBetweenRoundsSeconds, now, now,
+		)
+		for _, memberID := range g.MemberIDs {
+			loggedGroupOf[memberID] = loggedGroupID
+		}
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		c.Logger().Errorf("StoreWorkoutLog: Failed to build insert exercise groups query: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to prepare exercise groups")
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		c.Logger().Errorf("StoreWorkoutLog: Failed to insert exercise groups: %v", err)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to create exercise groups")
+	}
+	return loggedGroupOf, nil
+}
+
+// fetchTemplateSetPrescriptions loads the set prescriptions of the template exercises and, for the
+// exercises prescribed as a percentage of one-rep max, the user's estimated one-rep maxes.
+func (h *WorkoutLogHandler) fetchTemplateSetPrescriptions(ctx context.Context, tx *sql.Tx, userID uuid.UUID, workoutExercises []model.WorkoutExercise) (map[uuid.UUID][]model.SetPrescription, map[uuid.UUID]float64, error) {
+	workoutExerciseIDs := make([]uuid.UUID, 0, len(workoutExercises))
+	for _, we := range workoutExercises {
+		workoutExerciseIDs = append(workoutExerciseIDs, we.ID)
+	}
+	prescriptions, err := provider.FetchSetPrescriptions(ctx, tx, h.sq, workoutExerciseIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var percentExerciseIDs []uuid.UUID
+	for _, we := range workoutExercises {
+		for _, p := range prescriptions[we.ID] {
+			if p.TargetPercent1RM != nil {
+				percentExerciseIDs = append(percentExerciseIDs, we.ExerciseID)
+				break
+			}
+		}
+	}
+	oneRepMaxes, err := provider.FetchEstimatedOneRepMaxes(ctx, tx, h.sq, userID, percentExerciseIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return prescriptions, oneRepMaxes, nil
+}
+
+// expandExerciseSets returns the initial sets of lei: one per set prescription of its template
+// exercise we when it has any, otherwise we.Sets copies of the flat reps and weight targets.
+// oneRepMax is the user's estimate for the exercise, used by percentage prescriptions.
+func expandExerciseSets(lei model.LoggedExerciseInstance, we model.WorkoutExercise, prescriptions []model.SetPrescription, oneRepMax float64, now time.Time) []model.ExerciseSet {
+	sets := make([]model.ExerciseSet, 0)
+	if len(prescriptions) > 0 {
+		for _, p := range prescriptions {
+			sets = append(sets, model.ExerciseSet{
+				ID:                       uuid.New(),
+				WorkoutLogID:             lei.WorkoutLogID,
+				ExerciseID:               lei.ExerciseID,
+				LoggedExerciseInstanceID: lei.ID,
+				SetNumber:                p.SetNumber,
+				Weight:                   p.PrefillWeight(oneRepMax),
+				Reps:                     p.TargetReps,
+				DurationSeconds:          we.DurationSeconds,
+				DistanceMeters:           we.DistanceMeters,
+				Status:                   model.ExerciseSetStatusPending,
+				CreatedAt:                now,
+				UpdatedAt:                now,
+				SetType:                  &p.SetType,
+				TargetRepsMax:            p.TargetRepsMax,
+				TargetRPE:                p.TargetRPE,
+				RestSeconds:              p.RestSeconds,
+			})
+		}
+		return sets
+	}
+
+	numSets := 0
+	if we.Sets != nil {
+		numSets = *we.Sets
+	}
+
+	for i := 1; i <= numSets; i++ {
+		newExerciseSetID := uuid.New()
+		exerciseSet := model.ExerciseSet{
+			ID:                       newExerciseSetID,
+			WorkoutLogID:             lei.WorkoutLogID,
+			ExerciseID:               lei.ExerciseID,
+			LoggedExerciseInstanceID: lei.ID,
+			SetNumber:                i,
+			Weight:                   we.Weight,
+			Reps:                     we.Reps,
+			DurationSeconds:          we.DurationSeconds,
+			DistanceMeters:           we.DistanceMeters,
+			FinishedAt:               nil,
+			Status:                   0,
+			CreatedAt:                now,
+			UpdatedAt:                now,
+			DeletedAt:                nil,
+		}
+		sets = append(sets, exerciseSet)
+	}
+	return sets
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"rtglabs-go/model"
+
+	"github.com/google/uuid"
+)
+
+func TestExpandExerciseSets(t *testing.T) {
+	now := time.Date(2025, 7, 30, 18, 0, 0, 0, time.UTC)
+	lei := model.LoggedExerciseInstance{ID: uuid.New(), WorkoutLogID: uuid.New(), ExerciseID: uuid.New()}
+	sets, reps, weight := 3, 8, 60.0
+	we := model.WorkoutExercise{ID: uuid.New(), ExerciseID: lei.ExerciseID, Sets: &sets, Reps: &reps, Weight: &weight}
+
+	t.Run("flat targets", func(t *testing.T) {
+		got := expandExerciseSets(lei, we, nil, 0, now)
+		if len(got) != 3 {
+			t.Fatalf("expandExerciseSets() returned %d sets, want 3", len(got))
+		}
+		for i, es := range got {
+			if es.SetNumber != i+1 || *es.Reps != 8 || *es.Weight != 60 {
+				t.Errorf("set %d = number %d, %v reps at %v, want number %d, 8 reps at 60", i, es.SetNumber, *es.Reps, *es.Weight, i+1)
+			}
+			if es.SetType != nil || es.TargetRPE != nil {
+				t.Errorf("set %d carries prescription fields without a prescription", i)
+			}
+			if es.LoggedExerciseInstanceID != lei.ID || es.WorkoutLogID != lei.WorkoutLogID {
+				t.Errorf("set %d isn't attached to the logged exercise instance", i)
+			}
+		}
+	})
+
+	t.Run("no sets", func(t *testing.T) {
+		if got := expandExerciseSets(lei, model.WorkoutExercise{ExerciseID: lei.ExerciseID}, nil, 0, now); len(got) != 0 {
+			t.Errorf("expandExerciseSets() returned %d sets, want none", len(got))
+		}
+	})
+
+	t.Run("prescriptions replace flat targets", func(t *testing.T) {
+		warmupReps, workingReps, workingRepsMax := 10, 5, 7
+		warmupPercent, workingWeight, rpe := 50.0, 100.0, 8.5
+		rest := 180
+		prescriptions := []model.SetPrescription{
+			{SetNumber: 1, SetType: model.SetTypeWarmup, TargetReps: &warmupReps, TargetPercent1RM: &warmupPercent},
+			{SetNumber: 2, SetType: model.SetTypeWorking, TargetReps: &workingReps, TargetRepsMax: &workingRepsMax, TargetWeight: &workingWeight, TargetRPE: &rpe, RestSeconds: &rest},
+		}
+
+		got := expandExerciseSets(lei, we, prescriptions, 121, now)
+		if len(got) != 2 {
+			t.Fatalf("expandExerciseSets() returned %d sets, want 2", len(got))
+		}
+
+		warmup, working := got[0], got[1]
+		if *warmup.SetType != model.SetTypeWarmup || *warmup.Reps != 10 || *warmup.Weight != 60.5 {
+			t.Errorf("warm-up = %s, %d reps at %v, want warmup, 10 reps at 60.5", *warmup.SetType, *warmup.Reps, *warmup.Weight)
+		}
+		if *working.SetType != model.SetTypeWorking || *working.Weight != 100 || *working.TargetRepsMax != 7 || *working.TargetRPE != 8.5 || *working.RestSeconds != 180 {
+			t.Errorf("working set = %+v, want the prescription's type, weight, rep range, RPE and rest", working)
+		}
+		if warmup.SetType == working.SetType {
+			t.Error("sets share the set type of a single prescription")
+		}
+		if working.Status != model.ExerciseSetStatusPending {
+			t.Errorf("working set status = %d, want pending", working.Status)
+		}
+	})
+
+	t.Run("percentage without a one-rep max", func(t *testing.T) {
+		percent := 75.0
+		got := expandExerciseSets(lei, we, []model.SetPrescription{{SetNumber: 1, SetType: model.SetTypeWorking, TargetPercent1RM: &percent}}, 0, now)
+		if len(got) != 1 || got[0].Weight != nil {
+			t.Errorf("expandExerciseSets() = %+v, want one set without a weight", got)
+		}
+	})
+}
@@ -3,12 +3,14 @@ package handler
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 	"rtglabs-go/dto"
 	"rtglabs-go/model" // Assuming this contains your WorkoutLogStatusCompleted
 	"rtglabs-go/provider"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -50,6 +52,78 @@ func (h *WorkoutLogHandler) UpdateWorkoutLog(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
+
+	// Custom exercises of other users can't be logged.
+	var exerciseIDs []uuid.UUID
+	for _, leiReq := range req.LoggedExerciseInstances {
+		if leiReq.ExerciseID != nil {
+			exerciseIDs = append(exerciseIDs, *leiReq.ExerciseID)
+		}
+		for _, setReq := range leiReq.ExerciseSets {
+			if setReq.ExerciseID != uuid.Nil {
+				exerciseIDs = append(exerciseIDs, setReq.ExerciseID)
+			}
+		}
+	}
+	invisible, err := provider.InvisibleExerciseIDs(ctx, h.DB, h.sq, userID, exerciseIDs)
+	if err != nil {
+		c.Logger().Errorf("UpdateWorkoutLog: Failed to check exercise visibility: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workout log")
+	}
+	if len(invisible) > 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Exercise '%s' does not exist or is not visible to you", invisible[0]))
+	}
+
+	// Deleted exercises stay in the logs that already have them but can't be added to others.
+	deleted, err := provider.DeletedExerciseIDs(ctx, h.DB, h.sq, exerciseIDs)
+	if err != nil {
+		c.Logger().Errorf("UpdateWorkoutLog: Failed to check deleted exercises: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workout log")
+	}
+	if len(deleted) > 0 {
+		logged, err := h.loggedExerciseIDs(ctx, userID, workoutLogID)
+		if err != nil {
+			c.Logger().Errorf("UpdateWorkoutLog: Failed to fetch logged exercises: %v", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workout log")
+		}
+		for _, id := range deleted {
+			if _, ok := logged[id]; !ok {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Exercise '%s' has been deleted", id))
+			}
+		}
+	}
+
+	// Set values must match how their exercise is tracked, e.g. a plank set records a duration
+	// rather than weight and reps.
+	trackingTypes, err := provider.FetchExerciseTrackingTypes(ctx, h.DB, h.sq, exerciseIDs)
+	if err != nil {
+		c.Logger().Errorf("UpdateWorkoutLog: Failed to fetch exercise tracking types: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workout log")
+	}
+	for _, leiReq := range req.LoggedExerciseInstances {
+		for i, setReq := range leiReq.ExerciseSets {
+			exerciseID := setReq.ExerciseID
+			if exerciseID == uuid.Nil && leiReq.ExerciseID != nil {
+				exerciseID = *leiReq.ExerciseID
+			}
+			trackingType, found := trackingTypes[exerciseID]
+			if !found {
+				continue
+			}
+			weight := setReq.Weight
+			metrics := model.SetMetrics{
+				Weight:          &weight,
+				Reps:            setReq.Reps,
+				DurationSeconds: setReq.DurationSeconds,
+				DistanceMeters:  setReq.DistanceMeters,
+			}
+			completed := setReq.Status != nil && *setReq.Status == model.ExerciseSetStatusCompleted
+			if validateErr := model.ValidateSetMetrics(trackingType, metrics, completed); validateErr != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid exercise set #%d: %v", i+1, validateErr))
+			}
+		}
+	}
+
 	tx, err := h.DB.BeginTx(ctx, nil)
 	if err != nil {
 		c.Logger().Errorf("UpdateWorkoutLog: Failed to begin transaction: %v", err)
@@ -219,6 +293,11 @@ func (h *WorkoutLogHandler) UpdateWorkoutLog(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Workout log updated, but failed to retrieve full details.")
 	}
 
+	if groupErr := h.attachExerciseGroups(c, &updatedWorkoutLog); groupErr != nil {
+		return groupErr
+	}
+	h.personalizeWorkoutLogs(c, &updatedWorkoutLog)
+
 	return c.JSON(http.StatusOK, dto.UpdateWorkoutLogResponse{
 		Message:    "Workout log updated successfully!",
 		WorkoutLog: updatedWorkoutLog,
@@ -274,11 +353,13 @@ func (h *WorkoutLogHandler) processExerciseSets(tx *sql.Tx, ctx context.Context,
 
 			insertESBuilder := h.sq.Insert("exercise_sets").
 				Columns("id", "workout_log_id", "exercise_id", "logged_exercise_instance_id",
-					"set_number", "weight", "reps", "finished_at", "status", "created_at", "updated_at").
+					"set_number", "weight", "reps", "duration_seconds", "distance_meters", "finished_at", "status", "created_at", "updated_at").
 				Values(newSetID, workoutLogID, finalExerciseID, loggedExerciseInstanceID,
 					provider.IntPtrToInt(setReq.SetNumber, i+1),
 					setReq.Weight,
 					provider.IntPtrToInt(setReq.Reps, 0),
+					setReq.DurationSeconds,
+					setReq.DistanceMeters,
 					setReq.FinishedAt,
 					provider.IntPtrToInt(setReq.Status, model.ExerciseSetStatusPending),
 					now, now)
@@ -310,6 +391,12 @@ func (h *WorkoutLogHandler) processExerciseSets(tx *sql.Tx, ctx context.Context,
 			if setReq.Reps != nil {
 				updateESBuilder = updateESBuilder.Set("reps", *setReq.Reps)
 			}
+			if setReq.DurationSeconds != nil {
+				updateESBuilder = updateESBuilder.Set("duration_seconds", *setReq.DurationSeconds)
+			}
+			if setReq.DistanceMeters != nil {
+				updateESBuilder = updateESBuilder.Set("distance_meters", *setReq.DistanceMeters)
+			}
 			if setReq.Status != nil {
 				updateESBuilder = updateESBuilder.Set("status", *setReq.Status)
 			}
@@ -440,14 +527,15 @@ func (h *WorkoutLogHandler) fetchWorkoutLogDetails(ctx context.Context, logger e
 			w.id AS w_id, w.user_id AS w_user_id, w.name AS w_name, w.created_at AS w_created_at, w.updated_at AS w_updated_at, w.deleted_at AS w_deleted_at,
 			lei.id AS lei_id, lei.workout_log_id AS lei_workout_log_id, lei.exercise_id AS lei_exercise_id,
 			lei.created_at AS lei_created_at, lei.updated_at AS lei_updated_at, lei.deleted_at AS lei_deleted_at,
-			e.id AS e_id, e.name AS e_name, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at,
+			e.id AS e_id, e.name AS e_name, e.tracking_type AS e_tracking_type, e.created_at AS e_created_at, e.updated_at AS e_updated_at, e.deleted_at AS e_deleted_at,
 			es.id AS es_id, es.workout_log_id AS es_workout_log_id, es.exercise_id AS es_exercise_id,
-			es.logged_exercise_instance_id AS es_lei_id, es.set_number, es.weight, es.reps,
-			es.finished_at AS es_finished_at, es.status AS es_status, es.created_at AS es_created_at, es.updated_at AS es_updated_at, es.deleted_at AS es_deleted_at
+			es.logged_exercise_instance_id AS es_lei_id, es.set_number, es.weight, es.reps, es.duration_seconds, es.distance_meters,
+			es.finished_at AS es_finished_at, es.status AS es_status, es.created_at AS es_created_at, es.updated_at AS es_updated_at, es.deleted_at AS es_deleted_at,
+			es.set_type AS es_set_type, es.target_reps_max AS es_target_reps_max, es.target_rpe AS es_target_rpe, es.rest_seconds AS es_rest_seconds
 		FROM workout_logs AS wl
 		LEFT JOIN workouts AS w ON wl.workout_id = w.id
 		LEFT JOIN logged_exercise_instances AS lei ON wl.id = lei.workout_log_id AND lei.deleted_at IS NULL
-		LEFT JOIN exercises AS e ON lei.exercise_id = e.id AND e.deleted_at IS NULL
+		LEFT JOIN exercises AS e ON lei.exercise_id = e.id
 		LEFT JOIN exercise_sets AS es ON lei.id = es.logged_exercise_instance_id AND es.deleted_at IS NULL
 		WHERE wl.id = $1 AND wl.user_id = $2 AND wl.deleted_at IS NULL
 		ORDER BY lei.created_at ASC, es.set_number ASC;
@@ -484,15 +572,22 @@ func (h *WorkoutLogHandler) fetchWorkoutLogDetails(ctx context.Context, logger e
 
 			eID                                sql.Null[uuid.UUID]
 			eName                              sql.NullString
+			eTrackingType                      sql.NullString
 			eCreatedAt, eUpdatedAt, eDeletedAt sql.NullTime
 
 			esID, esWorkoutLogID, esExerciseID, esLeiID sql.Null[uuid.UUID]
 			esSetNumber                                 sql.NullInt64
 			esWeight                                    sql.NullFloat64
 			esReps                                      sql.NullInt64
+			esDurationSeconds                           sql.NullInt64
+			esDistanceMeters                            sql.NullFloat64
 			esFinishedAt                                sql.NullTime
 			esStatus                                    sql.NullInt64
 			esCreatedAt, esUpdatedAt, esDeletedAt       sql.NullTime
+			esSetType                                   sql.NullString
+			esTargetRepsMax                             sql.NullInt64
+			esTargetRPE                                 sql.NullFloat64
+			esRestSeconds                               sql.NullInt64
 		)
 
 		scanErr := rows.Scan(
@@ -501,9 +596,10 @@ func (h *WorkoutLogHandler) fetchWorkoutLogDetails(ctx context.Context, logger e
 			&wlCreatedAt, &wlUpdatedAt, &wlDeletedAt,
 			&wID, &wUserID, &wName, &wCreatedAt, &wUpdatedAt, &wDeletedAt,
 			&leiID, &leiWorkoutLogID, &leiExerciseID, &leiCreatedAt, &leiUpdatedAt, &leiDeletedAt,
-			&eID, &eName, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
-			&esID, &esWorkoutLogID, &esExerciseID, &esLeiID, &esSetNumber, &esWeight, &esReps,
+			&eID, &eName, &eTrackingType, &eCreatedAt, &eUpdatedAt, &eDeletedAt,
+			&esID, &esWorkoutLogID, &esExerciseID, &esLeiID, &esSetNumber, &esWeight, &esReps, &esDurationSeconds, &esDistanceMeters,
 			&esFinishedAt, &esStatus, &esCreatedAt, &esUpdatedAt, &esDeletedAt,
+			&esSetType, &esTargetRepsMax, &esTargetRPE, &esRestSeconds,
 		)
 		if scanErr != nil {
 			logger.Errorf("fetchWorkoutLogDetails: Failed to scan workout log row: %v", scanErr)
@@ -567,11 +663,12 @@ func (h *WorkoutLogHandler) fetchWorkoutLogDetails(ctx context.Context, logger e
 				}
 				if eID.Valid {
 					newLei.Exercise = dto.ExerciseResponse{
-						ID:        eID.V,
-						Name:      eName.String,
-						CreatedAt: eCreatedAt.Time,
-						UpdatedAt: eUpdatedAt.Time,
-						DeletedAt: provider.NullTimeToTimePtr(eDeletedAt),
+						ID:           eID.V,
+						Name:         eName.String,
+						TrackingType: eTrackingType.String,
+						CreatedAt:    eCreatedAt.Time,
+						UpdatedAt:    eUpdatedAt.Time,
+						DeletedAt:    provider.NullTimeToTimePtr(eDeletedAt),
 					}
 				}
 				workoutLog.LoggedExerciseInstances = append(workoutLog.LoggedExerciseInstances, newLei)
@@ -588,11 +685,17 @@ func (h *WorkoutLogHandler) fetchWorkoutLogDetails(ctx context.Context, logger e
 					SetNumber:                provider.NullInt64ToIntPtr(esSetNumber),
 					Weight:                   provider.NullFloat64ToFloat64(esWeight),
 					Reps:                     provider.NullInt64ToIntPtr(esReps),
+					DurationSeconds:          provider.NullInt64ToIntPtr(esDurationSeconds),
+					DistanceMeters:           provider.NullFloat64ToFloat64Ptr(esDistanceMeters),
 					FinishedAt:               provider.NullTimeToTimePtr(esFinishedAt),
 					Status:                   provider.NullInt64ToInt(esStatus),
 					CreatedAt:                esCreatedAt.Time,
 					UpdatedAt:                esUpdatedAt.Time,
 					DeletedAt:                provider.NullTimeToTimePtr(esDeletedAt),
+					SetType:                  provider.NullStringToStringPtr(esSetType),
+					TargetRepsMax:            provider.NullInt64ToIntPtr(esTargetRepsMax),
+					TargetRPE:                provider.NullFloat64ToFloat64Ptr(esTargetRPE),
+					RestSeconds:              provider.NullInt64ToIntPtr(esRestSeconds),
 				}
 				lei.ExerciseSets = append(lei.ExerciseSets, esDTO)
 			}
@@ -610,3 +713,39 @@ func (h *WorkoutLogHandler) fetchWorkoutLogDetails(ctx context.Context, logger e
 
 	return workoutLog, nil
 }
+
+// loggedExerciseIDs returns the exercises of the user's workout log, from its logged exercise
+// instances and their sets.
+func (h *WorkoutLogHandler) loggedExerciseIDs(ctx context.Context, userID, workoutLogID uuid.UUID) (map[uuid.UUID]struct{}, error) {
+	query, args, err := h.sq.Select("lei.exercise_id", "es.exercise_id").
+		From("logged_exercise_instances AS lei").
+		Join("workout_logs AS wl ON wl.id = lei.workout_log_id").
+		LeftJoin("exercise_sets AS es ON es.logged_exercise_instance_id = lei.id AND es.deleted_at IS NULL").
+		Where(squirrel.Eq{"wl.id": workoutLogID, "wl.user_id": userID, "lei.deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build logged exercises query: %w", err)
+	}
+	rows, err := h.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query logged exercises: %w", err)
+	}
+	defer rows.Close()
+
+	logged := make(map[uuid.UUID]struct{})
+	for rows.Next() {
+		var instanceExerciseID uuid.UUID
+		var setExerciseID uuid.NullUUID
+		if err := rows.Scan(&instanceExerciseID, &setExerciseID); err != nil {
+			return nil, fmt.Errorf("failed to scan logged exercise: %w", err)
+		}
+		logged[instanceExerciseID] = struct{}{}
+		if setExerciseID.Valid {
+			logged[setExerciseID.UUID] = struct{}{}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("logged exercises rows error: %w", err)
+	}
+	return logged, nil
+}
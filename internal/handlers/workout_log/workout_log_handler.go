@@ -2,8 +2,15 @@ package handler
 
 import (
 	"database/sql" // For *sql.DB, sql.Null* types
+	"net/http"
 
 	"github.com/Masterminds/squirrel" // Import squirrel
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"rtglabs-go/dto"
+	ex_handlers "rtglabs-go/internal/handlers/exercise" // Shared exercise personalization
+	"rtglabs-go/internal/middleware"
+	"rtglabs-go/provider"
 )
 
 // WorkoutHandler holds the database client and squirrel statement builder.
@@ -21,3 +28,59 @@ func NewWorkoutLogHandler(db *sql.DB) *WorkoutLogHandler {
 	}
 }
 
+// attachExerciseGroups loads the exercise groups of the workout logs and sets the group_id of
+// their logged exercise instances.
+func (h *WorkoutLogHandler) attachExerciseGroups(c echo.Context, logs ...*dto.WorkoutLogResponse) error {
+	workoutLogIDs := make([]uuid.UUID, 0, len(logs))
+	for _, wl := range logs {
+		workoutLogIDs = append(workoutLogIDs, wl.ID)
+	}
+	groups, err := provider.FetchLoggedExerciseGroups(c.Request().Context(), h.DB, h.sq, workoutLogIDs)
+	if err != nil {
+		c.Logger().Errorf("attachExerciseGroups: Failed to fetch exercise groups: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch exercise groups")
+	}
+	for _, wl := range logs {
+		wl.ExerciseGroups = make([]dto.ExerciseGroupResponse, 0, len(groups[wl.ID]))
+		groupOf := make(map[uuid.UUID]uuid.UUID)
+		for _, g := range groups[wl.ID] {
+			wl.ExerciseGroups = append(wl.ExerciseGroups, dto.ExerciseGroupResponse{
+				ID:                          g.ID,
+				GroupType:                   g.GroupType,
+				Rounds:                      g.Rounds,
+				RestBetweenExercisesSeconds: g.RestBetweenExercisesSeconds,
+				RestBetweenRoundsSeconds:    g.RestBetweenRoundsSeconds,
+			})
+			for _, memberID := range g.MemberIDs {
+				groupOf[memberID] = g.ID
+			}
+		}
+		for i := range wl.LoggedExerciseInstances {
+			if groupID, ok := groupOf[wl.LoggedExerciseInstances[i].ID]; ok {
+				wl.LoggedExerciseInstances[i].GroupID = &groupID
+			}
+		}
+	}
+	return nil
+}
+
+// personalizeWorkoutLogs personalizes the exercises nested in the workout logs, see
+// ex_handlers.PersonalizeExercises.
+func (h *WorkoutLogHandler) personalizeWorkoutLogs(c echo.Context, logs ...*dto.WorkoutLogResponse) {
+	var exercises []*dto.ExerciseResponse
+	for _, wl := range logs {
+		for i := range wl.LoggedExerciseInstances {
+			exercises = append(exercises, &wl.LoggedExerciseInstances[i].Exercise)
+		}
+		for _, we := range wl.Workout.WorkoutExercises {
+			if we.Exercise != nil {
+				exercises = append(exercises, we.Exercise)
+			}
+		}
+	}
+	userID, _ := c.Get("user_id").(uuid.UUID)
+	locale := middleware.RequestLocale(c)
+	if err := ex_handlers.PersonalizeExercises(c.Request().Context(), h.DB, h.sq, userID, locale, exercises); err != nil {
+		c.Logger().Warnf("personalizeWorkoutLogs: %v", err)
+	}
+}
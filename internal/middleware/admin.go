@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RequireAdmin only lets users with users.is_admin set through. It must run after the
+// middleware that puts "user_id" into the context.
+func RequireAdmin(db *sql.DB) echo.MiddlewareFunc {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get("user_id").(uuid.UUID)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+			}
+
+			isAdmin, err := provider.IsAdmin(c.Request().Context(), db, sq, userID)
+			if err != nil {
+				c.Logger().Errorf("RequireAdmin: %v", err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+			}
+			if !isAdmin {
+				return echo.NewHTTPError(http.StatusForbidden, "Admin access required")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"database/sql"
+	"net/http"
+
+	"rtglabs-go/model"
+	"rtglabs-go/provider"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// localeResolverKey holds the function RequestLocale uses to resolve the locale on first use.
+const localeResolverKey = "locale_resolver"
+
+// Locale makes the language of the response available through RequestLocale: the locale set in
+// the user's profile, else the best match for the Accept-Language header, else
+// model.DefaultLocale. The profile is only read when a handler asks for the locale, so routes
+// that return no translated text cost no query. It must run after the middleware that puts
+// "user_id" into the context.
+func Locale(db *sql.DB) echo.MiddlewareFunc {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get("user_id").(uuid.UUID)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+			}
+
+			c.Set(localeResolverKey, func() string {
+				locale, err := provider.FetchProfileLocale(c.Request().Context(), db, sq, userID)
+				if err != nil {
+					// Not worth failing the request over; the header is a good guess.
+					c.Logger().Warnf("Locale: %v", err)
+				}
+				return locale
+			})
+			return next(c)
+		}
+	}
+}
+
+// RequestLocale returns the locale of the current request (see Locale) and records it in the
+// context as "locale" and in the Content-Language header. Call it before writing the response.
+func RequestLocale(c echo.Context) string {
+	if locale, ok := c.Get("locale").(string); ok {
+		return locale
+	}
+
+	var locale string
+	if resolve, ok := c.Get(localeResolverKey).(func() string); ok {
+		locale = resolve()
+	}
+	if !model.IsSupportedLocale(locale) {
+		locale = provider.MatchLocale(c.Request().Header.Get("Accept-Language"))
+	}
+
+	c.Set("locale", locale)
+	c.Response().Header().Set("Content-Language", locale)
+	c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+	return locale
+}
@@ -9,6 +9,7 @@ import (
 	exercise_handler "rtglabs-go/internal/handlers/exercise"
 	workout_handler "rtglabs-go/internal/handlers/workout"
 	workout_log_handler "rtglabs-go/internal/handlers/workout_log"
+	"rtglabs-go/internal/middleware"
 
 	"github.com/labstack/echo/v4"
 )
@@ -16,13 +17,13 @@ import (
 // registerPrivateRoutes registers all routes that require authentication.
 func (s *Server) registerPrivateRoutes() {
 	// Create the auth handler instance, passing s.sqlDB
-	authHandler := auth_handlers.NewAuthHandler(s.sqlDB, os.Getenv("GOOGLE_WEB_CLIENT_ID"))
+	authHandler := auth_handlers.NewAuthHandler(s.sqlDB, os.Getenv("GOOGLE_WEB_CLIENT_ID"), s.emailSender, s.storage)
 
-	// Create the bodyweight handler instance, passing s.sqlDB
-	bwHandler := bw_handlers.NewBodyweightHandler(s.sqlDB)
+	// Create the bodyweight handler instance, passing s.sqlDB and the email sender for goal notifications
+	bwHandler := bw_handlers.NewBodyweightHandler(s.sqlDB, s.emailSender)
 
 	// --- FIXED: Pass the Typesense client to ExerciseHandler ---
-	exerciseHandler := exercise_handler.NewExerciseHandler(s.sqlDB, s.typesenseClient)
+	exerciseHandler := exercise_handler.NewExerciseHandler(s.sqlDB, s.typesenseClient, s.exerciseSearcher, s.storage)
 	//
 	workoutHandler := workout_handler.NewWorkoutHandler(s.sqlDB)
 	//
@@ -52,6 +53,7 @@ func (s *Server) registerPrivateRoutes() {
 			return next(c)
 		}
 	})
+	g.Use(middleware.Locale(s.sqlDB))
 
 	g.POST("/logout", authHandler.DestroySession)
 	g.GET("/validate-session", authHandler.ValidateSession) // New endpoint
@@ -59,8 +61,15 @@ func (s *Server) registerPrivateRoutes() {
 	// Protected Profile routes
 	g.GET("/user/profile", authHandler.GetProfile)
 	g.PUT("/user/profile", authHandler.UpdateProfile)
+	g.PUT("/user/avatar", authHandler.UpdateAvatar)
+	g.GET("/user/energy", authHandler.GetEnergyEstimate)
+	g.GET("/user/muscle-volume", authHandler.GetMuscleVolume)
 
 	// Protected Bodyweight routes
+	g.GET("/bodyweights/goal", bwHandler.GetBodyweightGoal)
+	g.PUT("/bodyweights/goal", bwHandler.UpsertBodyweightGoal)
+	g.DELETE("/bodyweights/goal", bwHandler.DestroyBodyweightGoal)
+	g.POST("/bodyweights/import", bwHandler.ImportBodyweight)
 	g.POST("/bodyweights", bwHandler.StoreBodyweight)
 	g.GET("/bodyweights", bwHandler.IndexBodyweight)
 	g.GET("/bodyweights/:id", bwHandler.GetBodyweight)
@@ -70,12 +79,44 @@ func (s *Server) registerPrivateRoutes() {
 	// Protected Exercise routes
 	g.GET("/exercise", exerciseHandler.IndexExercise)
 	g.POST("/exercise", exerciseHandler.StoreExercise)
+	g.GET("/exercise/:id", exerciseHandler.GetExercise)
+	g.GET("/exercise/:id/alternatives", exerciseHandler.GetExerciseAlternatives)
+	g.PUT("/exercise/:id", exerciseHandler.UpdateExercise)
+	g.DELETE("/exercise/:id", exerciseHandler.DestroyExercise)
+	g.PUT("/exercise/:id/instructions", exerciseHandler.UpdateExerciseInstructions)
+	g.POST("/exercise/:id/media", exerciseHandler.StoreExerciseMedia)
+	g.DELETE("/exercise/:id/media/:media_id", exerciseHandler.DestroyExerciseMedia)
+	g.GET("/exercise/:id/translations", exerciseHandler.IndexExerciseTranslation)
+	g.PUT("/exercise/:id/translations/:locale", exerciseHandler.UpdateExerciseTranslation)
+	g.DELETE("/exercise/:id/translations/:locale", exerciseHandler.DestroyExerciseTranslation)
+	g.GET("/exercise/:id/note", exerciseHandler.GetExerciseNote)
+	g.PUT("/exercise/:id/note", exerciseHandler.UpdateExerciseNote)
+	g.DELETE("/exercise/:id/note", exerciseHandler.DestroyExerciseNote)
+	g.GET("/muscles", exerciseHandler.IndexMuscle)
+
+	// Admin routes
+	admin := g.Group("/admin", middleware.RequireAdmin(s.sqlDB))
+	admin.POST("/exercises/:id/promote", exerciseHandler.PromoteExercise)
+	admin.POST("/exercises/:id/merge", exerciseHandler.MergeExercise)
+	admin.GET("/exercises/:id/aliases", exerciseHandler.IndexExerciseAlias)
+	admin.POST("/exercises/:id/aliases", exerciseHandler.StoreExerciseAlias)
+	admin.DELETE("/exercises/:id/aliases/:alias_id", exerciseHandler.DestroyExerciseAlias)
+	admin.PUT("/exercises/:id/muscles", exerciseHandler.UpdateExerciseMuscles)
+	admin.POST("/muscles", exerciseHandler.StoreMuscle)
+	admin.PUT("/muscles/:id", exerciseHandler.UpdateMuscle)
+	admin.DELETE("/muscles/:id", exerciseHandler.DestroyMuscle)
+
 	// Protected Workout routes
 	g.POST("/workouts", workoutHandler.StoreWorkout)
 	g.GET("/workouts", workoutHandler.IndexWorkout)
+	g.PUT("/workouts/arrange", workoutHandler.ArrangeWorkouts)
 	g.GET("/workouts/:id", workoutHandler.GetWorkout)
 	g.PUT("/workouts/:id", workoutHandler.UpdateWorkout)
 	g.DELETE("/workouts/:id", workoutHandler.DestroyWorkout)
+	g.GET("/workout-folders", workoutHandler.IndexWorkoutFolder)
+	g.POST("/workout-folders", workoutHandler.StoreWorkoutFolder)
+	g.PUT("/workout-folders/:id", workoutHandler.UpdateWorkoutFolder)
+	g.DELETE("/workout-folders/:id", workoutHandler.DestroyWorkoutFolder)
 	//
 	g.GET("/workout-logs", workoutLogHandler.IndexWorkoutLog)
 	g.POST("/workout-logs", workoutLogHandler.StoreWorkoutLog)
@@ -20,7 +20,7 @@ import (
 func (s *Server) registerPublicRoutes() {
 	// ... (your existing handlers initialization)
 	forgotPasswordHandler := handlers.NewForgotPasswordHandler(s.sqlDB, s.emailSender, s.appBaseURL)
-	authHandler := auth_handlers.NewAuthHandler(s.sqlDB, os.Getenv("GOOGLE_WEB_CLIENT_ID"))
+	authHandler := auth_handlers.NewAuthHandler(s.sqlDB, os.Getenv("GOOGLE_WEB_CLIENT_ID"), s.emailSender, s.storage)
 
 	// --- MODIFIED STATIC FILE SERVER FOR DEVELOPMENT ---
 	// Determine the path to your 'assets' directory relative to the executable.
@@ -40,6 +40,11 @@ func (s *Server) registerPublicRoutes() {
 	log.Printf("Serving static files from disk: %s at URL path /assets", assetsDir)
 	s.echo.Static("/assets", assetsDir) // Use echo.Static directly
 
+	// Uploaded files (avatars) written by the local disk storage backend.
+	if s.uploadsDir != "" {
+		s.echo.Static("/uploads", s.uploadsDir)
+	}
+
 	// --- Original Static File Server (Comment out or remove) ---
 	// fileServer := http.FileServer(http.FS(web.Files))
 	// s.echo.GET("/assets/*", echo.WrapHandler(fileServer))
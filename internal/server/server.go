@@ -1,12 +1,15 @@
 package server
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -26,15 +29,19 @@ import (
 
 // Server holds the server configuration and dependencies.
 type Server struct {
-	port            int
-	db              database.Service
-	echo            *echo.Echo
-	logger          *zap.Logger // Assumed to be initialized by NewPrettyLogger() from elsewhere
-	emailSender     mail.EmailSender
-	appBaseURL      string
-	appConfig       *config.AppConfig // Consider removing if not used
-	sqlDB           *sql.DB
-	typesenseClient *typesense.Client // Correctly typed *typesense.Client
+	port             int
+	db               database.Service
+	echo             *echo.Echo
+	logger           *zap.Logger // Assumed to be initialized by NewPrettyLogger() from elsewhere
+	emailSender      mail.EmailSender
+	appBaseURL       string
+	appConfig        *config.AppConfig // Consider removing if not used
+	sqlDB            *sql.DB
+	typesenseClient  *typesense.Client         // Correctly typed *typesense.Client
+	typesense        *provider.TypesenseClient // Wrapper with the config and search circuit breaker, for /health
+	exerciseSearcher provider.ExerciseSearcher // Selected by EXERCISE_SEARCH_BACKEND
+	storage          provider.Storage          // Backend for user uploads (avatars)
+	uploadsDir       string                    // Served under /uploads when using local disk storage
 }
 
 // NewServer initializes and returns a new HTTP server.
@@ -83,21 +90,89 @@ func NewServer() *http.Server {
 
 	appBaseURL := os.Getenv("APP_BASE_URL")
 
-	// Initialize Typesense Client using your provider
-	tsClient := provider.NewTypesenseClient(
-		os.Getenv("TYPESENSE_HOST"),
-		os.Getenv("TYPESENSE_API_KEY"),
-	).Client // Access the underlying *typesense.Client from the provider's wrapper
+	// Pick the exercise search backend: "auto" (default) uses Typesense and fails over to
+	// Postgres while Typesense is unhealthy; "typesense" or "postgres" force one backend.
+	// With "postgres" Typesense is not configured at all.
+	searchBackend := provider.NormalizeExerciseSearchBackend(os.Getenv("EXERCISE_SEARCH_BACKEND"))
+	useTypesense := searchBackend != provider.ExerciseSearchBackendPostgres
+
+	// Initialize Typesense Client using your provider; see config.LoadTypesenseConfig for the settings
+	var (
+		typesenseProvider *provider.TypesenseClient
+		tsClient          *typesense.Client
+	)
+	if useTypesense {
+		tsConfig, err := config.LoadTypesenseConfig()
+		if err != nil {
+			log.Fatalf("Invalid Typesense configuration: %v", err)
+		}
+		typesenseProvider, err = provider.NewTypesenseClient(tsConfig)
+		if err != nil {
+			log.Fatalf("Invalid Typesense configuration: %v", err)
+		}
+		tsClient = typesenseProvider.Client // Access the underlying *typesense.Client from the provider's wrapper
+	}
+
+	exerciseSearcher, err := provider.NewExerciseSearcher(searchBackend, sqlDB, typesenseProvider)
+	if err != nil {
+		log.Fatalf("Failed to initialize exercise search: %v", err)
+	}
+
+	// Create the exercises collection if needed and refuse to start when its schema no longer
+	// matches what the indexer writes. An unreachable Typesense only logs a warning since
+	// search falls back to Postgres. TYPESENSE_SCHEMA_CHECK=warn downgrades drift to a warning.
+	if useTypesense {
+		indexer := provider.NewExerciseIndexer(tsClient)
+		schemaCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := indexer.EnsureCollection(schemaCtx)
+		if err == nil {
+			err = indexer.ValidateCollection(schemaCtx)
+		}
+		cancel()
+		switch {
+		case errors.Is(err, provider.ErrExerciseSchemaDrift) && os.Getenv("TYPESENSE_SCHEMA_CHECK") != "warn":
+			log.Fatalf("%v (run `admin reindex-exercises --recreate` to rebuild the collection)", err)
+		case err != nil:
+			log.Printf("Warning: exercises collection check failed: %v", err)
+		}
+	}
+
+	// Keep the Typesense index in sync with the exercises table by draining the search outbox.
+	// Set SEARCH_SYNC_WORKER=false on instances that should not run the worker. Without Typesense
+	// the rows stay queued and are applied once the backend is switched back.
+	if useTypesense && os.Getenv("SEARCH_SYNC_WORKER") != "false" {
+		worker := provider.NewSearchSyncWorker(sqlDB, provider.NewExerciseIndexer(tsClient))
+		go worker.Run(context.Background())
+	}
+
+	// Initialize the upload storage. Only the local disk backend exists for now;
+	// files are served by this server under /uploads.
+	uploadsDir := os.Getenv("STORAGE_LOCAL_DIR")
+	if uploadsDir == "" {
+		uploadsDir = "storage/uploads"
+	}
+	uploadsBaseURL := os.Getenv("STORAGE_PUBLIC_URL")
+	if uploadsBaseURL == "" {
+		uploadsBaseURL = strings.TrimRight(appBaseURL, "/") + "/uploads"
+	}
+	storage, err := provider.NewLocalDiskStorage(uploadsDir, uploadsBaseURL)
+	if err != nil {
+		log.Fatalf("Failed to initialize upload storage: %v", err)
+	}
 
 	s := &Server{
-		port:            port,
-		db:              database.New(), // Consider removing `db` field if `database.New()` is unused or sqlDB is sufficient
-		echo:            echo.New(),
-		logger:          NewPrettyLogger(), // Calls the actual NewPrettyLogger() from where it's defined
-		emailSender:     emailSender,
-		appBaseURL:      appBaseURL,
-		sqlDB:           sqlDB,
-		typesenseClient: tsClient, // Save the *typesense.Client here
+		port:             port,
+		db:               database.New(), // Consider removing `db` field if `database.New()` is unused or sqlDB is sufficient
+		echo:             echo.New(),
+		logger:           NewPrettyLogger(), // Calls the actual NewPrettyLogger() from where it's defined
+		emailSender:      emailSender,
+		appBaseURL:       appBaseURL,
+		sqlDB:            sqlDB,
+		typesenseClient:  tsClient, // Save the *typesense.Client here
+		typesense:        typesenseProvider,
+		exerciseSearcher: exerciseSearcher,
+		storage:          storage,
+		uploadsDir:       uploadsDir,
 	}
 
 	s.setupMiddleware()
@@ -228,7 +303,8 @@ func (s *Server) HelloWorldHandler(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-// healthHandler is a simple handler for the "/health" route.
+// healthHandler is a simple handler for the "/health" route. An unreachable Typesense only
+// reports "degraded" since exercise search falls back to Postgres.
 func (s *Server) healthHandler(c echo.Context) error {
 	if err := s.sqlDB.Ping(); err != nil {
 		s.logger.Error("Database health check failed", zap.Error(err))
@@ -237,7 +313,22 @@ func (s *Server) healthHandler(c echo.Context) error {
 			"error":  "database connection failed",
 		})
 	}
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "healthy",
+
+	status := "healthy"
+	typesenseStatus := map[string]string{"status": "disabled"}
+	if s.typesense != nil { // Not configured with EXERCISE_SEARCH_BACKEND=postgres
+		ctx, cancel := context.WithTimeout(c.Request().Context(), 2*time.Second)
+		ok, err := s.typesense.Client.Health(ctx, 2*time.Second)
+		cancel()
+		typesenseStatus["status"] = "ok"
+		if err != nil || !ok {
+			typesenseStatus["status"] = "unreachable"
+			status = "degraded"
+		}
+		typesenseStatus["circuit_breaker"] = s.typesense.SearchBreaker.State().String()
+	}
+	return c.JSON(http.StatusOK, map[string]any{
+		"status":    status,
+		"typesense": typesenseStatus,
 	})
 }
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bodyweight_goals (
+    id UUID UNIQUE PRIMARY KEY,
+    user_id UUID NOT NULL,
+    start_weight REAL NOT NULL,
+    target_weight REAL NOT NULL,
+    target_date DATE NOT NULL,
+    weekly_rate REAL NULL,
+    reached_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE NULL,
+
+    CONSTRAINT fk_bodyweight_goals_user
+        FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE
+);
+
+-- A user can only have one active (non-deleted) goal at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bodyweight_goals_active_user
+    ON bodyweight_goals (user_id)
+    WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_bodyweight_goals_active_user;
+DROP TABLE IF EXISTS bodyweight_goals;
+-- +goose StatementEnd
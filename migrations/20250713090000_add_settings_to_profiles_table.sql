@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE profiles
+    ADD COLUMN IF NOT EXISTS birthdate DATE NULL,
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN IF NOT EXISTS week_start_day INTEGER NOT NULL DEFAULT 1, -- 0 = Sunday ... 6 = Saturday (Go's time.Weekday)
+    ADD COLUMN IF NOT EXISTS default_rest_seconds INTEGER NOT NULL DEFAULT 90;
+
+ALTER TABLE profiles
+    ADD CONSTRAINT chk_profiles_week_start_day CHECK (week_start_day BETWEEN 0 AND 6),
+    ADD CONSTRAINT chk_profiles_default_rest_seconds CHECK (default_rest_seconds BETWEEN 0 AND 3600);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE profiles
+    DROP CONSTRAINT IF EXISTS chk_profiles_default_rest_seconds,
+    DROP CONSTRAINT IF EXISTS chk_profiles_week_start_day,
+    DROP COLUMN IF EXISTS default_rest_seconds,
+    DROP COLUMN IF EXISTS week_start_day,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS birthdate;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- pg_trgm powers the fuzzy (typo tolerant) matching of the Postgres exercise search fallback.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_exercises_name_trgm
+    ON exercises USING GIN (name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_exercises_name_fts
+    ON exercises USING GIN (to_tsvector('simple', name));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_exercises_name_fts;
+DROP INDEX IF EXISTS idx_exercises_name_trgm;
+-- The extension is left installed; other objects may depend on it.
+-- +goose StatementEnd
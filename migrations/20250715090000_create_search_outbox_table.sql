@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Transactional outbox for the search index: rows are written in the same transaction as the
+-- change to the source table and processed asynchronously by the search sync worker.
+CREATE TABLE IF NOT EXISTS search_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL, -- e.g. 'exercise'
+    entity_id UUID NOT NULL,
+    operation VARCHAR(10) NOT NULL,   -- 'upsert' or 'delete'
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    available_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP, -- Pushed back on failure
+    processed_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_search_outbox_operation CHECK (operation IN ('upsert', 'delete'))
+);
+
+-- The worker only ever scans pending rows in order of availability.
+CREATE INDEX IF NOT EXISTS idx_search_outbox_pending
+    ON search_outbox (available_at, id)
+    WHERE processed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_search_outbox_pending;
+DROP TABLE IF EXISTS search_outbox;
+-- +goose StatementEnd
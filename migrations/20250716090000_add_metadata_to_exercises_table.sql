@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Exercise metadata used to live only in the Typesense documents. Postgres is now the source
+-- of truth; the vocabulary-controlled columns are validated by the API (see model/exercise.go).
+ALTER TABLE exercises
+    ADD COLUMN IF NOT EXISTS description TEXT NULL,
+    ADD COLUMN IF NOT EXISTS position VARCHAR(50) NULL,
+    ADD COLUMN IF NOT EXISTS force_type VARCHAR(50) NULL,
+    ADD COLUMN IF NOT EXISTS difficulty VARCHAR(50) NULL,
+    ADD COLUMN IF NOT EXISTS movement_type VARCHAR(50) NULL,
+    ADD COLUMN IF NOT EXISTS muscle_group VARCHAR(50) NULL,
+    ADD COLUMN IF NOT EXISTS equipment VARCHAR(50) NULL,
+    ADD COLUMN IF NOT EXISTS bodypart VARCHAR(50) NULL;
+
+-- The Postgres search fallback also matches the description now.
+DROP INDEX IF EXISTS idx_exercises_name_fts;
+CREATE INDEX IF NOT EXISTS idx_exercises_search_fts
+    ON exercises USING GIN (to_tsvector('simple', name || ' ' || COALESCE(description, '')));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_exercises_search_fts;
+CREATE INDEX IF NOT EXISTS idx_exercises_name_fts
+    ON exercises USING GIN (to_tsvector('simple', name));
+
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS bodypart,
+    DROP COLUMN IF EXISTS equipment,
+    DROP COLUMN IF EXISTS muscle_group,
+    DROP COLUMN IF EXISTS movement_type,
+    DROP COLUMN IF EXISTS difficulty,
+    DROP COLUMN IF EXISTS force_type,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS description;
+-- +goose StatementEnd
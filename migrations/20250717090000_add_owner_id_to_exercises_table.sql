@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- owner_id NULL means the exercise is part of the global catalog; otherwise it is a custom
+-- exercise only visible to its owner.
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS owner_id UUID NULL;
+ALTER TABLE exercises
+    ADD CONSTRAINT fk_exercises_owner
+        FOREIGN KEY (owner_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE;
+
+-- Names are unique within the global catalog and within each user's custom exercises,
+-- instead of across the whole table.
+ALTER TABLE exercises DROP CONSTRAINT IF EXISTS exercises_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_global_name
+    ON exercises (name)
+    WHERE owner_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_owner_name
+    ON exercises (owner_id, name)
+    WHERE owner_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Restoring the global UNIQUE(name) fails if users created custom exercises sharing a name.
+DROP INDEX IF EXISTS idx_exercises_owner_name;
+DROP INDEX IF EXISTS idx_exercises_global_name;
+ALTER TABLE exercises ADD CONSTRAINT exercises_name_key UNIQUE (name);
+ALTER TABLE exercises DROP CONSTRAINT IF EXISTS fk_exercises_owner;
+ALTER TABLE exercises DROP COLUMN IF EXISTS owner_id;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Admins can manage the global exercise catalog. Grant with: admin set-admin --email <email>
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
+-- +goose StatementEnd
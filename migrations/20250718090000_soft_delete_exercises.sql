@@ -0,0 +1,84 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleting an exercise used to cascade into workouts and logged history. Exercises are now
+-- soft deleted; the foreign keys refuse a hard delete while the exercise is still referenced.
+-- NO ACTION (instead of RESTRICT) is checked at the end of the statement, so deleting a user
+-- still works when the cascade removes their custom exercises and their workouts together.
+ALTER TABLE exercise_instances DROP CONSTRAINT IF EXISTS fk_exercise_instances_exercise;
+ALTER TABLE exercise_instances
+    ADD CONSTRAINT fk_exercise_instances_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE NO ACTION;
+
+ALTER TABLE workout_exercises DROP CONSTRAINT IF EXISTS fk_workout_exercises_exercise;
+ALTER TABLE workout_exercises
+    ADD CONSTRAINT fk_workout_exercises_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE NO ACTION;
+
+ALTER TABLE logged_exercise_instances DROP CONSTRAINT IF EXISTS fk_logged_exercise_instances_exercise;
+ALTER TABLE logged_exercise_instances
+    ADD CONSTRAINT fk_logged_exercise_instances_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE NO ACTION;
+
+ALTER TABLE exercise_sets DROP CONSTRAINT IF EXISTS fk_exercise_sets_exercise;
+ALTER TABLE exercise_sets
+    ADD CONSTRAINT fk_exercise_sets_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE NO ACTION;
+
+-- Soft-deleted exercises no longer reserve their name.
+DROP INDEX IF EXISTS idx_exercises_global_name;
+DROP INDEX IF EXISTS idx_exercises_owner_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_global_name
+    ON exercises (name)
+    WHERE owner_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_owner_name
+    ON exercises (owner_id, name)
+    WHERE owner_id IS NOT NULL AND deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_exercises_global_name;
+DROP INDEX IF EXISTS idx_exercises_owner_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_global_name
+    ON exercises (name)
+    WHERE owner_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_owner_name
+    ON exercises (owner_id, name)
+    WHERE owner_id IS NOT NULL;
+
+ALTER TABLE exercise_sets DROP CONSTRAINT IF EXISTS fk_exercise_sets_exercise;
+ALTER TABLE exercise_sets
+    ADD CONSTRAINT fk_exercise_sets_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE;
+
+ALTER TABLE logged_exercise_instances DROP CONSTRAINT IF EXISTS fk_logged_exercise_instances_exercise;
+ALTER TABLE logged_exercise_instances
+    ADD CONSTRAINT fk_logged_exercise_instances_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE;
+
+ALTER TABLE workout_exercises DROP CONSTRAINT IF EXISTS fk_workout_exercises_exercise;
+ALTER TABLE workout_exercises
+    ADD CONSTRAINT fk_workout_exercises_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE;
+
+ALTER TABLE exercise_instances DROP CONSTRAINT IF EXISTS fk_exercise_instances_exercise;
+ALTER TABLE exercise_instances
+    ADD CONSTRAINT fk_exercise_instances_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE;
+-- +goose StatementEnd
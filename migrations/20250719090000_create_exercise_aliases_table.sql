@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Alternative names people search for ("RDL", "OHP", "skull crushers"). They are indexed with the
+-- exercise in Typesense, pushed as synonyms, and matched by the Postgres fallback search.
+CREATE TABLE IF NOT EXISTS exercise_aliases (
+    id UUID PRIMARY KEY,
+    exercise_id UUID NOT NULL,
+    alias VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exercise_aliases_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercise_aliases_exercise_alias
+    ON exercise_aliases (exercise_id, LOWER(alias));
+
+CREATE INDEX IF NOT EXISTS idx_exercise_aliases_alias_trgm
+    ON exercise_aliases USING GIN (alias gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exercise_aliases;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How sets of an exercise are measured (see model.ExerciseTrackingTypes). Existing exercises
+-- keep the weight × reps behaviour they always had.
+ALTER TABLE exercises
+    ADD COLUMN IF NOT EXISTS tracking_type VARCHAR(32) NOT NULL DEFAULT 'weight_reps';
+
+-- Logged values for time and distance based exercises (planks, rowing, running, carries).
+ALTER TABLE exercise_sets
+    ADD COLUMN IF NOT EXISTS duration_seconds INTEGER NULL CHECK (duration_seconds >= 0),
+    ADD COLUMN IF NOT EXISTS distance_meters NUMERIC(10,2) NULL CHECK (distance_meters >= 0);
+
+-- Matching targets on workout templates.
+ALTER TABLE workout_exercises
+    ADD COLUMN IF NOT EXISTS duration_seconds INTEGER NULL CHECK (duration_seconds >= 0),
+    ADD COLUMN IF NOT EXISTS distance_meters NUMERIC(10,2) NULL CHECK (distance_meters >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workout_exercises
+    DROP COLUMN IF EXISTS distance_meters,
+    DROP COLUMN IF EXISTS duration_seconds;
+
+ALTER TABLE exercise_sets
+    DROP COLUMN IF EXISTS distance_meters,
+    DROP COLUMN IF EXISTS duration_seconds;
+
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS tracking_type;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Stable identifier of catalog exercises loaded by `admin seed-exercises`. Exercises created
+-- through the API have no slug.
+ALTER TABLE exercises
+    ADD COLUMN IF NOT EXISTS slug VARCHAR(255) NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercises_slug
+    ON exercises (slug)
+    WHERE slug IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_exercises_slug;
+
+ALTER TABLE exercises
+    DROP COLUMN IF EXISTS slug;
+-- +goose StatementEnd
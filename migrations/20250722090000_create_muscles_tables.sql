@@ -0,0 +1,75 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Individual muscles, finer grained than exercises.muscle_group: the "shoulders" group holds the
+-- front, side and rear delts. Admins manage the catalog; the rows below are the starting set.
+CREATE TABLE IF NOT EXISTS muscles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    muscle_group VARCHAR(50) NULL, -- One of model.ExerciseMuscleGroups
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_muscles_slug UNIQUE (slug)
+);
+
+-- Which muscles an exercise works. weight is the share of the exercise's volume attributed to
+-- the muscle, e.g. bench press: chest 1.0 (primary), triceps 0.5 and front delts 0.5 (secondary).
+CREATE TABLE IF NOT EXISTS exercise_muscles (
+    exercise_id UUID NOT NULL,
+    muscle_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    weight NUMERIC(4, 3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (exercise_id, muscle_id),
+    CONSTRAINT fk_exercise_muscles_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_exercise_muscles_muscle
+        FOREIGN KEY (muscle_id)
+        REFERENCES muscles (id)
+        ON DELETE RESTRICT,
+    CONSTRAINT chk_exercise_muscles_role CHECK (role IN ('primary', 'secondary')),
+    CONSTRAINT chk_exercise_muscles_weight CHECK (weight > 0 AND weight <= 1)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_muscles_muscle_id ON exercise_muscles (muscle_id);
+
+INSERT INTO muscles (slug, name, muscle_group) VALUES
+    ('upper_chest', 'Upper Chest', 'chest'),
+    ('chest', 'Chest', 'chest'),
+    ('lats', 'Latissimus Dorsi', 'lats'),
+    ('upper_back', 'Upper Back (Rhomboids)', 'back'),
+    ('traps', 'Trapezius', 'traps'),
+    ('lower_back', 'Erector Spinae', 'lower_back'),
+    ('front_delts', 'Front Delts', 'shoulders'),
+    ('side_delts', 'Side Delts', 'shoulders'),
+    ('rear_delts', 'Rear Delts', 'shoulders'),
+    ('rotator_cuff', 'Rotator Cuff', 'shoulders'),
+    ('biceps', 'Biceps', 'biceps'),
+    ('brachialis', 'Brachialis', 'biceps'),
+    ('triceps', 'Triceps', 'triceps'),
+    ('forearm_flexors', 'Forearm Flexors', 'forearms'),
+    ('forearm_extensors', 'Forearm Extensors', 'forearms'),
+    ('abs', 'Rectus Abdominis', 'abs'),
+    ('obliques', 'Obliques', 'obliques'),
+    ('quadriceps', 'Quadriceps', 'quadriceps'),
+    ('hamstrings', 'Hamstrings', 'hamstrings'),
+    ('glutes', 'Gluteus Maximus', 'glutes'),
+    ('glute_medius', 'Gluteus Medius', 'abductors'),
+    ('adductors', 'Adductors', 'adductors'),
+    ('hip_flexors', 'Hip Flexors', NULL),
+    ('calves', 'Calves', 'calves'),
+    ('tibialis', 'Tibialis Anterior', NULL),
+    ('neck', 'Neck', 'neck')
+ON CONFLICT (slug) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exercise_muscles;
+DROP TABLE IF EXISTS muscles;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How to perform an exercise. Each column is an ordered list of short texts; the steps are
+-- indexed for search, the cues and mistakes are only shown on the exercise page.
+CREATE TABLE IF NOT EXISTS exercise_instructions (
+    exercise_id UUID PRIMARY KEY,
+    steps TEXT[] NOT NULL DEFAULT '{}',
+    form_cues TEXT[] NOT NULL DEFAULT '{}',
+    common_mistakes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exercise_instructions_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE
+);
+
+-- Images, GIFs and videos attached to an exercise. Uploaded files keep their storage key so
+-- they can be deleted with the row; external URLs (e.g. YouTube) have none.
+CREATE TABLE IF NOT EXISTS exercise_media (
+    id UUID PRIMARY KEY,
+    exercise_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    url TEXT NOT NULL,
+    storage_key TEXT NULL,
+    content_type VARCHAR(100) NULL,
+    caption VARCHAR(255) NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exercise_media_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE,
+    CONSTRAINT chk_exercise_media_kind CHECK (kind IN ('image', 'gif', 'video'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_media_exercise_id ON exercise_media (exercise_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exercise_media;
+DROP TABLE IF EXISTS exercise_instructions;
+-- +goose StatementEnd
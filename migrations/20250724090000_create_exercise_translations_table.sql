@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Translations of the exercise texts, one row per exercise and locale (e.g. "es"). The
+-- exercises table keeps the model.DefaultLocale text; a missing translation falls back to it.
+CREATE TABLE IF NOT EXISTS exercise_translations (
+    exercise_id UUID NOT NULL,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    steps TEXT[] NOT NULL DEFAULT '{}',
+    form_cues TEXT[] NOT NULL DEFAULT '{}',
+    common_mistakes TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (exercise_id, locale),
+    CONSTRAINT fk_exercise_translations_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_translations_name_trgm ON exercise_translations USING gin (name gin_trgm_ops);
+
+-- The language the user picked in the app; NULL follows the Accept-Language header.
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE profiles DROP COLUMN IF EXISTS locale;
+DROP TABLE IF EXISTS exercise_translations;
+-- +goose StatementEnd
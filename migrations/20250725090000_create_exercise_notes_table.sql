@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A user's own notes on an exercise (seat height, grip width, pain notes), shown wherever the
+-- exercise appears in their workouts and logs. Pinned cues are short reminders shown while
+-- logging sets.
+CREATE TABLE IF NOT EXISTS exercise_notes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    exercise_id UUID NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    pinned_cues TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_exercise_notes_user
+        FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE,
+    CONSTRAINT fk_exercise_notes_exercise
+        FOREIGN KEY (exercise_id)
+        REFERENCES exercises (id)
+        ON DELETE CASCADE,
+    CONSTRAINT uq_exercise_notes_user_exercise UNIQUE (user_id, exercise_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exercise_notes;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Folders group a user's workout templates, e.g. "Push/Pull/Legs" or "Deload weeks".
+CREATE TABLE IF NOT EXISTS workout_folders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_workout_folders_user
+        FOREIGN KEY (user_id)
+        REFERENCES users (id)
+        ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_workout_folders_user_name ON workout_folders (user_id, LOWER(name));
+
+-- Deleting a folder moves its templates back to the top level. Position orders the templates
+-- within their folder (or the top level); tags are free-form, stored lowercase.
+ALTER TABLE workouts
+    ADD COLUMN IF NOT EXISTS folder_id UUID NULL
+        CONSTRAINT fk_workouts_folder REFERENCES workout_folders (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_workouts_folder_id ON workouts (folder_id, position);
+CREATE INDEX IF NOT EXISTS idx_workouts_tags ON workouts USING gin (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_workouts_tags;
+DROP INDEX IF EXISTS idx_workouts_folder_id;
+ALTER TABLE workouts
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS folder_id;
+DROP TABLE IF EXISTS workout_folders;
+-- +goose StatementEnd
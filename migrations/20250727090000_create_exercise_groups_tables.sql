@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Groups of template exercises performed back to back: supersets, giant sets and circuits.
+-- Exercises without a group are straight sets.
+CREATE TABLE IF NOT EXISTS workout_exercise_groups (
+    id UUID PRIMARY KEY,
+    workout_id UUID NOT NULL,
+    group_type VARCHAR(20) NOT NULL,
+    rounds INTEGER NOT NULL DEFAULT 1,
+    rest_between_exercises_seconds INTEGER NULL,
+    rest_between_rounds_seconds INTEGER NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_workout_exercise_groups_type CHECK (group_type IN ('superset', 'giant_set', 'circuit')),
+    CONSTRAINT chk_workout_exercise_groups_rounds CHECK (rounds >= 1),
+    CONSTRAINT fk_workout_exercise_groups_workout
+        FOREIGN KEY (workout_id)
+        REFERENCES workouts (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_exercise_groups_workout_id ON workout_exercise_groups (workout_id);
+
+ALTER TABLE workout_exercises
+    ADD COLUMN IF NOT EXISTS group_id UUID NULL
+        CONSTRAINT fk_workout_exercises_group REFERENCES workout_exercise_groups (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_workout_exercises_group_id ON workout_exercises (group_id);
+
+-- Copied from the template when a workout log is started, so later template edits don't change past logs.
+CREATE TABLE IF NOT EXISTS logged_exercise_groups (
+    id UUID PRIMARY KEY,
+    workout_log_id UUID NOT NULL,
+    group_type VARCHAR(20) NOT NULL,
+    rounds INTEGER NOT NULL DEFAULT 1,
+    rest_between_exercises_seconds INTEGER NULL,
+    rest_between_rounds_seconds INTEGER NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_logged_exercise_groups_type CHECK (group_type IN ('superset', 'giant_set', 'circuit')),
+    CONSTRAINT chk_logged_exercise_groups_rounds CHECK (rounds >= 1),
+    CONSTRAINT fk_logged_exercise_groups_workout_log
+        FOREIGN KEY (workout_log_id)
+        REFERENCES workout_logs (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_logged_exercise_groups_workout_log_id ON logged_exercise_groups (workout_log_id);
+
+ALTER TABLE logged_exercise_instances
+    ADD COLUMN IF NOT EXISTS group_id UUID NULL
+        CONSTRAINT fk_logged_exercise_instances_group REFERENCES logged_exercise_groups (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_logged_exercise_instances_group_id ON logged_exercise_instances (group_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_logged_exercise_instances_group_id;
+ALTER TABLE logged_exercise_instances DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS logged_exercise_groups;
+DROP INDEX IF EXISTS idx_workout_exercises_group_id;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS workout_exercise_groups;
+-- +goose StatementEnd
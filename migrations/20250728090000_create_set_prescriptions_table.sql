@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Per-set targets of a template exercise, e.g. 1 warm-up at 60%, 3 working sets of 5 at 100 kg
+-- and an AMRAP back-off. When present they replace the exercise's flat sets/reps/weight.
+CREATE TABLE IF NOT EXISTS set_prescriptions (
+    id UUID PRIMARY KEY,
+    workout_exercise_id UUID NOT NULL,
+    set_number INTEGER NOT NULL,
+    set_type VARCHAR(20) NOT NULL DEFAULT 'working',
+    target_reps INTEGER NULL,
+    target_reps_max INTEGER NULL,         -- With target_reps, a rep range such as 8-12
+    target_weight NUMERIC(8,2) NULL,
+    target_percent_1rm NUMERIC(5,2) NULL, -- Percentage of the estimated one-rep max, instead of a weight
+    target_rpe NUMERIC(3,1) NULL,
+    rest_seconds INTEGER NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_set_prescriptions_set UNIQUE (workout_exercise_id, set_number),
+    CONSTRAINT chk_set_prescriptions_type CHECK (set_type IN ('warmup', 'working', 'backoff', 'drop', 'amrap', 'failure')),
+    CONSTRAINT chk_set_prescriptions_rep_range CHECK (target_reps_max IS NULL OR target_reps IS NULL OR target_reps_max >= target_reps),
+    CONSTRAINT chk_set_prescriptions_load CHECK (target_weight IS NULL OR target_percent_1rm IS NULL),
+    CONSTRAINT chk_set_prescriptions_rpe CHECK (target_rpe IS NULL OR target_rpe BETWEEN 1 AND 10),
+    CONSTRAINT fk_set_prescriptions_workout_exercise
+        FOREIGN KEY (workout_exercise_id)
+        REFERENCES workout_exercises (id)
+        ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS set_prescriptions;
+-- +goose StatementEnd
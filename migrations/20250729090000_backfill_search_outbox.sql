@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Documents indexed before owner_id existed don't match the owner_id filter of the Typesense
+-- search, which would hide the whole catalog. Queue every exercise for re-indexing so the search
+-- sync worker rewrites them with the current document fields; soft-deleted exercises are removed.
+INSERT INTO search_outbox (entity_type, entity_id, operation)
+SELECT 'exercise', id, 'upsert'
+FROM exercises;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Nothing to undo: the queued entries are either processed already or harmless to process.
+SELECT 1;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sets expanded from a set prescription keep its set type and targets, so the log still shows a
+-- warm-up as a warm-up after the template changes. NULL for sets that weren't prescribed.
+ALTER TABLE exercise_sets
+    ADD COLUMN IF NOT EXISTS set_type VARCHAR(20) NULL,
+    ADD COLUMN IF NOT EXISTS target_reps_max INTEGER NULL,
+    ADD COLUMN IF NOT EXISTS target_rpe NUMERIC(3,1) NULL,
+    ADD COLUMN IF NOT EXISTS rest_seconds INTEGER NULL,
+    ADD CONSTRAINT chk_exercise_sets_type CHECK (set_type IS NULL OR set_type IN ('warmup', 'working', 'backoff', 'drop', 'amrap', 'failure')),
+    ADD CONSTRAINT chk_exercise_sets_rpe CHECK (target_rpe IS NULL OR target_rpe BETWEEN 1 AND 10);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercise_sets
+    DROP CONSTRAINT IF EXISTS chk_exercise_sets_rpe,
+    DROP CONSTRAINT IF EXISTS chk_exercise_sets_type,
+    DROP COLUMN IF EXISTS rest_seconds,
+    DROP COLUMN IF EXISTS target_rpe,
+    DROP COLUMN IF EXISTS target_reps_max,
+    DROP COLUMN IF EXISTS set_type;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BodyweightGoal represents a row in the 'bodyweight_goals' table.
+// A user has at most one active (non-deleted) goal; replacing a goal soft-deletes the old one.
+type BodyweightGoal struct {
+	ID           uuid.UUID  `db:"id" json:"id"`
+	UserID       uuid.UUID  `db:"user_id" json:"userId"`             // FK to users.id
+	StartWeight  float64    `db:"start_weight" json:"startWeight"`   // Latest bodyweight when the goal was set
+	TargetWeight float64    `db:"target_weight" json:"targetWeight"` // NOT NULL
+	TargetDate   time.Time  `db:"target_date" json:"targetDate"`     // DATE column, NOT NULL
+	WeeklyRate   *float64   `db:"weekly_rate" json:"weeklyRate"`     // Optional planned change per week (always positive)
+	ReachedAt    *time.Time `db:"reached_at" json:"reachedAt"`       // Set once when the goal is first reached
+	CreatedAt    time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updatedAt"`
+	DeletedAt    *time.Time `db:"deleted_at" json:"deletedAt"` // For soft deletes, nullable
+}
+
+// IsLoss reports whether the goal is to lose weight (target below the start weight).
+func (g *BodyweightGoal) IsLoss() bool {
+	return g.TargetWeight < g.StartWeight
+}
+
+// IsReachedBy reports whether the given weight satisfies the goal in its direction.
+func (g *BodyweightGoal) IsReachedBy(weight float64) bool {
+	if g.IsLoss() {
+		return weight <= g.TargetWeight
+	}
+	return weight >= g.TargetWeight
+}
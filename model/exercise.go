@@ -1,15 +1,130 @@
 package model
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Exercise struct {
-	ID        uuid.UUID  `db:"id" json:"id"`
-	Name      string     `db:"name" json:"name"`
-	CreatedAt time.Time  `db:"created_at" json:"createdAt"`
-	UpdatedAt time.Time  `db:"updated_at" json:"updatedAt"`
-	DeletedAt *time.Time `db:"deleted_at" json:"deletedAt"`
+	ID           uuid.UUID  `db:"id" json:"id"`
+	Name         string     `db:"name" json:"name"`
+	Slug         *string    `db:"slug" json:"slug"`        // Set for exercises loaded from the seed dataset
+	OwnerID      *uuid.UUID `db:"owner_id" json:"ownerId"` // NULL for the global catalog, otherwise a user's custom exercise
+	Description  *string    `db:"description" json:"description"`
+	Position     *string    `db:"position" json:"position"`          // One of ExercisePositions
+	ForceType    *string    `db:"force_type" json:"forceType"`       // One of ExerciseForceTypes
+	Difficulty   *string    `db:"difficulty" json:"difficulty"`      // One of ExerciseDifficulties
+	MovementType *string    `db:"movement_type" json:"movementType"` // One of ExerciseMovementTypes
+	MuscleGroup  *string    `db:"muscle_group" json:"muscleGroup"`   // One of ExerciseMuscleGroups
+	Equipment    *string    `db:"equipment" json:"equipment"`        // One of ExerciseEquipment
+	Bodypart     *string    `db:"bodypart" json:"bodypart"`          // One of ExerciseBodyparts
+	TrackingType string     `db:"tracking_type" json:"trackingType"` // One of ExerciseTrackingTypes
+	CreatedAt    time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt    time.Time  `db:"updated_at" json:"updatedAt"`
+	DeletedAt    *time.Time `db:"deleted_at" json:"deletedAt"`
+	Aliases      []string   `db:"-" json:"aliases"` // Loaded from exercise_aliases when needed
+	// Muscles is loaded from exercise_muscles when needed.
+	Muscles []ExerciseMuscle `db:"-" json:"muscles"`
+	// Instructions and Media are loaded from exercise_instructions and exercise_media when
+	// needed; Instructions is nil for exercises without any.
+	Instructions *ExerciseInstructions `db:"-" json:"instructions"`
+	Media        []ExerciseMedia       `db:"-" json:"media"`
+	// Translations is loaded from exercise_translations when needed, e.g. to index the
+	// translated names.
+	Translations []ExerciseTranslation `db:"-" json:"translations"`
+}
+
+// Values of Exercise.TrackingType: which values the sets of an exercise record.
+const (
+	ExerciseTrackingWeightReps       = "weight_reps"       // Bench press, squat
+	ExerciseTrackingReps             = "reps"              // Pull-ups, push-ups
+	ExerciseTrackingDuration         = "duration"          // Plank, dead hang
+	ExerciseTrackingDistance         = "distance"          // Sled push for distance
+	ExerciseTrackingDistanceDuration = "distance_duration" // Running, rowing, cycling
+	ExerciseTrackingWeightDistance   = "weight_distance"   // Farmer's carry
+)
+
+// ExerciseTrackingTypes lists the valid Exercise.TrackingType values.
+var ExerciseTrackingTypes = []string{
+	ExerciseTrackingWeightReps, ExerciseTrackingReps, ExerciseTrackingDuration,
+	ExerciseTrackingDistance, ExerciseTrackingDistanceDuration, ExerciseTrackingWeightDistance,
+}
+
+// ExerciseMetrics tells which values a tracking type records.
+type ExerciseMetrics struct {
+	Weight   bool
+	Reps     bool
+	Duration bool
+	Distance bool
+}
+
+var exerciseTrackingMetrics = map[string]ExerciseMetrics{
+	ExerciseTrackingWeightReps:       {Weight: true, Reps: true},
+	ExerciseTrackingReps:             {Reps: true},
+	ExerciseTrackingDuration:         {Duration: true},
+	ExerciseTrackingDistance:         {Distance: true},
+	ExerciseTrackingDistanceDuration: {Distance: true, Duration: true},
+	ExerciseTrackingWeightDistance:   {Weight: true, Distance: true},
+}
+
+// TrackingMetrics returns the metrics recorded by trackingType. Unknown types (and the empty
+// string of rows read without the column) fall back to weight × reps.
+func TrackingMetrics(trackingType string) ExerciseMetrics {
+	if metrics, ok := exerciseTrackingMetrics[trackingType]; ok {
+		return metrics
+	}
+	return exerciseTrackingMetrics[ExerciseTrackingWeightReps]
+}
+
+// IsCustom reports whether the exercise belongs to a single user rather than the global catalog.
+func (e *Exercise) IsCustom() bool {
+	return e.OwnerID != nil
+}
+
+// Controlled vocabularies for the exercise metadata columns. Values are lower snake_case
+// so they can be used directly as search facets and filter values.
+var (
+	ExercisePositions = []string{
+		"standing", "seated", "lying", "kneeling", "hanging", "bent_over", "incline", "decline",
+	}
+	ExerciseForceTypes = []string{
+		"push", "pull", "static",
+	}
+	ExerciseDifficulties = []string{
+		"beginner", "intermediate", "advanced",
+	}
+	ExerciseMovementTypes = []string{
+		"compound", "isolation",
+	}
+	ExerciseMuscleGroups = []string{
+		"chest", "back", "lats", "traps", "lower_back", "shoulders", "biceps", "triceps", "forearms",
+		"abs", "obliques", "quadriceps", "hamstrings", "glutes", "calves", "adductors", "abductors",
+		"neck", "full_body",
+	}
+	ExerciseEquipment = []string{
+		"barbell", "dumbbell", "kettlebell", "machine", "cable", "bodyweight", "band", "smith_machine",
+		"ez_bar", "trap_bar", "medicine_ball", "plate", "pull_up_bar", "other",
+	}
+	ExerciseBodyparts = []string{
+		"upper_body", "lower_body", "core", "full_body",
+	}
+)
+
+// ExerciseVocabularies maps each vocabulary-controlled column to its allowed values.
+var ExerciseVocabularies = map[string][]string{
+	"position":      ExercisePositions,
+	"force_type":    ExerciseForceTypes,
+	"difficulty":    ExerciseDifficulties,
+	"movement_type": ExerciseMovementTypes,
+	"muscle_group":  ExerciseMuscleGroups,
+	"equipment":     ExerciseEquipment,
+	"bodypart":      ExerciseBodyparts,
+	"tracking_type": ExerciseTrackingTypes,
+}
+
+// IsExerciseVocabularyValue reports whether value is allowed for the given column.
+func IsExerciseVocabularyValue(column, value string) bool {
+	return slices.Contains(ExerciseVocabularies[column], value)
 }
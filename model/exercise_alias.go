@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExerciseAlias represents a row in the 'exercise_aliases' table: an alternative name
+// an exercise is searched by.
+type ExerciseAlias struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	ExerciseID uuid.UUID `db:"exercise_id" json:"exerciseId"`
+	Alias      string    `db:"alias" json:"alias"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
+}
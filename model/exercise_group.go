@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Exercise group types. Exercises outside a group are done as straight sets.
+const (
+	ExerciseGroupTypeSuperset = "superset"  // Two exercises alternated
+	ExerciseGroupTypeGiantSet = "giant_set" // Three or more exercises back to back
+	ExerciseGroupTypeCircuit  = "circuit"   // Any number of exercises repeated for rounds
+)
+
+// ExerciseGroup represents a row in the 'workout_exercise_groups' table, or in
+// 'logged_exercise_groups' once copied into a workout log. OwnerID is the workout or
+// workout log the group belongs to.
+type ExerciseGroup struct {
+	ID                          uuid.UUID `db:"id" json:"id"`
+	OwnerID                     uuid.UUID `json:"ownerId"`
+	GroupType                   string    `db:"group_type" json:"groupType"`
+	Rounds                      int       `db:"rounds" json:"rounds"`
+	RestBetweenExercisesSeconds *int      `db:"rest_between_exercises_seconds" json:"restBetweenExercisesSeconds"` // Nullable
+	RestBetweenRoundsSeconds    *int      `db:"rest_between_rounds_seconds" json:"restBetweenRoundsSeconds"`       // Nullable
+	CreatedAt                   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt                   time.Time `db:"updated_at" json:"updatedAt"`
+
+	MemberIDs []uuid.UUID `json:"memberIds"` // Workout exercise (or logged exercise instance) IDs, in order
+}
+
+// ValidateExerciseGroupSize checks that a group of the given type has a workable number of exercises.
+func ValidateExerciseGroupSize(groupType string, members int) error {
+	switch groupType {
+	case ExerciseGroupTypeSuperset:
+		if members != 2 {
+			return fmt.Errorf("a superset needs exactly 2 exercises, got %d", members)
+		}
+	case ExerciseGroupTypeGiantSet:
+		if members < 3 {
+			return fmt.Errorf("a giant set needs at least 3 exercises, got %d", members)
+		}
+	default:
+		if members < 2 {
+			return fmt.Errorf("a %s needs at least 2 exercises, got %d", groupType, members)
+		}
+	}
+	return nil
+}
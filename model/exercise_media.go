@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExerciseInstructions represents a row in the 'exercise_instructions' table.
+type ExerciseInstructions struct {
+	ExerciseID     uuid.UUID `db:"exercise_id" json:"exerciseId"`
+	Steps          []string  `db:"steps" json:"steps"`                    // In order, e.g. "Unrack the bar"
+	FormCues       []string  `db:"form_cues" json:"formCues"`             // e.g. "Keep your elbows tucked"
+	CommonMistakes []string  `db:"common_mistakes" json:"commonMistakes"` // e.g. "Bouncing the bar off the chest"
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ExerciseMedia represents a row in the 'exercise_media' table: an image, GIF or video of an exercise.
+type ExerciseMedia struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	ExerciseID  uuid.UUID `db:"exercise_id" json:"exerciseId"`
+	Kind        string    `db:"kind" json:"kind"` // One of ExerciseMediaKinds
+	URL         string    `db:"url" json:"url"`
+	StorageKey  *string   `db:"storage_key" json:"-"` // Set for files uploaded to our storage
+	ContentType *string   `db:"content_type" json:"contentType"`
+	Caption     *string   `db:"caption" json:"caption"`
+	Position    int       `db:"position" json:"position"` // Display order, ascending
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// Values of ExerciseMedia.Kind.
+const (
+	ExerciseMediaImage = "image"
+	ExerciseMediaGIF   = "gif"
+	ExerciseMediaVideo = "video" // External URL only, e.g. YouTube or Vimeo
+)
+
+// ExerciseMediaKinds lists the valid ExerciseMedia.Kind values.
+var ExerciseMediaKinds = []string{ExerciseMediaImage, ExerciseMediaGIF, ExerciseMediaVideo}
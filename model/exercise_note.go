@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExerciseNote represents a row in the 'exercise_notes' table: a user's own notes on an
+// exercise, kept across every workout and log containing it.
+type ExerciseNote struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	UserID     uuid.UUID `db:"user_id" json:"userId"`
+	ExerciseID uuid.UUID `db:"exercise_id" json:"exerciseId"`
+	Note       string    `db:"note" json:"note"`              // Free text, e.g. "Seat on notch 4"
+	PinnedCues []string  `db:"pinned_cues" json:"pinnedCues"` // Short reminders shown while logging, e.g. "Elbows in"
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
+}
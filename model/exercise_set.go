@@ -1,6 +1,7 @@
 package model
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,11 +15,19 @@ type ExerciseSet struct {
 	SetNumber                int        `db:"set_number"`
 	Weight                   *float64   `db:"weight"`                     // Nullable
 	Reps                     *int       `db:"reps" json:"reps"` // Nullable, uses pointer
+	DurationSeconds          *int       `db:"duration_seconds"`           // Nullable, for duration tracked exercises
+	DistanceMeters           *float64   `db:"distance_meters"`            // Nullable, for distance tracked exercises
 	FinishedAt               *time.Time `db:"finished_at"`                // Nullable
 	Status                   int        `db:"status"`                     // e.g., 0=Pending, 1=Completed, 2=Skipped
 	CreatedAt                time.Time  `db:"created_at"`
 	UpdatedAt                time.Time  `db:"updated_at"`
 	DeletedAt                *time.Time `db:"deleted_at"` // Nullable
+
+	// Copied from the set prescription the set was expanded from, nil otherwise
+	SetType       *string  `db:"set_type"`
+	TargetRepsMax *int     `db:"target_reps_max"`
+	TargetRPE     *float64 `db:"target_rpe"`
+	RestSeconds   *int     `db:"rest_seconds"`
 }
 
 const (
@@ -27,3 +36,50 @@ const (
 	// Add other statuses if needed
 )
 
+
+// SetMetrics are the measured values of a logged set, or the targets of a workout exercise.
+type SetMetrics struct {
+	Weight          *float64
+	Reps            *int
+	DurationSeconds *int
+	DistanceMeters  *float64
+}
+
+// ValidateSetMetrics checks m against the tracking type of its exercise. Metrics the type doesn't
+// record must be empty (a zero weight counts as empty, clients send it for unweighted sets).
+// When complete is set, the reps, duration and distance the type records must be present;
+// weight never is required since 0 means bodyweight.
+func ValidateSetMetrics(trackingType string, m SetMetrics, complete bool) error {
+	metrics := TrackingMetrics(trackingType)
+	if m.DurationSeconds != nil && *m.DurationSeconds < 0 {
+		return fmt.Errorf("duration can't be negative")
+	}
+	if m.DistanceMeters != nil && *m.DistanceMeters < 0 {
+		return fmt.Errorf("distance can't be negative")
+	}
+	if !metrics.Weight && m.Weight != nil && *m.Weight != 0 {
+		return fmt.Errorf("%s exercises don't record weight", trackingType)
+	}
+	if !metrics.Reps && m.Reps != nil && *m.Reps != 0 {
+		return fmt.Errorf("%s exercises don't record reps", trackingType)
+	}
+	if !metrics.Duration && m.DurationSeconds != nil {
+		return fmt.Errorf("%s exercises don't record a duration", trackingType)
+	}
+	if !metrics.Distance && m.DistanceMeters != nil {
+		return fmt.Errorf("%s exercises don't record a distance", trackingType)
+	}
+	if !complete {
+		return nil
+	}
+	if metrics.Reps && m.Reps == nil {
+		return fmt.Errorf("%s exercises require reps", trackingType)
+	}
+	if metrics.Duration && m.DurationSeconds == nil {
+		return fmt.Errorf("%s exercises require a duration", trackingType)
+	}
+	if metrics.Distance && m.DistanceMeters == nil {
+		return fmt.Errorf("%s exercises require a distance", trackingType)
+	}
+	return nil
+}
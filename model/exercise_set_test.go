@@ -0,0 +1,41 @@
+package model
+
+import "testing"
+
+func TestValidateSetMetrics(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name         string
+		trackingType string
+		metrics      SetMetrics
+		complete     bool
+		wantErr      bool
+	}{
+		{name: "nil metrics, incomplete", trackingType: ExerciseTrackingWeightReps, metrics: SetMetrics{}, wantErr: false},
+		{name: "nil reps, complete", trackingType: ExerciseTrackingWeightReps, metrics: SetMetrics{}, complete: true, wantErr: true},
+		{name: "zero reps, complete", trackingType: ExerciseTrackingWeightReps, metrics: SetMetrics{Reps: intPtr(0)}, complete: true, wantErr: false},
+		{name: "weight never required", trackingType: ExerciseTrackingWeightReps, metrics: SetMetrics{Reps: intPtr(5)}, complete: true, wantErr: false},
+		{name: "zero weight on reps exercise", trackingType: ExerciseTrackingReps, metrics: SetMetrics{Weight: floatPtr(0), Reps: intPtr(10)}, complete: true, wantErr: false},
+		{name: "weight on reps exercise", trackingType: ExerciseTrackingReps, metrics: SetMetrics{Weight: floatPtr(20), Reps: intPtr(10)}, wantErr: true},
+		{name: "zero reps on duration exercise", trackingType: ExerciseTrackingDuration, metrics: SetMetrics{Reps: intPtr(0), DurationSeconds: intPtr(60)}, complete: true, wantErr: false},
+		{name: "reps on duration exercise", trackingType: ExerciseTrackingDuration, metrics: SetMetrics{Reps: intPtr(3)}, wantErr: true},
+		{name: "zero duration on distance exercise", trackingType: ExerciseTrackingDistance, metrics: SetMetrics{DurationSeconds: intPtr(0)}, wantErr: true},
+		{name: "zero distance on duration exercise", trackingType: ExerciseTrackingDuration, metrics: SetMetrics{DistanceMeters: floatPtr(0)}, wantErr: true},
+		{name: "negative duration", trackingType: ExerciseTrackingDuration, metrics: SetMetrics{DurationSeconds: intPtr(-1)}, wantErr: true},
+		{name: "negative distance", trackingType: ExerciseTrackingDistance, metrics: SetMetrics{DistanceMeters: floatPtr(-5)}, wantErr: true},
+		{name: "nil distance, complete", trackingType: ExerciseTrackingDistanceDuration, metrics: SetMetrics{DurationSeconds: intPtr(600)}, complete: true, wantErr: true},
+		{name: "distance and duration, complete", trackingType: ExerciseTrackingDistanceDuration, metrics: SetMetrics{DurationSeconds: intPtr(600), DistanceMeters: floatPtr(2000)}, complete: true, wantErr: false},
+		{name: "unknown type falls back to weight reps", trackingType: "", metrics: SetMetrics{Weight: floatPtr(60), Reps: intPtr(8)}, complete: true, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetMetrics(tt.trackingType, tt.metrics, tt.complete)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSetMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
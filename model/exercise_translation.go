@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExerciseTranslation represents a row in the 'exercise_translations' table: the texts of an
+// exercise in a locale other than DefaultLocale.
+type ExerciseTranslation struct {
+	ExerciseID     uuid.UUID `db:"exercise_id" json:"exerciseId"`
+	Locale         string    `db:"locale" json:"locale"` // One of SupportedLocales, never DefaultLocale
+	Name           string    `db:"name" json:"name"`
+	Description    *string   `db:"description" json:"description"`
+	Steps          []string  `db:"steps" json:"steps"` // Same shape as ExerciseInstructions
+	FormCues       []string  `db:"form_cues" json:"formCues"`
+	CommonMistakes []string  `db:"common_mistakes" json:"commonMistakes"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// Localize replaces the texts of ex with those of t. A missing description or an empty
+// instruction list keeps the default text, so a partial translation still reads sensibly.
+func (ex *Exercise) Localize(t *ExerciseTranslation) {
+	if t == nil {
+		return
+	}
+	ex.Name = t.Name
+	if t.Description != nil {
+		ex.Description = t.Description
+	}
+	if ex.Instructions == nil && len(t.Steps)+len(t.FormCues)+len(t.CommonMistakes) == 0 {
+		return
+	}
+	localized := ExerciseInstructions{ExerciseID: ex.ID, CreatedAt: t.CreatedAt, UpdatedAt: t.UpdatedAt}
+	if ex.Instructions != nil {
+		localized = *ex.Instructions
+	}
+	if len(t.Steps) > 0 {
+		localized.Steps = t.Steps
+	}
+	if len(t.FormCues) > 0 {
+		localized.FormCues = t.FormCues
+	}
+	if len(t.CommonMistakes) > 0 {
+		localized.CommonMistakes = t.CommonMistakes
+	}
+	ex.Instructions = &localized
+}
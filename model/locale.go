@@ -0,0 +1,16 @@
+package model
+
+import "slices"
+
+// DefaultLocale is the language of the texts stored on the exercises themselves. Requests in
+// an unsupported language, and texts without a translation, fall back to it.
+const DefaultLocale = "en"
+
+// SupportedLocales lists the locales exercises can be translated to, DefaultLocale first.
+// Locales are lowercase ISO 639-1 language codes.
+var SupportedLocales = []string{DefaultLocale, "es", "fr", "de", "it", "pt"}
+
+// IsSupportedLocale reports whether locale is one of SupportedLocales.
+func IsSupportedLocale(locale string) bool {
+	return slices.Contains(SupportedLocales, locale)
+}
@@ -15,4 +15,6 @@ type LoggedExerciseInstance struct {
 	CreatedAt    time.Time  `db:"created_at"`
 	UpdatedAt    time.Time  `db:"updated_at"`
 	DeletedAt    *time.Time `db:"deleted_at"` // Nullable
+
+	GroupID *uuid.UUID `db:"group_id"` // Superset/circuit copied from the template, nil for straight sets
 }
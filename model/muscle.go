@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Muscle represents a row in the 'muscles' table: one entry of the muscle catalog.
+type Muscle struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	Slug        string    `db:"slug" json:"slug"`
+	Name        string    `db:"name" json:"name"`
+	MuscleGroup *string   `db:"muscle_group" json:"muscleGroup"` // One of ExerciseMuscleGroups
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// ExerciseMuscle represents a row in the 'exercise_muscles' table together with the muscle it
+// points at.
+type ExerciseMuscle struct {
+	ExerciseID uuid.UUID `db:"exercise_id" json:"exerciseId"`
+	MuscleID   uuid.UUID `db:"muscle_id" json:"muscleId"`
+	Role       string    `db:"role" json:"role"`     // One of ExerciseMuscleRoles
+	Weight     float64   `db:"weight" json:"weight"` // Share of the exercise's volume, in (0, 1]
+	Muscle     Muscle    `db:"-" json:"muscle"`
+}
+
+// Values of ExerciseMuscle.Role.
+const (
+	ExerciseMuscleRolePrimary   = "primary"
+	ExerciseMuscleRoleSecondary = "secondary"
+)
+
+// ExerciseMuscleRoles lists the valid ExerciseMuscle.Role values.
+var ExerciseMuscleRoles = []string{ExerciseMuscleRolePrimary, ExerciseMuscleRoleSecondary}
+
+// DefaultExerciseMuscleWeight is the weight of a mapping created without one.
+func DefaultExerciseMuscleWeight(role string) float64 {
+	if role == ExerciseMuscleRoleSecondary {
+		return 0.5
+	}
+	return 1
+}
@@ -8,14 +8,82 @@ import (
 
 // Profile represents a row in the 'profiles' table.
 type Profile struct {
-	ID        uuid.UUID  `db:"id" json:"id"`          // From custommixin.UUID
-	UserID    uuid.UUID  `db:"user_id" json:"userId"` // Foreign Key to users.id. This must be UNIQUE in the DB.
-	Units     int        `db:"units" json:"units"`
-	Age       int        `db:"age" json:"age"`
-	Height    float64    `db:"height" json:"height"` // float64 is suitable for DECIMAL/NUMERIC types
-	Gender    int        `db:"gender" json:"gender"`
-	CreatedAt time.Time  `db:"created_at" json:"createdAt"` // From custommixin.Timestamps
-	UpdatedAt time.Time  `db:"updated_at" json:"updatedAt"` // From custommixin.Timestamps
-	DeletedAt *time.Time `db:"deleted_at" json:"deletedAt"` // From custommixin.Timestamps (for soft deletes), nullable
-	AvatarURL *string    `db:"avatar_url" json:"avatar_url"`
+	ID                 uuid.UUID  `db:"id" json:"id"`          // From custommixin.UUID
+	UserID             uuid.UUID  `db:"user_id" json:"userId"` // Foreign Key to users.id. This must be UNIQUE in the DB.
+	Units              int        `db:"units" json:"units"`
+	Age                int        `db:"age" json:"age"`       // Legacy static age; prefer Birthdate (see AgeAt)
+	Height             float64    `db:"height" json:"height"` // float64 is suitable for DECIMAL/NUMERIC types
+	Gender             Gender     `db:"gender" json:"gender"`
+	Birthdate          *time.Time `db:"birthdate" json:"birthdate"`                     // DATE column, nullable
+	Timezone           string     `db:"timezone" json:"timezone"`                       // IANA name, defaults to "UTC"
+	WeekStartDay       int        `db:"week_start_day" json:"weekStartDay"`             // time.Weekday: 0 = Sunday ... 6 = Saturday
+	DefaultRestSeconds int        `db:"default_rest_seconds" json:"defaultRestSeconds"` // Default rest timer between sets
+	CreatedAt          time.Time  `db:"created_at" json:"createdAt"`                    // From custommixin.Timestamps
+	UpdatedAt          time.Time  `db:"updated_at" json:"updatedAt"`                    // From custommixin.Timestamps
+	DeletedAt          *time.Time `db:"deleted_at" json:"deletedAt"`                    // From custommixin.Timestamps (for soft deletes), nullable
+	AvatarURL          *string    `db:"avatar_url" json:"avatar_url"`
+	Locale             *string    `db:"locale" json:"locale"` // One of SupportedLocales; nil follows Accept-Language
+}
+
+// Values stored in Profile.Units. Bodyweights are stored in the user's profile unit.
+const (
+	ProfileUnitsMetric   = 0 // kilograms
+	ProfileUnitsImperial = 1 // pounds
+)
+
+// Defaults applied when a profile is created without the optional settings.
+const (
+	ProfileDefaultTimezone       = "UTC"
+	ProfileDefaultWeekStartDay   = int(time.Monday)
+	ProfileDefaultRestSeconds    = 90
+	ProfileMaxDefaultRestSeconds = 3600
+	ProfileMinAgeYears           = 13
+	ProfileMaxAgeYears           = 120
+)
+
+// Gender is the typed enum stored in profiles.gender.
+// It is serialized as its integer value to stay compatible with existing clients.
+type Gender int
+
+const (
+	GenderUnspecified Gender = 0
+	GenderMale        Gender = 1
+	GenderFemale      Gender = 2
+	GenderOther       Gender = 3
+)
+
+// IsValid reports whether g is one of the known gender values.
+func (g Gender) IsValid() bool {
+	switch g {
+	case GenderUnspecified, GenderMale, GenderFemale, GenderOther:
+		return true
+	}
+	return false
+}
+
+// String returns a readable name for the gender, used in logs and emails.
+func (g Gender) String() string {
+	switch g {
+	case GenderMale:
+		return "male"
+	case GenderFemale:
+		return "female"
+	case GenderOther:
+		return "other"
+	}
+	return "unspecified"
+}
+
+// AgeAt returns the profile's age in whole years at the given time.
+// It is derived from Birthdate when present and falls back to the legacy Age column otherwise.
+func (p *Profile) AgeAt(now time.Time) int {
+	if p.Birthdate == nil {
+		return p.Age
+	}
+	b := *p.Birthdate
+	age := now.Year() - b.Year()
+	if now.Month() < b.Month() || (now.Month() == b.Month() && now.Day() < b.Day()) {
+		age--
+	}
+	return age
 }
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchOutboxEntry represents a row in the 'search_outbox' table: a pending change
+// that still has to be applied to the search index.
+type SearchOutboxEntry struct {
+	ID          int64      `db:"id" json:"id"`
+	EntityType  string     `db:"entity_type" json:"entityType"`
+	EntityID    uuid.UUID  `db:"entity_id" json:"entityId"`
+	Operation   string     `db:"operation" json:"operation"`
+	Attempts    int        `db:"attempts" json:"attempts"`
+	LastError   *string    `db:"last_error" json:"lastError"`
+	AvailableAt time.Time  `db:"available_at" json:"availableAt"` // Not processed before this time (retry backoff)
+	ProcessedAt *time.Time `db:"processed_at" json:"processedAt"`
+	CreatedAt   time.Time  `db:"created_at" json:"createdAt"`
+}
+
+// Values stored in SearchOutboxEntry.EntityType.
+const (
+	SearchEntityExercise = "exercise"
+)
+
+// Values stored in SearchOutboxEntry.Operation.
+const (
+	SearchOperationUpsert = "upsert"
+	SearchOperationDelete = "delete"
+)
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Set types of a set prescription.
+const (
+	SetTypeWarmup  = "warmup"
+	SetTypeWorking = "working"
+	SetTypeBackoff = "backoff"
+	SetTypeDrop    = "drop"
+	SetTypeAMRAP   = "amrap" // As many reps as possible, TargetReps is the minimum
+	SetTypeFailure = "failure"
+)
+
+// SetPrescription represents a row in the 'set_prescriptions' table: the targets of one set of
+// a workout template exercise.
+type SetPrescription struct {
+	ID                uuid.UUID `db:"id" json:"id"`
+	WorkoutExerciseID uuid.UUID `db:"workout_exercise_id" json:"workoutExerciseId"`
+	SetNumber         int       `db:"set_number" json:"setNumber"`
+	SetType           string    `db:"set_type" json:"setType"`
+	TargetReps        *int      `db:"target_reps" json:"targetReps"`              // Nullable
+	TargetRepsMax     *int      `db:"target_reps_max" json:"targetRepsMax"`       // Nullable, upper end of a rep range
+	TargetWeight      *float64  `db:"target_weight" json:"targetWeight"`          // Nullable
+	TargetPercent1RM  *float64  `db:"target_percent_1rm" json:"targetPercent1rm"` // Nullable, used instead of TargetWeight
+	TargetRPE         *float64  `db:"target_rpe" json:"targetRpe"`                // Nullable
+	RestSeconds       *int      `db:"rest_seconds" json:"restSeconds"`            // Nullable
+	CreatedAt         time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// PrefillWeight returns the weight to pre-fill a logged set with: the target weight, or the target
+// percentage of oneRepMax rounded to 0.5. It returns nil when neither is known (oneRepMax <= 0).
+func (p *SetPrescription) PrefillWeight(oneRepMax float64) *float64 {
+	if p.TargetWeight != nil {
+		return p.TargetWeight
+	}
+	if p.TargetPercent1RM == nil || oneRepMax <= 0 {
+		return nil
+	}
+	weight := math.Round(oneRepMax**p.TargetPercent1RM/100*2) / 2
+	return &weight
+}
+
+// EstimateOneRepMax estimates a one-rep max from a set of reps at weight with the Epley formula.
+func EstimateOneRepMax(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	return weight * (1 + float64(reps)/30)
+}
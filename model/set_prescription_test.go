@@ -0,0 +1,60 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSetPrescriptionPrefillWeight(t *testing.T) {
+	floatPtr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name         string
+		prescription SetPrescription
+		oneRepMax    float64
+		want         *float64
+	}{
+		{name: "target weight", prescription: SetPrescription{TargetWeight: floatPtr(100)}, oneRepMax: 140, want: floatPtr(100)},
+		{name: "target weight without one-rep max", prescription: SetPrescription{TargetWeight: floatPtr(100)}, want: floatPtr(100)},
+		{name: "percentage of one-rep max", prescription: SetPrescription{TargetPercent1RM: floatPtr(72.5)}, oneRepMax: 100, want: floatPtr(72.5)},
+		{name: "rounded to half a kilo", prescription: SetPrescription{TargetPercent1RM: floatPtr(75)}, oneRepMax: 103, want: floatPtr(77.5)},
+		{name: "percentage without one-rep max", prescription: SetPrescription{TargetPercent1RM: floatPtr(80)}, want: nil},
+		{name: "percentage with negative one-rep max", prescription: SetPrescription{TargetPercent1RM: floatPtr(80)}, oneRepMax: -1, want: nil},
+		{name: "no load target", prescription: SetPrescription{}, oneRepMax: 100, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.prescription.PrefillWeight(tt.oneRepMax)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil:
+				t.Errorf("PrefillWeight() = %v, want %v", got, tt.want)
+			case *got != *tt.want:
+				t.Errorf("PrefillWeight() = %v, want %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateOneRepMax(t *testing.T) {
+	tests := []struct {
+		name   string
+		weight float64
+		reps   int
+		want   float64
+	}{
+		{name: "zero reps", weight: 100, reps: 0, want: 100},
+		{name: "single", weight: 100, reps: 1, want: 100},
+		{name: "five reps", weight: 90, reps: 5, want: 105},
+		{name: "ten reps", weight: 60, reps: 10, want: 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateOneRepMax(tt.weight, tt.reps); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("EstimateOneRepMax() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
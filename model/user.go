@@ -19,4 +19,5 @@ type User struct {
 	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
 	GoogleID        *string    `db:"google_id" json:"-"`
 	Provider        *string    `db:"provider" json:"provider"` // "email" | "google"
+	IsAdmin         bool       `db:"is_admin" json:"is_admin"` // Can manage the global exercise catalog
 }
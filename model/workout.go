@@ -16,6 +16,12 @@ type Workout struct {
 	CreatedAt time.Time  `db:"created_at" json:"createdAt"`           // From custommixin.Timestamps
 	UpdatedAt time.Time  `db:"updated_at" json:"updatedAt"`           // From custommixin.Timestamps
 	DeletedAt *time.Time `db:"deleted_at" json:"deletedAt"` // From custommixin.Timestamps (for soft deletes), nullable
+
+	// Organization of the user's templates: the folder (nil for the top level), the order
+	// within it and free-form lowercase tags.
+	FolderID *uuid.UUID `db:"folder_id" json:"folderId"`
+	Position int        `db:"position" json:"position"`
+	Tags     []string   `db:"tags" json:"tags"`
 }
 
 // NOTE ON EDGES (Relationships):
@@ -20,6 +20,8 @@ type WorkoutExercise struct {
 	Sets               *int       `db:"sets" json:"sets"`                               // Nullable, uses pointer
 	Weight             *float64   `db:"weight" json:"weight"`                           // Nullable, uses pointer
 	Reps               *int       `db:"reps" json:"reps"`                               // Nullable, uses pointer
+	DurationSeconds    *int       `db:"duration_seconds" json:"durationSeconds"`        // Nullable, target for duration tracked exercises
+	DistanceMeters     *float64   `db:"distance_meters" json:"distanceMeters"`          // Nullable, target for distance tracked exercises
 	WorkoutID          uuid.UUID  `db:"workout_id" json:"workoutId"`                              // FK to workouts.id, UNIQUE and NOT NULL
 	ExerciseID         uuid.UUID  `db:"exercise_id" json:"exerciseId"`                            // FK to exercises.id, UNIQUE and NOT NULL
 	ExerciseInstanceID *uuid.UUID `db:"exercise_instance_id" json:"exerciseInstanceId"` // FK to exercise_instances.id, UNIQUE and NULLABLE
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkoutFolder represents a row in the 'workout_folders' table: a user's group of workout templates.
+type WorkoutFolder struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	UserID    uuid.UUID `db:"user_id" json:"userId"`
+	Name      string    `db:"name" json:"name"` // Unique per user, case-insensitively
+	Position  int       `db:"position" json:"position"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"time"
+)
+
+// WeightPoint is a single bodyweight measurement used for trend calculations.
+type WeightPoint struct {
+	At     time.Time
+	Weight float64
+}
+
+// WeeklyWeightTrend fits a least-squares line through the given points and returns
+// the slope expressed as weight change per week (negative means losing weight).
+// ok is false when there are fewer than two points or they all fall on the same instant,
+// in which case no meaningful trend can be computed.
+func WeeklyWeightTrend(points []WeightPoint) (perWeek float64, ok bool) {
+	if len(points) < 2 {
+		return 0, false
+	}
+
+	// Use days since the first point as X to keep the numbers small.
+	origin := points[0].At
+	for _, p := range points {
+		if p.At.Before(origin) {
+			origin = p.At
+		}
+	}
+
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := p.At.Sub(origin).Hours() / 24
+		sumX += x
+		sumY += p.Weight
+		sumXY += x * p.Weight
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+
+	slopePerDay := (n*sumXY - sumX*sumY) / denominator
+	return slopePerDay * 7, true
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWeeklyWeightTrend(t *testing.T) {
+	start := time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return start.AddDate(0, 0, n) }
+
+	tests := []struct {
+		name        string
+		points      []WeightPoint
+		wantPerWeek float64
+		wantOK      bool
+	}{
+		{name: "no points", points: nil, wantOK: false},
+		{name: "one point", points: []WeightPoint{{At: start, Weight: 80}}, wantOK: false},
+		{
+			name:   "same instant",
+			points: []WeightPoint{{At: start, Weight: 80}, {At: start, Weight: 81}},
+			wantOK: false,
+		},
+		{
+			name:        "flat",
+			points:      []WeightPoint{{At: day(0), Weight: 80}, {At: day(7), Weight: 80}},
+			wantPerWeek: 0,
+			wantOK:      true,
+		},
+		{
+			name:        "losing half a kilo a week",
+			points:      []WeightPoint{{At: day(0), Weight: 80}, {At: day(7), Weight: 79.5}, {At: day(14), Weight: 79}},
+			wantPerWeek: -0.5,
+			wantOK:      true,
+		},
+		{
+			name:        "unordered points",
+			points:      []WeightPoint{{At: day(14), Weight: 81}, {At: day(0), Weight: 80}, {At: day(7), Weight: 80.5}},
+			wantPerWeek: 0.5,
+			wantOK:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			perWeek, ok := WeeklyWeightTrend(tt.points)
+			if ok != tt.wantOK {
+				t.Fatalf("WeeklyWeightTrend() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(perWeek-tt.wantPerWeek) > 1e-9 {
+				t.Errorf("WeeklyWeightTrend() perWeek = %v, want %v", perWeek, tt.wantPerWeek)
+			}
+		})
+	}
+}
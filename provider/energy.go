@@ -0,0 +1,133 @@
+package provider
+
+import "math"
+
+// Energy constants used by the estimation helpers.
+const (
+	// KcalPerKgBodyweight is the commonly used energy equivalent of one kilogram of body mass change.
+	KcalPerKgBodyweight = 7700.0
+
+	KgPerLb = 0.45359237
+	CmPerIn = 2.54
+
+	// Activity factors follow the usual sedentary -> extremely active scale.
+	ActivityFactorMin = 1.2
+	ActivityFactorMax = 1.9
+)
+
+// Sex used by the Mifflin-St Jeor equation. Unknown averages the male and female constants.
+type EnergySex int
+
+const (
+	EnergySexUnknown EnergySex = iota
+	EnergySexMale
+	EnergySexFemale
+)
+
+// MifflinStJeorBMR returns the basal metabolic rate in kcal/day.
+//
+//	BMR = 10*kg + 6.25*cm - 5*age + s, where s = +5 (male), -161 (female)
+func MifflinStJeorBMR(weightKg, heightCm float64, ageYears int, sex EnergySex) float64 {
+	base := 10*weightKg + 6.25*heightCm - 5*float64(ageYears)
+	switch sex {
+	case EnergySexMale:
+		return base + 5
+	case EnergySexFemale:
+		return base - 161
+	}
+	return base + (5-161)/2.0
+}
+
+// TrainingSummary aggregates a user's logged workouts over a window of days.
+type TrainingSummary struct {
+	WindowDays           int
+	Sessions             int     // Completed workout logs in the window
+	ActiveSeconds        float64 // Sum of total_active_duration_seconds
+	VolumeKg             float64 // Sum of weight * reps over completed sets (in kg)
+	BodyweightKg         float64 // Used to normalise volume
+	SessionsPerWeek      float64
+	AvgSessionMinutes    float64
+	VolumePerSessionByBW float64 // Average session volume divided by bodyweight
+}
+
+// NewTrainingSummary fills in the derived per-week / per-session values.
+func NewTrainingSummary(windowDays, sessions int, activeSeconds, volumeKg, bodyweightKg float64) TrainingSummary {
+	s := TrainingSummary{
+		WindowDays:    windowDays,
+		Sessions:      sessions,
+		ActiveSeconds: activeSeconds,
+		VolumeKg:      volumeKg,
+		BodyweightKg:  bodyweightKg,
+	}
+	if windowDays > 0 {
+		s.SessionsPerWeek = float64(sessions) / (float64(windowDays) / 7)
+	}
+	if sessions > 0 {
+		s.AvgSessionMinutes = activeSeconds / 60 / float64(sessions)
+		if bodyweightKg > 0 {
+			s.VolumePerSessionByBW = volumeKg / float64(sessions) / bodyweightKg
+		}
+	}
+	return s
+}
+
+// ActivityFactor derives a TDEE multiplier from training frequency, session length and volume.
+//
+// Frequency picks the base tier (sedentary 1.2 up to very active 1.725). Long or high-volume
+// sessions move it up by 0.05 each, short and light ones move it down, and the result is
+// clamped to [ActivityFactorMin, ActivityFactorMax].
+func ActivityFactor(s TrainingSummary) float64 {
+	var factor float64
+	switch {
+	case s.SessionsPerWeek < 0.5:
+		factor = 1.2
+	case s.SessionsPerWeek < 2.5:
+		factor = 1.375
+	case s.SessionsPerWeek < 4.5:
+		factor = 1.55
+	case s.SessionsPerWeek < 6.5:
+		factor = 1.725
+	default:
+		factor = 1.85
+	}
+
+	if s.Sessions > 0 {
+		switch {
+		case s.AvgSessionMinutes >= 75:
+			factor += 0.05
+		case s.AvgSessionMinutes > 0 && s.AvgSessionMinutes < 30:
+			factor -= 0.05
+		}
+		// Volume per session relative to bodyweight: ~100x bodyweight moved is a hard session.
+		switch {
+		case s.VolumePerSessionByBW >= 100:
+			factor += 0.05
+		case s.VolumePerSessionByBW > 0 && s.VolumePerSessionByBW < 30:
+			factor -= 0.05
+		}
+	}
+
+	return math.Min(ActivityFactorMax, math.Max(ActivityFactorMin, factor))
+}
+
+// KcalPerDayForWeeklyChange converts a weekly bodyweight change (kg) into a daily energy balance.
+func KcalPerDayForWeeklyChange(kgPerWeek float64) float64 {
+	return kgPerWeek * KcalPerKgBodyweight / 7
+}
+
+// RoundKcal rounds calories to the nearest 10, which is as precise as these estimates get.
+func RoundKcal(kcal float64) float64 {
+	return math.Round(kcal/10) * 10
+}
+
+// Defaults for the calorie targets derived from TDEE.
+const (
+	EnergyCutDeficitKcal   = 500.0  // ~0.45 kg per week
+	EnergyBulkSurplusKcal  = 250.0  // Lean bulk, ~0.2 kg per week
+	EnergyMinDailyCalories = 1200.0 // Targets never go below this or the BMR
+)
+
+// CalorieFloor is the lowest daily target suggested for a user with the given BMR.
+func CalorieFloor(bmr float64) float64 {
+	return math.Max(EnergyMinDailyCalories, bmr)
+}
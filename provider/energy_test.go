@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMifflinStJeorBMR(t *testing.T) {
+	tests := []struct {
+		name string
+		sex  EnergySex
+		want float64
+	}{
+		{name: "male", sex: EnergySexMale, want: 1780},
+		{name: "female", sex: EnergySexFemale, want: 1614},
+		{name: "unknown averages both", sex: EnergySexUnknown, want: 1697},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MifflinStJeorBMR(80, 180, 30, tt.sex); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("MifflinStJeorBMR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTrainingSummary(t *testing.T) {
+	s := NewTrainingSummary(28, 12, 12*3600, 12*8000, 80)
+	if s.SessionsPerWeek != 3 {
+		t.Errorf("SessionsPerWeek = %v, want 3", s.SessionsPerWeek)
+	}
+	if s.AvgSessionMinutes != 60 {
+		t.Errorf("AvgSessionMinutes = %v, want 60", s.AvgSessionMinutes)
+	}
+	if s.VolumePerSessionByBW != 100 {
+		t.Errorf("VolumePerSessionByBW = %v, want 100", s.VolumePerSessionByBW)
+	}
+
+	empty := NewTrainingSummary(0, 0, 0, 0, 0)
+	if empty.SessionsPerWeek != 0 || empty.AvgSessionMinutes != 0 || empty.VolumePerSessionByBW != 0 {
+		t.Errorf("NewTrainingSummary() with no data = %+v, want zero derived values", empty)
+	}
+}
+
+func TestActivityFactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary TrainingSummary
+		want    float64
+	}{
+		{name: "no training", summary: NewTrainingSummary(28, 0, 0, 0, 80), want: 1.2},
+		{name: "moderate, long and heavy", summary: NewTrainingSummary(28, 12, 12*3600, 12*8000, 80), want: 1.6},
+		{name: "light, short and easy", summary: NewTrainingSummary(28, 4, 4*20*60, 4*800, 80), want: 1.275},
+		{name: "clamped to the minimum", summary: NewTrainingSummary(28, 1, 20*60, 800, 80), want: ActivityFactorMin},
+		{name: "clamped to the maximum", summary: NewTrainingSummary(7, 7, 7*90*60, 7*10000, 80), want: ActivityFactorMax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ActivityFactor(tt.summary); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ActivityFactor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKcalHelpers(t *testing.T) {
+	if got := KcalPerDayForWeeklyChange(-0.5); math.Abs(got+550) > 1e-9 {
+		t.Errorf("KcalPerDayForWeeklyChange(-0.5) = %v, want -550", got)
+	}
+
+	roundTests := []struct {
+		kcal, want float64
+	}{
+		{kcal: 1234.5, want: 1230},
+		{kcal: 1235, want: 1240},
+		{kcal: -547, want: -550},
+	}
+	for _, tt := range roundTests {
+		if got := RoundKcal(tt.kcal); got != tt.want {
+			t.Errorf("RoundKcal(%v) = %v, want %v", tt.kcal, got, tt.want)
+		}
+	}
+
+	floorTests := []struct {
+		bmr, want float64
+	}{
+		{bmr: 1100, want: EnergyMinDailyCalories},
+		{bmr: 1500, want: 1500},
+	}
+	for _, tt := range floorTests {
+		if got := CalorieFloor(tt.bmr); got != tt.want {
+			t.Errorf("CalorieFloor(%v) = %v, want %v", tt.bmr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// FetchExerciseAliases returns the aliases of the given exercises keyed by exercise id,
+// alphabetically ordered. Exercises without aliases are absent from the map.
+func FetchExerciseAliases(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	aliases := make(map[uuid.UUID][]string)
+	if len(exerciseIDs) == 0 {
+		return aliases, nil
+	}
+	query, args, err := sq.Select("exercise_id", "alias").
+		From("exercise_aliases").
+		Where(squirrel.Eq{"exercise_id": exerciseIDs}).
+		OrderBy("exercise_id", "LOWER(alias)").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise aliases query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise aliases: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			exerciseID uuid.UUID
+			alias      string
+		)
+		if err := rows.Scan(&exerciseID, &alias); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise alias: %w", err)
+		}
+		aliases[exerciseID] = append(aliases[exerciseID], alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise aliases rows error: %w", err)
+	}
+	return aliases, nil
+}
+
+// exerciseSynonymID is the id of the Typesense synonym holding an exercise's name and aliases.
+func exerciseSynonymID(exerciseID uuid.UUID) string {
+	return "exercise-" + exerciseID.String()
+}
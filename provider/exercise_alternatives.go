@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// Points a candidate earns for each property it shares with the exercise being replaced.
+// The muscle group dominates: an alternative that trains something else isn't one.
+const (
+	AlternativeMuscleGroupPoints  = 8
+	AlternativeMovementTypePoints = 4
+	AlternativeForceTypePoints    = 2
+	AlternativeBodypartPoints     = 1
+	AlternativeLoggedPoints       = 3 // The user already knows how to do it
+)
+
+// Reasons reported with an ExerciseAlternative.
+const (
+	AlternativeReasonMuscleGroup  = "same_muscle_group"
+	AlternativeReasonMovementType = "same_movement_type"
+	AlternativeReasonForceType    = "same_force_type"
+	AlternativeReasonBodypart     = "same_bodypart"
+	AlternativeReasonLogged       = "logged_before"
+)
+
+// ExerciseAlternative is a candidate replacement for an exercise with its score.
+type ExerciseAlternative struct {
+	Exercise    model.Exercise
+	Score       int
+	Reasons     []string // AlternativeReason* values explaining the score
+	TimesLogged int      // Workout logs of the user containing the candidate
+}
+
+// ScoreExerciseAlternative scores candidate as a replacement for source.
+func ScoreExerciseAlternative(source, candidate *model.Exercise, timesLogged int) (int, []string) {
+	score := 0
+	reasons := make([]string, 0, 5)
+	same := func(a, b *string) bool { return a != nil && b != nil && *a == *b }
+
+	if same(source.MuscleGroup, candidate.MuscleGroup) {
+		score += AlternativeMuscleGroupPoints
+		reasons = append(reasons, AlternativeReasonMuscleGroup)
+	}
+	if same(source.MovementType, candidate.MovementType) {
+		score += AlternativeMovementTypePoints
+		reasons = append(reasons, AlternativeReasonMovementType)
+	}
+	if same(source.ForceType, candidate.ForceType) {
+		score += AlternativeForceTypePoints
+		reasons = append(reasons, AlternativeReasonForceType)
+	}
+	if same(source.Bodypart, candidate.Bodypart) {
+		score += AlternativeBodypartPoints
+		reasons = append(reasons, AlternativeReasonBodypart)
+	}
+	if timesLogged > 0 {
+		score += AlternativeLoggedPoints
+		reasons = append(reasons, AlternativeReasonLogged)
+	}
+	return score, reasons
+}
+
+// FindExerciseAlternatives returns up to limit exercises visible to userID that could replace
+// source, best first. Candidates train the same muscle group (or, when source has none, the same
+// bodypart). They are scored and ranked in SQL with the same points as ScoreExerciseAlternative,
+// ties going to the more often logged exercise and then the name, so the best matches are never
+// cut off by a candidate cap.
+//
+// When availableEquipment is not empty, only exercises using that equipment, using bodyweight, or
+// without equipment metadata are considered. Equipment is a filter rather than a score: an
+// exercise needing equipment that isn't there can't replace anything, however well it matches.
+func FindExerciseAlternatives(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, userID uuid.UUID, source *model.Exercise, availableEquipment []string, limit int) ([]ExerciseAlternative, error) {
+	var similar squirrel.Sqlizer
+	switch {
+	case source.MuscleGroup != nil:
+		similar = squirrel.Eq{"muscle_group": *source.MuscleGroup}
+	case source.Bodypart != nil:
+		similar = squirrel.Eq{"bodypart": *source.Bodypart}
+	default:
+		// Nothing to compare against.
+		return []ExerciseAlternative{}, nil
+	}
+
+	where := squirrel.And{
+		squirrel.Eq{"exercises.deleted_at": nil},
+		squirrel.NotEq{"exercises.id": source.ID},
+		ExerciseVisibleTo("exercises.", userID),
+		similar,
+	}
+	if len(availableEquipment) > 0 {
+		equipment := availableEquipment
+		if !slices.Contains(equipment, "bodyweight") {
+			equipment = append(slices.Clone(equipment), "bodyweight")
+		}
+		where = append(where, squirrel.Or{squirrel.Eq{"equipment": nil}, squirrel.Eq{"equipment": equipment}})
+	}
+
+	// Workout logs of the user per exercise; inner queries use "?" placeholders.
+	logged := squirrel.Select("lei.exercise_id", "COUNT(DISTINCT lei.workout_log_id) AS times_logged").
+		From("logged_exercise_instances AS lei").
+		Join("workout_logs AS wl ON wl.id = lei.workout_log_id").
+		Where(squirrel.Eq{"wl.user_id": userID, "lei.deleted_at": nil, "wl.deleted_at": nil}).
+		GroupBy("lei.exercise_id")
+
+	score, scoreArgs := alternativeScoreSQL(source)
+	columns := make([]string, 0, len(ExerciseColumns)+1)
+	for _, column := range ExerciseColumns {
+		columns = append(columns, "exercises."+column)
+	}
+	columns = append(columns, "COALESCE(logged.times_logged, 0)")
+
+	query, args, err := sq.Select(columns...).
+		From("exercises").
+		JoinClause(squirrel.ConcatExpr("LEFT JOIN (", logged, ") AS logged ON logged.exercise_id = exercises.id")).
+		Where(where).
+		OrderByClause("("+score+") DESC", scoreArgs...).
+		OrderBy("COALESCE(logged.times_logged, 0) DESC", "exercises.name ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build alternatives query: %w", err)
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alternatives: %w", err)
+	}
+	defer rows.Close()
+
+	alternatives := make([]ExerciseAlternative, 0, limit)
+	for rows.Next() {
+		var timesLogged int
+		ex, err := ScanExercise(extraColumnScanner{rows, []any{&timesLogged}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alternative: %w", err)
+		}
+		score, reasons := ScoreExerciseAlternative(source, &ex, timesLogged)
+		alternatives = append(alternatives, ExerciseAlternative{
+			Exercise:    ex,
+			Score:       score,
+			Reasons:     reasons,
+			TimesLogged: timesLogged,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("alternatives rows error: %w", err)
+	}
+	return alternatives, nil
+}
+
+// alternativeScoreSQL returns the SQL equivalent of ScoreExerciseAlternative for candidates of
+// source, for a query that LEFT JOINs the user's logged counts as "logged".
+func alternativeScoreSQL(source *model.Exercise) (string, []any) {
+	score := "0"
+	var args []any
+	for _, property := range []struct {
+		column string
+		value  *string
+		points int
+	}{
+		{"exercises.muscle_group", source.MuscleGroup, AlternativeMuscleGroupPoints},
+		{"exercises.movement_type", source.MovementType, AlternativeMovementTypePoints},
+		{"exercises.force_type", source.ForceType, AlternativeForceTypePoints},
+		{"exercises.bodypart", source.Bodypart, AlternativeBodypartPoints},
+	} {
+		if property.value == nil {
+			continue
+		}
+		score += fmt.Sprintf(" + CASE WHEN %s = ? THEN %d ELSE 0 END", property.column, property.points)
+		args = append(args, *property.value)
+	}
+	score += fmt.Sprintf(" + CASE WHEN logged.times_logged > 0 THEN %d ELSE 0 END", AlternativeLoggedPoints)
+	return score, args
+}
+
+// extraColumnScanner scans rows that carry extra columns after the ones a Scan* function reads.
+type extraColumnScanner struct {
+	RowScanner
+	extra []any
+}
+
+// Scan scans dest followed by the extra destinations.
+func (s extraColumnScanner) Scan(dest ...any) error {
+	return s.RowScanner.Scan(append(dest, s.extra...)...)
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func TestScoreExerciseAlternative(t *testing.T) {
+	str := func(v string) *string { return &v }
+	source := &model.Exercise{
+		MuscleGroup:  str("chest"),
+		MovementType: str("compound"),
+		ForceType:    str("push"),
+		Bodypart:     str("upper_body"),
+	}
+
+	tests := []struct {
+		name        string
+		source      *model.Exercise
+		candidate   *model.Exercise
+		timesLogged int
+		wantScore   int
+		wantReasons []string
+	}{
+		{
+			name:        "nothing in common",
+			source:      source,
+			candidate:   &model.Exercise{MuscleGroup: str("back"), MovementType: str("isolation")},
+			wantScore:   0,
+			wantReasons: []string{},
+		},
+		{
+			name:   "everything in common",
+			source: source,
+			candidate: &model.Exercise{
+				MuscleGroup:  str("chest"),
+				MovementType: str("compound"),
+				ForceType:    str("push"),
+				Bodypart:     str("upper_body"),
+			},
+			timesLogged: 4,
+			wantScore:   AlternativeMuscleGroupPoints + AlternativeMovementTypePoints + AlternativeForceTypePoints + AlternativeBodypartPoints + AlternativeLoggedPoints,
+			wantReasons: []string{
+				AlternativeReasonMuscleGroup, AlternativeReasonMovementType, AlternativeReasonForceType,
+				AlternativeReasonBodypart, AlternativeReasonLogged,
+			},
+		},
+		{
+			name:        "muscle group and logged",
+			source:      source,
+			candidate:   &model.Exercise{MuscleGroup: str("chest"), MovementType: str("isolation")},
+			timesLogged: 1,
+			wantScore:   AlternativeMuscleGroupPoints + AlternativeLoggedPoints,
+			wantReasons: []string{AlternativeReasonMuscleGroup, AlternativeReasonLogged},
+		},
+		{
+			name:        "missing metadata never matches",
+			source:      &model.Exercise{},
+			candidate:   &model.Exercise{},
+			wantScore:   0,
+			wantReasons: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := ScoreExerciseAlternative(tt.source, tt.candidate, tt.timesLogged)
+			if score != tt.wantScore {
+				t.Errorf("ScoreExerciseAlternative() score = %d, want %d", score, tt.wantScore)
+			}
+			if !slices.Equal(reasons, tt.wantReasons) {
+				t.Errorf("ScoreExerciseAlternative() reasons = %v, want %v", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+// TestAlternativeScoreSQLAgreesWithGo checks that the ORDER BY of FindExerciseAlternatives ranks
+// candidates by the same score ScoreExerciseAlternative reports for them.
+func TestAlternativeScoreSQLAgreesWithGo(t *testing.T) {
+	str := func(v string) *string { return &v }
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	// Partial metadata: no movement type and no bodypart to match on.
+	source := &model.Exercise{ID: uuid.New(), MuscleGroup: str("chest"), ForceType: str("push")}
+
+	var gotQuery string
+	var gotArgs []driver.NamedValue
+	db := openFakeDB(t, func(query string, args []driver.NamedValue) ([]string, [][]driver.Value) {
+		gotQuery, gotArgs = query, args
+		return nil, nil
+	})
+	if _, err := FindExerciseAlternatives(context.Background(), db, sq, uuid.New(), source, nil, 5); err != nil {
+		t.Fatalf("FindExerciseAlternatives() error = %v", err)
+	}
+
+	_, orderBy, found := strings.Cut(gotQuery, " ORDER BY ")
+	if !found {
+		t.Fatalf("alternatives query %q has no ORDER BY", gotQuery)
+	}
+	placeholder := regexp.MustCompile(`\$(\d+)`)
+	wantOrderBy := "(0 + CASE WHEN exercises.muscle_group = ? THEN 8 ELSE 0 END" +
+		" + CASE WHEN exercises.force_type = ? THEN 2 ELSE 0 END" +
+		" + CASE WHEN logged.times_logged > 0 THEN 3 ELSE 0 END) DESC," +
+		" COALESCE(logged.times_logged, 0) DESC, exercises.name ASC LIMIT 5"
+	if got := placeholder.ReplaceAllString(orderBy, "?"); got != wantOrderBy {
+		t.Fatalf("ORDER BY = %q, want %q", got, wantOrderBy)
+	}
+
+	// Evaluate the CASE terms of the ORDER BY for each candidate and compare with the Go score.
+	arg := func(n string) string {
+		i, _ := strconv.Atoi(n)
+		return gotArgs[i-1].Value.(string)
+	}
+	caseTerm := regexp.MustCompile(`CASE WHEN exercises\.(\w+) = \$(\d+) THEN (\d+)`)
+	loggedTerm := regexp.MustCompile(`CASE WHEN logged\.times_logged > 0 THEN (\d+)`)
+	sqlScore := func(candidate *model.Exercise, timesLogged int) int {
+		columns := map[string]*string{
+			"muscle_group":  candidate.MuscleGroup,
+			"movement_type": candidate.MovementType,
+			"force_type":    candidate.ForceType,
+			"bodypart":      candidate.Bodypart,
+		}
+		score := 0
+		for _, m := range caseTerm.FindAllStringSubmatch(orderBy, -1) {
+			if value := columns[m[1]]; value != nil && *value == arg(m[2]) {
+				points, _ := strconv.Atoi(m[3])
+				score += points
+			}
+		}
+		if timesLogged > 0 {
+			points, _ := strconv.Atoi(loggedTerm.FindStringSubmatch(orderBy)[1])
+			score += points
+		}
+		return score
+	}
+
+	candidates := []struct {
+		exercise    *model.Exercise
+		timesLogged int
+	}{
+		{&model.Exercise{MuscleGroup: str("chest")}, 0},
+		{&model.Exercise{MuscleGroup: str("chest"), ForceType: str("push"), MovementType: str("compound"), Bodypart: str("upper_body")}, 0},
+		{&model.Exercise{MuscleGroup: str("chest"), ForceType: str("pull")}, 2},
+		{&model.Exercise{MuscleGroup: str("chest"), ForceType: str("push")}, 7},
+	}
+	for i, c := range candidates {
+		goScore, _ := ScoreExerciseAlternative(source, c.exercise, c.timesLogged)
+		if got := sqlScore(c.exercise, c.timesLogged); got != goScore {
+			t.Errorf("candidate %d: SQL score = %d, Go score = %d", i, got, goScore)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// FetchWorkoutExerciseGroups returns the exercise groups of the given workout templates keyed by
+// workout id, each with its non-deleted member workout exercises in workout order.
+func FetchWorkoutExerciseGroups(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, workoutIDs []uuid.UUID) (map[uuid.UUID][]model.ExerciseGroup, error) {
+	return fetchExerciseGroups(ctx, q, sq, "workout_exercise_groups", "workout_id", "workout_exercises",
+		"m.workout_order NULLS LAST, m.created_at", "MIN(m.workout_order)", workoutIDs)
+}
+
+// FetchLoggedExerciseGroups returns the exercise groups of the given workout logs keyed by
+// workout log id, each with its non-deleted member logged exercise instances.
+func FetchLoggedExerciseGroups(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, workoutLogIDs []uuid.UUID) (map[uuid.UUID][]model.ExerciseGroup, error) {
+	return fetchExerciseGroups(ctx, q, sq, "logged_exercise_groups", "workout_log_id", "logged_exercise_instances",
+		"m.created_at, m.id", "MIN(m.created_at)", workoutLogIDs)
+}
+
+// fetchExerciseGroups loads the groups in groupTable owned (via ownerColumn) by ownerIDs. Members
+// are the memberTable rows (aliased m) pointing at the group through group_id, sorted by memberOrder;
+// groups are sorted by the groupOrder aggregate. Groups whose members were all removed are skipped.
+func fetchExerciseGroups(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, groupTable, ownerColumn, memberTable, memberOrder, groupOrder string, ownerIDs []uuid.UUID) (map[uuid.UUID][]model.ExerciseGroup, error) {
+	groups := make(map[uuid.UUID][]model.ExerciseGroup)
+	if len(ownerIDs) == 0 {
+		return groups, nil
+	}
+	query, args, err := sq.Select(
+		"g.id", "g."+ownerColumn, "g.group_type", "g.rounds",
+		"g.rest_between_exercises_seconds", "g.rest_between_rounds_seconds", "g.created_at", "g.updated_at",
+		fmt.Sprintf("ARRAY_AGG(m.id::text ORDER BY %s)", memberOrder),
+	).
+		From(groupTable+" AS g").
+		Join(memberTable+" AS m ON m.group_id = g.id AND m.deleted_at IS NULL").
+		Where(squirrel.Eq{"g." + ownerColumn: ownerIDs}).
+		GroupBy("g.id").
+		OrderBy(groupOrder, "g.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise groups query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise groups: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var g model.ExerciseGroup
+		var memberIDs []string
+		if err := rows.Scan(
+			&g.ID, &g.OwnerID, &g.GroupType, &g.Rounds,
+			&g.RestBetweenExercisesSeconds, &g.RestBetweenRoundsSeconds, &g.CreatedAt, &g.UpdatedAt,
+			pq.Array(&memberIDs),
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise group: %w", err)
+		}
+		for _, raw := range memberIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid member id %q in exercise group %s: %w", raw, g.ID, err)
+			}
+			g.MemberIDs = append(g.MemberIDs, id)
+		}
+		groups[g.OwnerID] = append(groups[g.OwnerID], g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise groups rows error: %w", err)
+	}
+	return groups, nil
+}
@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/typesense/typesense-go/v3/typesense"
+	"github.com/typesense/typesense-go/v3/typesense/api"
+)
+
+// ExerciseIndexer writes exercise documents to Typesense.
+type ExerciseIndexer struct {
+	Client     *typesense.Client
+	Collection string
+}
+
+// NewExerciseIndexer creates an indexer for the default exercises collection.
+func NewExerciseIndexer(client *typesense.Client) *ExerciseIndexer {
+	return &ExerciseIndexer{
+		Client:     client,
+		Collection: ExercisesCollection,
+	}
+}
+
+// ExerciseDocument converts an exercise row into its Typesense document. The database is the
+// source of truth: the document is rebuilt from the row on every sync, and unset metadata is
+// simply left out. The document id is the exercise UUID so upserts and deletes address it directly.
+func ExerciseDocument(ex *model.Exercise) map[string]any {
+	doc := map[string]any{
+		"id":         ex.ID.String(),
+		"uuid":       ex.ID.String(),
+		"name":       ex.Name,
+		"owner_id":   ExerciseOwnerGlobal,
+		"created_at": ex.CreatedAt.Unix(),
+		"updated_at": ex.UpdatedAt.Unix(),
+	}
+	if ex.OwnerID != nil {
+		doc["owner_id"] = ex.OwnerID.String()
+	}
+	if ex.TrackingType != "" {
+		doc["tracking_type"] = ex.TrackingType
+	}
+	if len(ex.Aliases) > 0 {
+		doc["aliases"] = ex.Aliases
+	}
+	if ex.Instructions != nil && len(ex.Instructions.Steps) > 0 {
+		doc["instructions"] = ex.Instructions.Steps
+	}
+	for _, t := range ex.Translations {
+		doc[localizedField("name", t.Locale)] = t.Name
+		if t.Description != nil && *t.Description != "" {
+			doc[localizedField("description", t.Locale)] = *t.Description
+		}
+	}
+	optional := map[string]*string{
+		"description":   ex.Description,
+		"position":      ex.Position,
+		"force_type":    ex.ForceType,
+		"difficulty":    ex.Difficulty,
+		"movement_type": ex.MovementType,
+		"muscle_group":  ex.MuscleGroup,
+		"equipment":     ex.Equipment,
+		"bodypart":      ex.Bodypart,
+	}
+	for field, value := range optional {
+		if value != nil && *value != "" {
+			doc[field] = *value
+		}
+	}
+	return doc
+}
+
+// ExerciseColumns are the exercises columns read by ScanExercise, in scan order.
+var ExerciseColumns = []string{
+	"id", "name", "slug", "owner_id", "description", "position", "force_type", "difficulty", "movement_type",
+	"muscle_group", "equipment", "bodypart", "tracking_type", "created_at", "updated_at", "deleted_at",
+}
+
+// RowScanner is satisfied by *sql.Row and *sql.Rows.
+type RowScanner interface {
+	Scan(dest ...any) error
+}
+
+// ScanExercise scans a row selected with ExerciseColumns.
+func ScanExercise(row RowScanner) (model.Exercise, error) {
+	var (
+		ex                                                                      model.Exercise
+		ownerID                                                                 uuid.NullUUID
+		slug                                                                    sql.NullString
+		description, position, forceType, difficulty, movementType, muscleGroup sql.NullString
+		equipment, bodypart                                                     sql.NullString
+		deletedAt                                                               sql.NullTime
+	)
+	err := row.Scan(
+		&ex.ID, &ex.Name, &slug, &ownerID, &description, &position, &forceType, &difficulty, &movementType,
+		&muscleGroup, &equipment, &bodypart, &ex.TrackingType, &ex.CreatedAt, &ex.UpdatedAt, &deletedAt,
+	)
+	if err != nil {
+		return model.Exercise{}, err
+	}
+	ex.Slug = NullStringToStringPtr(slug)
+	ex.OwnerID = NullUUIDToUUIDPtr(ownerID)
+	ex.Description = NullStringToStringPtr(description)
+	ex.Position = NullStringToStringPtr(position)
+	ex.ForceType = NullStringToStringPtr(forceType)
+	ex.Difficulty = NullStringToStringPtr(difficulty)
+	ex.MovementType = NullStringToStringPtr(movementType)
+	ex.MuscleGroup = NullStringToStringPtr(muscleGroup)
+	ex.Equipment = NullStringToStringPtr(equipment)
+	ex.Bodypart = NullStringToStringPtr(bodypart)
+	ex.DeletedAt = NullTimeToTimePtr(deletedAt)
+	return ex, nil
+}
+
+// ExerciseHitFromModel converts an exercise row into a search hit.
+func ExerciseHitFromModel(ex *model.Exercise) ExerciseSearchHit {
+	return ExerciseSearchHit{
+		ID:           ex.ID,
+		Name:         ex.Name,
+		OwnerID:      ex.OwnerID,
+		Description:  StringPtrToString(ex.Description),
+		Position:     StringPtrToString(ex.Position),
+		ForceType:    StringPtrToString(ex.ForceType),
+		Difficulty:   StringPtrToString(ex.Difficulty),
+		MovementType: StringPtrToString(ex.MovementType),
+		MuscleGroup:  StringPtrToString(ex.MuscleGroup),
+		Equipment:    StringPtrToString(ex.Equipment),
+		Bodypart:     StringPtrToString(ex.Bodypart),
+		TrackingType: ex.TrackingType,
+		CreatedAt:    ex.CreatedAt,
+		UpdatedAt:    ex.UpdatedAt,
+		DeletedAt:    ex.DeletedAt,
+		Aliases:      ex.Aliases,
+	}
+}
+
+// Upsert creates or replaces the document for ex and its synonym.
+func (i *ExerciseIndexer) Upsert(ctx context.Context, ex *model.Exercise) error {
+	if _, err := i.Client.Collection(i.Collection).Documents().Upsert(ctx, ExerciseDocument(ex), &api.DocumentIndexParameters{}); err != nil {
+		return fmt.Errorf("failed to upsert exercise %s: %w", ex.ID, err)
+	}
+	return i.syncSynonym(ctx, ex)
+}
+
+// Delete removes the document for id and its synonym. A document that is already gone is not an error.
+func (i *ExerciseIndexer) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := i.Client.Collection(i.Collection).Document(id.String()).Delete(ctx); err != nil && !isTypesenseNotFound(err) {
+		return fmt.Errorf("failed to delete exercise %s: %w", id, err)
+	}
+	return i.deleteSynonym(ctx, id)
+}
+
+// syncSynonym makes the exercise name and its aliases a multi-way synonym, so searching
+// "RDL" also matches documents containing "Romanian Deadlift" and vice versa.
+func (i *ExerciseIndexer) syncSynonym(ctx context.Context, ex *model.Exercise) error {
+	if len(ex.Aliases) == 0 {
+		return i.deleteSynonym(ctx, ex.ID)
+	}
+	schema := &api.SearchSynonymSchema{Synonyms: append([]string{ex.Name}, ex.Aliases...)}
+	if _, err := i.Client.Collection(i.Collection).Synonyms().Upsert(ctx, exerciseSynonymID(ex.ID), schema); err != nil {
+		return fmt.Errorf("failed to upsert synonym for exercise %s: %w", ex.ID, err)
+	}
+	return nil
+}
+
+func (i *ExerciseIndexer) deleteSynonym(ctx context.Context, id uuid.UUID) error {
+	if _, err := i.Client.Collection(i.Collection).Synonym(exerciseSynonymID(id)).Delete(ctx); err != nil && !isTypesenseNotFound(err) {
+		return fmt.Errorf("failed to delete synonym for exercise %s: %w", id, err)
+	}
+	return nil
+}
+
+// Import upserts many exercises in one request. Per-document failures are collected
+// into the returned error; the successfully imported documents stay imported.
+func (i *ExerciseIndexer) Import(ctx context.Context, exercises []model.Exercise) error {
+	if len(exercises) == 0 {
+		return nil
+	}
+	documents := make([]any, 0, len(exercises))
+	for idx := range exercises {
+		documents = append(documents, ExerciseDocument(&exercises[idx]))
+	}
+
+	action := api.Upsert
+	results, err := i.Client.Collection(i.Collection).Documents().Import(ctx, documents, &api.ImportDocumentsParams{Action: &action})
+	if err != nil {
+		return fmt.Errorf("failed to import exercises: %w", err)
+	}
+
+	var failures []string
+	for _, res := range results {
+		if !res.Success {
+			failures = append(failures, res.Error)
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d exercises failed to import: %s", len(failures), len(exercises), strings.Join(failures, "; "))
+	}
+
+	// Synonyms have no bulk endpoint; only exercises with aliases need a request.
+	for idx := range exercises {
+		if len(exercises[idx].Aliases) == 0 {
+			continue
+		}
+		if err := i.syncSynonym(ctx, &exercises[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FetchExerciseForIndex loads an exercise row with its aliases, instructions and translations,
+// including soft-deleted ones.
+// It returns nil when the row does not exist at all.
+func FetchExerciseForIndex(ctx context.Context, db *sql.DB, id uuid.UUID) (*model.Exercise, error) {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	query, args, err := sq.Select(ExerciseColumns...).
+		From("exercises").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise query: %w", err)
+	}
+
+	ex, err := ScanExercise(db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to fetch exercise %s: %w", id, err)
+	}
+
+	aliases, err := FetchExerciseAliases(ctx, db, sq, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	ex.Aliases = aliases[id]
+
+	instructions, err := FetchExerciseInstructions(ctx, db, sq, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	ex.Instructions = instructions[id]
+
+	translations, err := FetchExerciseTranslations(ctx, db, sq, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	ex.Translations = translations[id]
+	return &ex, nil
+}
+
+// isTypesenseNotFound reports whether err is a Typesense 404.
+func isTypesenseNotFound(err error) bool {
+	var httpErr *typesense.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ExerciseMediaColumns are the exercise_media columns read by ScanExerciseMedia, in scan order.
+var ExerciseMediaColumns = []string{
+	"id", "exercise_id", "kind", "url", "storage_key", "content_type", "caption", "position", "created_at", "updated_at",
+}
+
+// ScanExerciseMedia scans a row selected with ExerciseMediaColumns.
+func ScanExerciseMedia(row RowScanner) (model.ExerciseMedia, error) {
+	var (
+		m                                model.ExerciseMedia
+		storageKey, contentType, caption sql.NullString
+	)
+	err := row.Scan(&m.ID, &m.ExerciseID, &m.Kind, &m.URL, &storageKey, &contentType, &caption, &m.Position, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		return m, err
+	}
+	m.StorageKey = NullStringToStringPtr(storageKey)
+	m.ContentType = NullStringToStringPtr(contentType)
+	m.Caption = NullStringToStringPtr(caption)
+	return m, nil
+}
+
+// FetchExerciseInstructions returns the instructions of the given exercises keyed by exercise
+// id. Exercises without instructions are absent from the map.
+func FetchExerciseInstructions(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID) (map[uuid.UUID]*model.ExerciseInstructions, error) {
+	instructions := make(map[uuid.UUID]*model.ExerciseInstructions)
+	if len(exerciseIDs) == 0 {
+		return instructions, nil
+	}
+	query, args, err := sq.Select("exercise_id", "steps", "form_cues", "common_mistakes", "created_at", "updated_at").
+		From("exercise_instructions").
+		Where(squirrel.Eq{"exercise_id": exerciseIDs}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise instructions query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise instructions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var in model.ExerciseInstructions
+		err := rows.Scan(
+			&in.ExerciseID, pq.Array(&in.Steps), pq.Array(&in.FormCues), pq.Array(&in.CommonMistakes),
+			&in.CreatedAt, &in.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise instructions: %w", err)
+		}
+		instructions[in.ExerciseID] = &in
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise instructions rows error: %w", err)
+	}
+	return instructions, nil
+}
+
+// FetchExerciseMedia returns the media of the given exercises keyed by exercise id, in display
+// order. Exercises without media are absent from the map.
+func FetchExerciseMedia(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID) (map[uuid.UUID][]model.ExerciseMedia, error) {
+	media := make(map[uuid.UUID][]model.ExerciseMedia)
+	if len(exerciseIDs) == 0 {
+		return media, nil
+	}
+	query, args, err := sq.Select(ExerciseMediaColumns...).
+		From("exercise_media").
+		Where(squirrel.Eq{"exercise_id": exerciseIDs}).
+		OrderBy("exercise_id", "position", "created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise media query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise media: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		m, err := ScanExerciseMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise media: %w", err)
+		}
+		media[m.ExerciseID] = append(media[m.ExerciseID], m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise media rows error: %w", err)
+	}
+	return media, nil
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// MuscleColumns are the muscles columns read by ScanMuscle, in scan order.
+var MuscleColumns = []string{"id", "slug", "name", "muscle_group", "created_at", "updated_at"}
+
+// ScanMuscle scans a row selected with MuscleColumns.
+func ScanMuscle(row RowScanner) (model.Muscle, error) {
+	var (
+		m           model.Muscle
+		muscleGroup sql.NullString
+	)
+	if err := row.Scan(&m.ID, &m.Slug, &m.Name, &muscleGroup, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return m, err
+	}
+	m.MuscleGroup = NullStringToStringPtr(muscleGroup)
+	return m, nil
+}
+
+// FetchExerciseMuscles returns the muscles worked by the given exercises keyed by exercise id,
+// primary muscles first and then by decreasing weight. Exercises without a mapping are absent
+// from the map.
+func FetchExerciseMuscles(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID) (map[uuid.UUID][]model.ExerciseMuscle, error) {
+	muscles := make(map[uuid.UUID][]model.ExerciseMuscle)
+	if len(exerciseIDs) == 0 {
+		return muscles, nil
+	}
+	columns := append([]string{"em.exercise_id", "em.role", "em.weight"}, prefixColumns("m.", MuscleColumns)...)
+	query, args, err := sq.Select(columns...).
+		From("exercise_muscles AS em").
+		Join("muscles AS m ON m.id = em.muscle_id").
+		Where(squirrel.Eq{"em.exercise_id": exerciseIDs}).
+		OrderBy("em.exercise_id", "em.role = 'primary' DESC", "em.weight DESC", "m.name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise muscles query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise muscles: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			em          model.ExerciseMuscle
+			muscleGroup sql.NullString
+		)
+		err := rows.Scan(
+			&em.ExerciseID, &em.Role, &em.Weight,
+			&em.Muscle.ID, &em.Muscle.Slug, &em.Muscle.Name, &muscleGroup, &em.Muscle.CreatedAt, &em.Muscle.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise muscle: %w", err)
+		}
+		em.MuscleID = em.Muscle.ID
+		em.Muscle.MuscleGroup = NullStringToStringPtr(muscleGroup)
+		muscles[em.ExerciseID] = append(muscles[em.ExerciseID], em)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise muscles rows error: %w", err)
+	}
+	return muscles, nil
+}
+
+// MuscleVolume is the training volume attributed to one muscle.
+type MuscleVolume struct {
+	Muscle model.Muscle
+	// Volume is the sum of weight × reps of the completed sets, each multiplied by the
+	// muscle's weight for the exercise.
+	Volume float64
+	// Sets counts the completed sets the same way, so a set of an exercise working the muscle
+	// secondarily at 0.5 counts as half a set.
+	Sets        float64
+	PrimarySets int // Completed sets of exercises working the muscle as a primary mover
+}
+
+// FetchMuscleVolume attributes the volume of the user's completed sets performed since the
+// given time to the muscles mapped to their exercises, highest volume first. Sets of exercises
+// without a muscle mapping are not counted.
+func FetchMuscleVolume(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, userID uuid.UUID, since time.Time) ([]MuscleVolume, error) {
+	columns := append([]string{
+		"COALESCE(SUM(em.weight * COALESCE(es.weight, 0) * COALESCE(es.reps, 0)), 0)",
+		"COALESCE(SUM(em.weight), 0)",
+		"COUNT(*) FILTER (WHERE em.role = 'primary')",
+	}, prefixColumns("m.", MuscleColumns)...)
+	query, args, err := sq.Select(columns...).
+		From("exercise_sets AS es").
+		Join("workout_logs AS wl ON wl.id = es.workout_log_id").
+		Join("exercise_muscles AS em ON em.exercise_id = es.exercise_id").
+		Join("muscles AS m ON m.id = em.muscle_id").
+		Where(squirrel.Eq{
+			"es.status":     model.ExerciseSetStatusCompleted,
+			"wl.user_id":    userID,
+			"es.deleted_at": nil,
+			"wl.deleted_at": nil,
+		}).
+		Where(squirrel.GtOrEq{"COALESCE(es.finished_at, wl.finished_at, wl.started_at, es.created_at)": since}).
+		GroupBy(prefixColumns("m.", MuscleColumns)...).
+		OrderBy("1 DESC", "m.name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build muscle volume query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query muscle volume: %w", err)
+	}
+	defer rows.Close()
+
+	volumes := make([]MuscleVolume, 0)
+	for rows.Next() {
+		var (
+			v           MuscleVolume
+			muscleGroup sql.NullString
+		)
+		err := rows.Scan(
+			&v.Volume, &v.Sets, &v.PrimarySets,
+			&v.Muscle.ID, &v.Muscle.Slug, &v.Muscle.Name, &muscleGroup, &v.Muscle.CreatedAt, &v.Muscle.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan muscle volume: %w", err)
+		}
+		v.Muscle.MuscleGroup = NullStringToStringPtr(muscleGroup)
+		volumes = append(volumes, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("muscle volume rows error: %w", err)
+	}
+	return volumes, nil
+}
+
+func prefixColumns(prefix string, columns []string) []string {
+	prefixed := make([]string, 0, len(columns))
+	for _, column := range columns {
+		prefixed = append(prefixed, prefix+column)
+	}
+	return prefixed
+}
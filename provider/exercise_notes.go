@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ExerciseNoteColumns are the exercise_notes columns read by ScanExerciseNote, in scan order.
+var ExerciseNoteColumns = []string{"id", "user_id", "exercise_id", "note", "pinned_cues", "created_at", "updated_at"}
+
+// ScanExerciseNote scans a row selected with ExerciseNoteColumns.
+func ScanExerciseNote(row RowScanner) (model.ExerciseNote, error) {
+	var n model.ExerciseNote
+	err := row.Scan(&n.ID, &n.UserID, &n.ExerciseID, &n.Note, pq.Array(&n.PinnedCues), &n.CreatedAt, &n.UpdatedAt)
+	return n, err
+}
+
+// FetchExerciseNotes returns the notes of userID on the given exercises keyed by exercise id.
+// Exercises without a note are absent from the map.
+func FetchExerciseNotes(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]*model.ExerciseNote, error) {
+	notes := make(map[uuid.UUID]*model.ExerciseNote)
+	if len(exerciseIDs) == 0 {
+		return notes, nil
+	}
+	query, args, err := sq.Select(ExerciseNoteColumns...).
+		From("exercise_notes").
+		Where(squirrel.Eq{"user_id": userID, "exercise_id": exerciseIDs}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise notes query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise notes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		n, err := ScanExerciseNote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise note: %w", err)
+		}
+		notes[n.ExerciseID] = &n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise notes rows error: %w", err)
+	}
+	return notes, nil
+}
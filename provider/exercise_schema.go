@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"rtglabs-go/model"
+
+	"github.com/typesense/typesense-go/v3/typesense/api"
+	"github.com/typesense/typesense-go/v3/typesense/api/pointer"
+)
+
+// The exercises collection is addressed through a Typesense alias (ExercisesCollection) that
+// points at a timestamped collection such as "exercises_1752998400". Schema changes that
+// Typesense can't apply in place are rolled out by building a new collection, importing every
+// exercise into it and then swapping the alias, so searches never hit an empty index.
+
+// ErrExerciseSchemaDrift is returned by ValidateCollection when the live collection doesn't
+// match ExercisesCollectionSchema.
+var ErrExerciseSchemaDrift = errors.New("exercises collection schema drift")
+
+// ExercisesCollectionSchema returns the Typesense schema for the exercise documents
+// produced by ExerciseDocument.
+func ExercisesCollectionSchema(name string) *api.CollectionSchema {
+	optionalString := func(field string, facet bool) api.Field {
+		return api.Field{Name: field, Type: "string", Optional: pointer.True(), Facet: pointer.Any(facet)}
+	}
+	schema := &api.CollectionSchema{
+		Name: name,
+		Fields: []api.Field{
+			{Name: "uuid", Type: "string"},
+			{Name: "name", Type: "string", Sort: pointer.True()},
+			{Name: "owner_id", Type: "string", Optional: pointer.True()}, // ExerciseOwnerGlobal or the owner's UUID
+			{Name: "aliases", Type: "string[]", Optional: pointer.True()},
+			{Name: "instructions", Type: "string[]", Optional: pointer.True()}, // The steps of ExerciseInstructions
+			optionalString("description", false),
+			optionalString("position", true),
+			optionalString("force_type", true),
+			optionalString("difficulty", true),
+			optionalString("movement_type", true),
+			optionalString("muscle_group", true),
+			optionalString("equipment", true),
+			optionalString("bodypart", true),
+			optionalString("tracking_type", true),
+			{Name: "created_at", Type: "int64"},
+			{Name: "updated_at", Type: "int64"},
+		},
+		DefaultSortingField: pointer.String("created_at"),
+	}
+	// Translated texts, e.g. "name_es", so searches match in the user's language.
+	for _, locale := range model.SupportedLocales {
+		if locale != model.DefaultLocale {
+			schema.Fields = append(schema.Fields,
+				optionalString(localizedField("name", locale), false),
+				optionalString(localizedField("description", locale), false),
+			)
+		}
+	}
+	return schema
+}
+
+// localizedField returns the document field holding the translation of field to locale,
+// e.g. "name_es". The default locale uses field itself.
+func localizedField(field, locale string) string {
+	if locale == "" || locale == model.DefaultLocale {
+		return field
+	}
+	return field + "_" + locale
+}
+
+// ResolveCollection returns the collection currently serving i.Collection. aliased is false
+// for a collection created under that name before aliases were used; name is empty when
+// neither an alias nor a collection exists.
+func (i *ExerciseIndexer) ResolveCollection(ctx context.Context) (name string, aliased bool, err error) {
+	alias, err := i.Client.Alias(i.Collection).Retrieve(ctx)
+	if err == nil {
+		return alias.CollectionName, true, nil
+	}
+	if !isTypesenseNotFound(err) {
+		return "", false, fmt.Errorf("failed to retrieve alias %q: %w", i.Collection, err)
+	}
+	if _, err := i.Client.Collection(i.Collection).Retrieve(ctx); err != nil {
+		if isTypesenseNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to retrieve collection %q: %w", i.Collection, err)
+	}
+	return i.Collection, false, nil
+}
+
+// EnsureCollection creates the collection and its alias when they do not exist yet, and adds
+// the fields of ExercisesCollectionSchema that an existing collection is missing. Changes to
+// existing fields are left to MigrateCollection; ValidateCollection reports them.
+func (i *ExerciseIndexer) EnsureCollection(ctx context.Context) error {
+	current, _, err := i.ResolveCollection(ctx)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		name, err := i.CreateCollection(ctx)
+		if err != nil {
+			return err
+		}
+		_, err = i.SwapAlias(ctx, name)
+		return err
+	}
+
+	existing, err := i.Client.Collection(current).Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve collection %q: %w", current, err)
+	}
+	known := make(map[string]bool, len(existing.Fields))
+	for _, field := range existing.Fields {
+		known[field.Name] = true
+	}
+	var missing []api.Field
+	for _, field := range ExercisesCollectionSchema(current).Fields {
+		if !known[field.Name] {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	// Existing documents only get the new fields indexed once they are upserted again.
+	if _, err := i.Client.Collection(current).Update(ctx, &api.CollectionUpdateSchema{Fields: missing}); err != nil {
+		return fmt.Errorf("failed to add fields to collection %q: %w", current, err)
+	}
+	return nil
+}
+
+// CreateCollection creates an empty timestamped collection with ExercisesCollectionSchema and
+// returns its name. The alias is left alone; call SwapAlias once the collection is filled.
+func (i *ExerciseIndexer) CreateCollection(ctx context.Context) (string, error) {
+	name := fmt.Sprintf("%s_%d", i.Collection, time.Now().Unix())
+	if _, err := i.Client.Collections().Create(ctx, ExercisesCollectionSchema(name)); err != nil {
+		return "", fmt.Errorf("failed to create collection %q: %w", name, err)
+	}
+	return name, nil
+}
+
+// SwapAlias points the i.Collection alias at name and returns the collection it pointed at
+// before, or "" when there was none. Typesense doesn't allow an alias with the name of a
+// collection, so a collection created under the alias name before aliases were used is
+// dropped first; searches fail for the moment between the two calls, once.
+func (i *ExerciseIndexer) SwapAlias(ctx context.Context, name string) (string, error) {
+	previous, aliased, err := i.ResolveCollection(ctx)
+	if err != nil {
+		return "", err
+	}
+	if previous != "" && !aliased {
+		if err := i.DropCollection(ctx, previous); err != nil {
+			return "", err
+		}
+		previous = ""
+	}
+	if _, err := i.Client.Aliases().Upsert(ctx, i.Collection, &api.CollectionAliasSchema{CollectionName: name}); err != nil {
+		return "", fmt.Errorf("failed to point alias %q at %q: %w", i.Collection, name, err)
+	}
+	return previous, nil
+}
+
+// DropCollection deletes the named collection and every document in it. A missing collection is not an error.
+func (i *ExerciseIndexer) DropCollection(ctx context.Context, name string) error {
+	if _, err := i.Client.Collection(name).Delete(ctx); err != nil && !isTypesenseNotFound(err) {
+		return fmt.Errorf("failed to drop collection %q: %w", name, err)
+	}
+	return nil
+}
+
+// SchemaDrift lists the differences between the collection behind i.Collection and
+// ExercisesCollectionSchema, e.g. `field "equipment": facet is false, expected true`.
+// Extra fields in the live collection are ignored.
+func (i *ExerciseIndexer) SchemaDrift(ctx context.Context) ([]string, error) {
+	current, _, err := i.ResolveCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current == "" {
+		return []string{fmt.Sprintf("collection %q does not exist", i.Collection)}, nil
+	}
+	live, err := i.Client.Collection(current).Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve collection %q: %w", current, err)
+	}
+
+	expected := ExercisesCollectionSchema(current)
+	var drift []string
+	if got, want := pointerValue(live.DefaultSortingField, ""), pointerValue(expected.DefaultSortingField, ""); got != want {
+		drift = append(drift, fmt.Sprintf("default sorting field is %q, expected %q", got, want))
+	}
+	liveFields := make(map[string]api.Field, len(live.Fields))
+	for _, field := range live.Fields {
+		liveFields[field.Name] = field
+	}
+	for _, want := range expected.Fields {
+		got, ok := liveFields[want.Name]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("field %q is missing", want.Name))
+			continue
+		}
+		if got.Type != want.Type {
+			drift = append(drift, fmt.Sprintf("field %q: type is %s, expected %s", want.Name, got.Type, want.Type))
+		}
+		flags := []struct {
+			name      string
+			got, want *bool
+		}{
+			{"facet", got.Facet, want.Facet},
+			{"optional", got.Optional, want.Optional},
+		}
+		// Sortability defaults differ per type, so it is only checked where the schema sets it.
+		if want.Sort != nil {
+			flags = append(flags, struct {
+				name      string
+				got, want *bool
+			}{"sort", got.Sort, want.Sort})
+		}
+		for _, flag := range flags {
+			if g, w := pointerValue(flag.got, false), pointerValue(flag.want, false); g != w {
+				drift = append(drift, fmt.Sprintf("field %q: %s is %t, expected %t", want.Name, flag.name, g, w))
+			}
+		}
+	}
+	return drift, nil
+}
+
+// ValidateCollection returns an error wrapping ErrExerciseSchemaDrift when the live collection
+// doesn't match ExercisesCollectionSchema. Connection errors are returned as they are.
+func (i *ExerciseIndexer) ValidateCollection(ctx context.Context) error {
+	drift, err := i.SchemaDrift(ctx)
+	if err != nil {
+		return err
+	}
+	if len(drift) > 0 {
+		return fmt.Errorf("%w: %s", ErrExerciseSchemaDrift, strings.Join(drift, "; "))
+	}
+	return nil
+}
+
+// pointerValue returns *p, or def when p is nil.
+func pointerValue[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
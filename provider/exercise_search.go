@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Values accepted by EXERCISE_SEARCH_BACKEND.
+const (
+	ExerciseSearchBackendAuto      = "auto"      // Typesense, failing over to Postgres while it is unhealthy
+	ExerciseSearchBackendTypesense = "typesense" // Typesense only
+	ExerciseSearchBackendPostgres  = "postgres"  // Postgres only (pg_trgm + full-text search)
+)
+
+// ExerciseFilterFields are the exercise columns that can be filtered on. Each one is returned
+// as a facet with the number of matching exercises per value.
+var ExerciseFilterFields = []string{"muscle_group", "equipment", "bodypart", "difficulty", "force_type", "tracking_type"}
+
+// Tags wrapped around the matched terms in ExerciseSearchHit.Highlights.
+const (
+	ExerciseHighlightStartTag = "<mark>"
+	ExerciseHighlightEndTag   = "</mark>"
+)
+
+// ExerciseSearchQuery describes one page of an exercise search.
+type ExerciseSearchQuery struct {
+	Q       string    // Free text; empty lists every exercise, newest first
+	UserID  uuid.UUID // Custom exercises of this user are included alongside the global catalog
+	Page    int       // 1-based
+	PerPage int
+	// Filters maps a field of ExerciseFilterFields to the accepted values. Values of one field
+	// are OR'ed, different fields are AND'ed.
+	Filters map[string][]string
+	// Locale is one of model.SupportedLocales. Translated names are matched too, and hits
+	// carry the translated name and description when there is one.
+	Locale string
+}
+
+// ExerciseSearchHit is a single exercise returned by a search backend.
+type ExerciseSearchHit struct {
+	ID           uuid.UUID
+	Name         string
+	OwnerID      *uuid.UUID // nil for the global catalog
+	Description  string
+	Position     string
+	ForceType    string
+	Difficulty   string
+	MovementType string
+	MuscleGroup  string
+	Equipment    string
+	Bodypart     string
+	TrackingType string // One of model.ExerciseTrackingTypes
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time
+	Aliases      []string
+	// MatchedAlias is the alias that matched Q when the name itself didn't, e.g. "RDL".
+	MatchedAlias string
+	// Highlights maps name and/or description to their text with the matched terms wrapped in
+	// ExerciseHighlightStartTag/EndTag. Only fields that matched Q are present.
+	Highlights map[string]string
+}
+
+// ExerciseFacetCount is the number of matching exercises with a given facet value.
+type ExerciseFacetCount struct {
+	Value string
+	Count int
+}
+
+// ExerciseFacet holds the value counts of one of the ExerciseFilterFields, most frequent first.
+type ExerciseFacet struct {
+	Field  string
+	Counts []ExerciseFacetCount
+}
+
+// ExerciseSearchResult is one page of hits plus the total number of matches.
+type ExerciseSearchResult struct {
+	Hits    []ExerciseSearchHit
+	Found   int
+	Facets  []ExerciseFacet // One entry per ExerciseFilterFields, in that order
+	Backend string          // Name of the backend that served the request
+}
+
+// ExerciseSearcher searches the exercise catalog.
+type ExerciseSearcher interface {
+	Search(ctx context.Context, q ExerciseSearchQuery) (*ExerciseSearchResult, error)
+	// Backend returns the backend name, e.g. "typesense" or "postgres".
+	Backend() string
+}
+
+// SearchHealthChecker is implemented by searchers that can report whether they are reachable.
+type SearchHealthChecker interface {
+	Healthy(ctx context.Context) bool
+}
+
+// NormalizeExerciseSearchBackend trims and lowercases an EXERCISE_SEARCH_BACKEND value so it can
+// be compared with the ExerciseSearchBackend* constants. An empty value means ExerciseSearchBackendAuto.
+func NormalizeExerciseSearchBackend(backend string) string {
+	backend = strings.ToLower(strings.TrimSpace(backend))
+	if backend == "" {
+		return ExerciseSearchBackendAuto
+	}
+	return backend
+}
+
+// NewExerciseSearcher builds the searcher selected by backend (see the ExerciseSearchBackend* constants).
+// An empty backend means ExerciseSearchBackendAuto.
+func NewExerciseSearcher(backend string, db *sql.DB, ts *TypesenseClient) (ExerciseSearcher, error) {
+	typesenseSearcher := func() ExerciseSearcher {
+		searcher := NewTypesenseExerciseSearcher(ts.Client)
+		searcher.Breaker = ts.SearchBreaker
+		return instrumentedExerciseSearcher{searcher}
+	}
+	postgresSearcher := instrumentedExerciseSearcher{NewPostgresExerciseSearcher(db)}
+
+	switch NormalizeExerciseSearchBackend(backend) {
+	case ExerciseSearchBackendAuto:
+		return NewFailoverExerciseSearcher(typesenseSearcher(), postgresSearcher, ts.Config.HealthCheckInterval), nil
+	case ExerciseSearchBackendTypesense:
+		return typesenseSearcher(), nil
+	case ExerciseSearchBackendPostgres:
+		return postgresSearcher, nil
+	}
+	return nil, fmt.Errorf("unknown exercise search backend %q (expected auto, typesense or postgres)", backend)
+}
+
+// FailoverExerciseSearcher serves searches from Primary and switches to Fallback while the
+// primary's health check fails or one of its searches fails to reach it (transport errors, 5xx
+// responses, an open circuit breaker). Health results are cached for HealthCheckInterval so a
+// dead primary doesn't add a timeout to every request.
+type FailoverExerciseSearcher struct {
+	Primary             ExerciseSearcher
+	Fallback            ExerciseSearcher
+	HealthCheckInterval time.Duration
+
+	mu        sync.Mutex
+	healthy   bool
+	checkedAt time.Time
+}
+
+// NewFailoverExerciseSearcher creates a FailoverExerciseSearcher.
+func NewFailoverExerciseSearcher(primary, fallback ExerciseSearcher, healthCheckInterval time.Duration) *FailoverExerciseSearcher {
+	return &FailoverExerciseSearcher{
+		Primary:             primary,
+		Fallback:            fallback,
+		HealthCheckInterval: healthCheckInterval,
+	}
+}
+
+// Backend reports the backend that would currently serve a search.
+func (s *FailoverExerciseSearcher) Backend() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.healthy {
+		return s.Primary.Backend()
+	}
+	return s.Fallback.Backend()
+}
+
+// Search tries the primary when it is believed healthy and falls back otherwise.
+func (s *FailoverExerciseSearcher) Search(ctx context.Context, q ExerciseSearchQuery) (*ExerciseSearchResult, error) {
+	if s.primaryHealthy(ctx) {
+		res, err := s.Primary.Search(ctx, q)
+		if err == nil {
+			return res, nil
+		}
+		if !primaryUnavailable(ctx, err) {
+			return nil, err
+		}
+		log.Printf("WARN: %s exercise search failed, falling back to %s: %v", s.Primary.Backend(), s.Fallback.Backend(), err)
+		exerciseSearchFallbacks.Inc()
+		s.setHealthy(false)
+	}
+	return s.Fallback.Search(ctx, q)
+}
+
+// primaryUnavailable reports whether a failed primary search says the primary is down. A request
+// the caller gave up on, or one Typesense rejected as invalid, would fail on the fallback too and
+// says nothing about the primary's health.
+func primaryUnavailable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	return !isTypesenseRequestError(err)
+}
+
+// primaryHealthy returns the cached health of the primary, re-checking it once the cache expired.
+func (s *FailoverExerciseSearcher) primaryHealthy(ctx context.Context) bool {
+	s.mu.Lock()
+	if !s.checkedAt.IsZero() && time.Since(s.checkedAt) < s.HealthCheckInterval {
+		healthy := s.healthy
+		s.mu.Unlock()
+		return healthy
+	}
+	s.mu.Unlock()
+
+	healthy := true
+	if checker, ok := s.Primary.(SearchHealthChecker); ok {
+		healthy = checker.Healthy(ctx)
+	}
+
+	s.mu.Lock()
+	if s.healthy != healthy && !s.checkedAt.IsZero() {
+		log.Printf("INFO: %s exercise search health changed: healthy=%t", s.Primary.Backend(), healthy)
+	}
+	s.mu.Unlock()
+	s.setHealthy(healthy)
+	return healthy
+}
+
+func (s *FailoverExerciseSearcher) setHealthy(healthy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.healthy = healthy
+	s.checkedAt = time.Now()
+}
@@ -0,0 +1,320 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// postgresTrigramThreshold is the minimum pg_trgm similarity for a fuzzy name match.
+const postgresTrigramThreshold = 0.3
+
+// postgresAliasMatch matches an alias (table alias "a") by substring or trigram similarity.
+// Arguments: the escaped LIKE pattern, the query and postgresTrigramThreshold.
+const postgresAliasMatch = `a.alias ILIKE ? ESCAPE '\' OR similarity(a.alias, ?) >= ?`
+
+// postgresTranslationMatch matches a translated name (table alias "t") the same way.
+const postgresTranslationMatch = `t.name ILIKE ? ESCAPE '\' OR similarity(t.name, ?) >= ?`
+
+// likeEscaper escapes the LIKE wildcards in user input.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// PostgresExerciseSearcher searches the exercises table directly. It combines a substring
+// match, pg_trgm similarity (typo tolerance) and full-text search, ranked by similarity.
+// It needs the pg_trgm extension and the indexes from the exercise search migration.
+type PostgresExerciseSearcher struct {
+	DB *sql.DB
+	sq squirrel.StatementBuilderType
+}
+
+// NewPostgresExerciseSearcher creates a PostgresExerciseSearcher.
+func NewPostgresExerciseSearcher(db *sql.DB) *PostgresExerciseSearcher {
+	return &PostgresExerciseSearcher{
+		DB: db,
+		sq: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Backend implements ExerciseSearcher.
+func (s *PostgresExerciseSearcher) Backend() string {
+	return ExerciseSearchBackendPostgres
+}
+
+// Healthy implements SearchHealthChecker.
+func (s *PostgresExerciseSearcher) Healthy(ctx context.Context) bool {
+	return s.DB.PingContext(ctx) == nil
+}
+
+// Search implements ExerciseSearcher.
+func (s *PostgresExerciseSearcher) Search(ctx context.Context, q ExerciseSearchQuery) (*ExerciseSearchResult, error) {
+	where := squirrel.And{squirrel.Eq{"deleted_at": nil}, ExerciseVisibleTo("", q.UserID)}
+	localized := q.Locale != "" && q.Locale != model.DefaultLocale
+	if q.Q != "" {
+		match := squirrel.Or{
+			squirrel.Expr(`name ILIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(q.Q)+"%"),
+			squirrel.Expr("similarity(name, ?) >= ?", q.Q, postgresTrigramThreshold),
+			squirrel.Expr("to_tsvector('simple', name || ' ' || COALESCE(description, '')) @@ plainto_tsquery('simple', ?)", q.Q),
+			squirrel.Expr("EXISTS (SELECT 1 FROM exercise_aliases a WHERE a.exercise_id = exercises.id AND ("+postgresAliasMatch+"))",
+				"%"+likeEscaper.Replace(q.Q)+"%", q.Q, postgresTrigramThreshold),
+		}
+		if localized {
+			match = append(match, squirrel.Expr("EXISTS (SELECT 1 FROM exercise_translations t WHERE t.exercise_id = exercises.id AND t.locale = ? AND ("+postgresTranslationMatch+"))",
+				q.Locale, "%"+likeEscaper.Replace(q.Q)+"%", q.Q, postgresTrigramThreshold))
+		}
+		where = append(where, match)
+	}
+	for _, field := range ExerciseFilterFields {
+		if values := q.Filters[field]; len(values) > 0 {
+			where = append(where, squirrel.Eq{field: values})
+		}
+	}
+
+	countQuery, countArgs, err := s.sq.Select("COUNT(*)").From("exercises").Where(where).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise count query: %w", err)
+	}
+	var found int
+	if err := s.DB.QueryRowContext(ctx, countQuery, countArgs...).Scan(&found); err != nil {
+		return nil, fmt.Errorf("failed to count exercises: %w", err)
+	}
+
+	builder := s.sq.Select(ExerciseColumns...).
+		From("exercises").
+		Where(where).
+		Limit(uint64(q.PerPage)).
+		Offset(uint64((q.Page - 1) * q.PerPage))
+	if q.Q != "" {
+		// An exercise found through an alias ("RDL") ranks by how well that alias matches.
+		if localized {
+			builder = builder.OrderByClause(
+				"GREATEST(similarity(name, ?), COALESCE((SELECT MAX(similarity(a.alias, ?)) FROM exercise_aliases a WHERE a.exercise_id = exercises.id), 0), COALESCE((SELECT similarity(t.name, ?) FROM exercise_translations t WHERE t.exercise_id = exercises.id AND t.locale = ?), 0)) DESC, name ASC",
+				q.Q, q.Q, q.Q, q.Locale,
+			)
+		} else {
+			builder = builder.OrderByClause(
+				"GREATEST(similarity(name, ?), COALESCE((SELECT MAX(similarity(a.alias, ?)) FROM exercise_aliases a WHERE a.exercise_id = exercises.id), 0)) DESC, name ASC",
+				q.Q, q.Q,
+			)
+		}
+	} else {
+		builder = builder.OrderBy("created_at DESC")
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise search query: %w", err)
+	}
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search exercises: %w", err)
+	}
+	defer rows.Close()
+
+	result := &ExerciseSearchResult{
+		Hits:    make([]ExerciseSearchHit, 0, q.PerPage),
+		Found:   found,
+		Backend: s.Backend(),
+	}
+	var exercises []model.Exercise
+	for rows.Next() {
+		ex, err := ScanExercise(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise: %w", err)
+		}
+		exercises = append(exercises, ex)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise rows error: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(exercises))
+	for _, ex := range exercises {
+		ids = append(ids, ex.ID)
+	}
+	translations, err := FetchLocalizedExercises(ctx, s.DB, s.sq, ids, q.Locale)
+	if err != nil {
+		return nil, err
+	}
+	highlighter := newPostgresHighlighter(q.Q)
+	for idx := range exercises {
+		exercises[idx].Localize(translations[exercises[idx].ID])
+		hit := ExerciseHitFromModel(&exercises[idx])
+		hit.Highlights = highlighter.highlight(map[string]string{"name": hit.Name, "description": hit.Description})
+		result.Hits = append(result.Hits, hit)
+	}
+
+	if err := s.attachAliases(ctx, q.Q, result.Hits); err != nil {
+		return nil, err
+	}
+
+	result.Facets, err = s.facets(ctx, where)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// attachAliases loads the aliases of the hits and, for hits whose name didn't match q,
+// the best matching alias.
+func (s *PostgresExerciseSearcher) attachAliases(ctx context.Context, q string, hits []ExerciseSearchHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+
+	builder := s.sq.Select("a.exercise_id", "a.alias").
+		From("exercise_aliases AS a").
+		Where(squirrel.Eq{"a.exercise_id": ids}).
+		OrderBy("a.exercise_id", "LOWER(a.alias)")
+	if q != "" {
+		builder = builder.
+			Column(squirrel.Alias(squirrel.Expr("("+postgresAliasMatch+")", "%"+likeEscaper.Replace(q)+"%", q, postgresTrigramThreshold), "matched")).
+			Column(squirrel.Alias(squirrel.Expr("similarity(a.alias, ?)", q), "score"))
+	} else {
+		builder = builder.Columns("FALSE AS matched", "0::real AS score")
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build exercise aliases query: %w", err)
+	}
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query exercise aliases: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[uuid.UUID]*ExerciseSearchHit, len(hits))
+	for i := range hits {
+		byID[hits[i].ID] = &hits[i]
+	}
+	bestScore := make(map[uuid.UUID]float64, len(hits))
+	for rows.Next() {
+		var (
+			exerciseID uuid.UUID
+			alias      string
+			matched    bool
+			score      float64
+		)
+		if err := rows.Scan(&exerciseID, &alias, &matched, &score); err != nil {
+			return fmt.Errorf("failed to scan exercise alias: %w", err)
+		}
+		hit := byID[exerciseID]
+		if hit == nil {
+			continue
+		}
+		hit.Aliases = append(hit.Aliases, alias)
+		if !matched || hit.Highlights["name"] != "" {
+			continue
+		}
+		if best, ok := bestScore[exerciseID]; !ok || score > best {
+			bestScore[exerciseID] = score
+			hit.MatchedAlias = alias
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("exercise aliases rows error: %w", err)
+	}
+	return nil
+}
+
+// facets counts the values of every ExerciseFilterFields column among the exercises matching
+// where, in a single UNION ALL query.
+func (s *PostgresExerciseSearcher) facets(ctx context.Context, where squirrel.Sqlizer) ([]ExerciseFacet, error) {
+	parts := make([]string, 0, len(ExerciseFilterFields))
+	var args []any
+	for _, field := range ExerciseFilterFields {
+		// Question placeholders are renumbered once the parts are joined.
+		part, partArgs, err := squirrel.Select(fmt.Sprintf("'%s' AS field", field), field+" AS value", "COUNT(*)").
+			From("exercises").
+			Where(where).
+			Where(squirrel.NotEq{field: nil}).
+			GroupBy(field).
+			ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build %s facet query: %w", field, err)
+		}
+		parts = append(parts, "("+part+")")
+		args = append(args, partArgs...)
+	}
+	query, err := squirrel.Dollar.ReplacePlaceholders(strings.Join(parts, " UNION ALL ") + " ORDER BY 1, 3 DESC, 2")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build facet query: %w", err)
+	}
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count exercise facets: %w", err)
+	}
+	defer rows.Close()
+
+	byField := map[string][]ExerciseFacetCount{}
+	for rows.Next() {
+		var field string
+		var count ExerciseFacetCount
+		if err := rows.Scan(&field, &count.Value, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise facet: %w", err)
+		}
+		byField[field] = append(byField[field], count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise facet rows error: %w", err)
+	}
+
+	facets := make([]ExerciseFacet, 0, len(ExerciseFilterFields))
+	for _, field := range ExerciseFilterFields {
+		counts := byField[field]
+		if counts == nil {
+			counts = []ExerciseFacetCount{}
+		}
+		facets = append(facets, ExerciseFacet{Field: field, Counts: counts})
+	}
+	return facets, nil
+}
+
+// postgresHighlighter wraps the words of a query in the highlight tags, mimicking the
+// Typesense highlights. A nil highlighter (empty query) highlights nothing.
+type postgresHighlighter struct {
+	re *regexp.Regexp
+}
+
+func newPostgresHighlighter(q string) *postgresHighlighter {
+	words := strings.Fields(q)
+	if len(words) == 0 {
+		return nil
+	}
+	// Longest first, so "bench" doesn't stop "benchpress" from matching as a whole.
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return &postgresHighlighter{re: regexp.MustCompile("(?i)" + strings.Join(words, "|"))}
+}
+
+// highlight returns the fields that contain a query word, with the matches wrapped in tags.
+func (h *postgresHighlighter) highlight(fields map[string]string) map[string]string {
+	if h == nil {
+		return nil
+	}
+	var result map[string]string
+	for field, text := range fields {
+		if !h.re.MatchString(text) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string, len(fields))
+		}
+		result[field] = h.re.ReplaceAllString(text, ExerciseHighlightStartTag+"$0"+ExerciseHighlightEndTag)
+	}
+	return result
+}
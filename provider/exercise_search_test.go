@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sony/gobreaker"
+	"github.com/typesense/typesense-go/v3/typesense"
+)
+
+// stubExerciseSearcher returns err from every search and counts the calls.
+type stubExerciseSearcher struct {
+	backend string
+	err     error
+	calls   int
+}
+
+func (s *stubExerciseSearcher) Search(context.Context, ExerciseSearchQuery) (*ExerciseSearchResult, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ExerciseSearchResult{Backend: s.backend}, nil
+}
+
+func (s *stubExerciseSearcher) Backend() string { return s.backend }
+
+func TestFailoverExerciseSearcher(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		primaryErr   error
+		wantBackend  string // Backend of the result, "" when the error is returned
+		wantFailover bool   // Whether later searches go to the fallback
+	}{
+		{name: "primary succeeds", ctx: context.Background(), wantBackend: "typesense"},
+		{name: "server error", ctx: context.Background(), primaryErr: fmt.Errorf("typesense search failed: %w", &typesense.HTTPError{Status: 503}), wantBackend: "postgres", wantFailover: true},
+		{name: "transport error", ctx: context.Background(), primaryErr: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, wantBackend: "postgres", wantFailover: true},
+		{name: "breaker open", ctx: context.Background(), primaryErr: gobreaker.ErrOpenState, wantBackend: "postgres", wantFailover: true},
+		{name: "invalid request", ctx: context.Background(), primaryErr: fmt.Errorf("typesense search failed: %w", &typesense.HTTPError{Status: 400}), wantBackend: ""},
+		{name: "cancelled request", ctx: cancelled, primaryErr: context.Canceled, wantBackend: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &stubExerciseSearcher{backend: "typesense", err: tt.primaryErr}
+			fallback := &stubExerciseSearcher{backend: "postgres"}
+			s := NewFailoverExerciseSearcher(primary, fallback, time.Hour)
+
+			res, err := s.Search(tt.ctx, ExerciseSearchQuery{})
+			if tt.wantBackend == "" {
+				if !errors.Is(err, tt.primaryErr) {
+					t.Errorf("Search() error = %v, want %v", err, tt.primaryErr)
+				}
+				if fallback.calls != 0 {
+					t.Error("Search() fell back for an error the fallback would repeat")
+				}
+			} else if err != nil || res.Backend != tt.wantBackend {
+				t.Errorf("Search() = %+v, %v, want a %s result", res, err, tt.wantBackend)
+			}
+
+			wantBackend := "typesense"
+			if tt.wantFailover {
+				wantBackend = "postgres"
+			}
+			if got := s.Backend(); got != wantBackend {
+				t.Errorf("Backend() after the search = %s, want %s", got, wantBackend)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"rtglabs-go/model"
+
+	"github.com/google/uuid"
+	"github.com/sony/gobreaker"
+	"github.com/typesense/typesense-go/v3/typesense"
+	"github.com/typesense/typesense-go/v3/typesense/api"
+	"github.com/typesense/typesense-go/v3/typesense/api/pointer"
+)
+
+// ExercisesCollection is the Typesense collection holding the exercise documents.
+const ExercisesCollection = "exercises"
+
+// ExerciseOwnerGlobal is the owner_id stored in the documents of global catalog exercises.
+const ExerciseOwnerGlobal = "global"
+
+// typesenseHealthTimeout bounds the health check so a dead node fails fast.
+const typesenseHealthTimeout = 2 * time.Second
+
+// typesenseMaxFacetValues is above the size of every vocabulary, so no facet value is cut off.
+const typesenseMaxFacetValues = 50
+
+// TypesenseExerciseSearcher searches the exercises collection in Typesense.
+type TypesenseExerciseSearcher struct {
+	Client     *typesense.Client
+	Collection string
+	Breaker    *gobreaker.CircuitBreaker // Optional; searches fail fast with gobreaker.ErrOpenState while it is open
+}
+
+// NewTypesenseExerciseSearcher creates a searcher for the default exercises collection.
+func NewTypesenseExerciseSearcher(client *typesense.Client) *TypesenseExerciseSearcher {
+	return &TypesenseExerciseSearcher{
+		Client:     client,
+		Collection: ExercisesCollection,
+	}
+}
+
+// Backend implements ExerciseSearcher.
+func (s *TypesenseExerciseSearcher) Backend() string {
+	return ExerciseSearchBackendTypesense
+}
+
+// Healthy implements SearchHealthChecker using the Typesense /health endpoint.
+func (s *TypesenseExerciseSearcher) Healthy(ctx context.Context) bool {
+	if s.Client == nil {
+		return false
+	}
+	ok, err := s.Client.Health(ctx, typesenseHealthTimeout)
+	return err == nil && ok
+}
+
+// Search implements ExerciseSearcher.
+func (s *TypesenseExerciseSearcher) Search(ctx context.Context, q ExerciseSearchQuery) (*ExerciseSearchResult, error) {
+	if s.Client == nil {
+		return nil, fmt.Errorf("typesense client is not configured")
+	}
+	if s.Breaker == nil {
+		return s.search(ctx, q)
+	}
+	res, err := s.Breaker.Execute(func() (any, error) {
+		return s.search(ctx, q)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ExerciseSearchResult), nil
+}
+
+func (s *TypesenseExerciseSearcher) search(ctx context.Context, q ExerciseSearchQuery) (*ExerciseSearchResult, error) {
+
+	queryBy, highlightFields := "name,aliases,description,instructions", "name,description"
+	if name := localizedField("name", q.Locale); name != "name" {
+		description := localizedField("description", q.Locale)
+		// The translated name comes first so matches in the user's language rank highest.
+		queryBy = name + "," + queryBy + "," + description
+		highlightFields += "," + name + "," + description
+	}
+
+	searchParams := &api.SearchCollectionParams{
+		Q:                 pointer.String(q.Q),
+		QueryBy:           pointer.String(queryBy),
+		Page:              pointer.Int(q.Page),
+		PerPage:           pointer.Int(q.PerPage),
+		FilterBy:          pointer.String(typesenseExerciseFilter(q)),
+		FacetBy:           pointer.String(strings.Join(ExerciseFilterFields, ",")),
+		MaxFacetValues:    pointer.Int(typesenseMaxFacetValues),
+		HighlightFields:   pointer.String(highlightFields),
+		HighlightStartTag: pointer.String(ExerciseHighlightStartTag),
+		HighlightEndTag:   pointer.String(ExerciseHighlightEndTag),
+	}
+	if q.Q == "" {
+		searchParams.SortBy = pointer.String("created_at:desc")
+	}
+
+	searchRes, err := s.Client.Collection(s.Collection).Documents().Search(ctx, searchParams)
+	if err != nil {
+		return nil, fmt.Errorf("typesense search failed: %w", err)
+	}
+
+	result := &ExerciseSearchResult{
+		Hits:    make([]ExerciseSearchHit, 0),
+		Backend: s.Backend(),
+	}
+	if searchRes.Found != nil {
+		result.Found = *searchRes.Found
+	}
+	result.Facets = exerciseFacetsFromTypesense(searchRes.FacetCounts)
+	if searchRes.Hits == nil {
+		return result, nil
+	}
+
+	for i, hit := range *searchRes.Hits {
+		if hit.Document == nil {
+			continue
+		}
+		exercise, err := exerciseHitFromDocument(*hit.Document)
+		if err != nil {
+			log.Printf("WARN: Typesense hit %d skipped: %v", i, err)
+			continue
+		}
+		exercise.Highlights = exerciseHighlightsFromTypesense(hit.Highlights)
+		localizeTypesenseHit(&exercise, *hit.Document, q.Locale)
+		exercise.MatchedAlias = matchedAliasFromTypesense(exercise, hit.Highlights)
+		result.Hits = append(result.Hits, exercise)
+	}
+	return result, nil
+}
+
+// typesenseExerciseFilter builds the filter_by expression: the visibility rule (only the global
+// catalog and the user's own custom exercises) plus the requested facet filters.
+func typesenseExerciseFilter(q ExerciseSearchQuery) string {
+	clauses := []string{fmt.Sprintf("owner_id:=[`%s`,`%s`]", ExerciseOwnerGlobal, q.UserID)}
+	for _, field := range ExerciseFilterFields {
+		values := q.Filters[field]
+		if len(values) == 0 {
+			continue
+		}
+		quoted := make([]string, 0, len(values))
+		for _, value := range values {
+			quoted = append(quoted, "`"+strings.ReplaceAll(value, "`", "")+"`")
+		}
+		clauses = append(clauses, fmt.Sprintf("%s:=[%s]", field, strings.Join(quoted, ",")))
+	}
+	return strings.Join(clauses, " && ")
+}
+
+// exerciseFacetsFromTypesense returns one facet per ExerciseFilterFields, keeping the
+// count order Typesense reports (most frequent first).
+func exerciseFacetsFromTypesense(facetCounts *[]api.FacetCounts) []ExerciseFacet {
+	byField := map[string][]ExerciseFacetCount{}
+	if facetCounts != nil {
+		for _, fc := range *facetCounts {
+			if fc.FieldName == nil || fc.Counts == nil {
+				continue
+			}
+			for _, count := range *fc.Counts {
+				if count.Value == nil || count.Count == nil {
+					continue
+				}
+				byField[*fc.FieldName] = append(byField[*fc.FieldName], ExerciseFacetCount{Value: *count.Value, Count: *count.Count})
+			}
+		}
+	}
+	facets := make([]ExerciseFacet, 0, len(ExerciseFilterFields))
+	for _, field := range ExerciseFilterFields {
+		counts := byField[field]
+		if counts == nil {
+			counts = []ExerciseFacetCount{}
+		}
+		facets = append(facets, ExerciseFacet{Field: field, Counts: counts})
+	}
+	return facets
+}
+
+// exerciseHighlightsFromTypesense maps the highlighted snippets of a hit by field name.
+func exerciseHighlightsFromTypesense(highlights *[]api.SearchHighlight) map[string]string {
+	if highlights == nil || len(*highlights) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(*highlights))
+	for _, h := range *highlights {
+		if h.Field == nil || h.Snippet == nil {
+			continue
+		}
+		result[*h.Field] = *h.Snippet
+	}
+	return result
+}
+
+// localizeTypesenseHit swaps the name and description of a hit, and their highlights, for
+// the translations to locale stored in the document. Untranslated texts are kept.
+func localizeTypesenseHit(hit *ExerciseSearchHit, document map[string]any, locale string) {
+	for _, field := range []string{"name", "description"} {
+		localized := localizedField(field, locale)
+		if localized == field {
+			return
+		}
+		highlight, highlighted := hit.Highlights[localized]
+		delete(hit.Highlights, localized)
+		text, _ := document[localized].(string)
+		if text == "" {
+			continue
+		}
+		if field == "name" {
+			hit.Name = text
+		} else {
+			hit.Description = text
+		}
+		// The highlight of the default text no longer matches what the hit shows.
+		delete(hit.Highlights, field)
+		if highlighted {
+			hit.Highlights[field] = highlight
+		}
+	}
+}
+
+// matchedAliasFromTypesense returns the first alias Typesense highlighted, unless the name
+// matched as well. Array highlights carry the indices of the matched elements.
+func matchedAliasFromTypesense(hit ExerciseSearchHit, highlights *[]api.SearchHighlight) string {
+	if highlights == nil || hit.Highlights["name"] != "" {
+		return ""
+	}
+	for _, h := range *highlights {
+		if h.Field == nil || *h.Field != "aliases" || h.Indices == nil {
+			continue
+		}
+		for _, idx := range *h.Indices {
+			if idx >= 0 && idx < len(hit.Aliases) {
+				return hit.Aliases[idx]
+			}
+		}
+	}
+	return ""
+}
+
+// exerciseHitFromDocument maps a Typesense document onto an ExerciseSearchHit.
+// Timestamps are stored as unix seconds; optional string fields default to "".
+func exerciseHitFromDocument(document map[string]any) (ExerciseSearchHit, error) {
+	uuidStr, ok := document["uuid"].(string)
+	if !ok {
+		return ExerciseSearchHit{}, fmt.Errorf("'uuid' field missing or not a string: %+v", document["uuid"])
+	}
+	id, err := uuid.Parse(uuidStr)
+	if err != nil {
+		return ExerciseSearchHit{}, fmt.Errorf("invalid UUID %q: %w", uuidStr, err)
+	}
+	name, ok := document["name"].(string)
+	if !ok {
+		return ExerciseSearchHit{}, fmt.Errorf("'name' missing or not a string for %s", uuidStr)
+	}
+
+	hit := ExerciseSearchHit{ID: id, Name: name}
+	if owner, _ := document["owner_id"].(string); owner != "" && owner != ExerciseOwnerGlobal {
+		ownerID, err := uuid.Parse(owner)
+		if err != nil {
+			return ExerciseSearchHit{}, fmt.Errorf("invalid owner_id %q for %s: %w", owner, uuidStr, err)
+		}
+		hit.OwnerID = &ownerID
+	}
+	if aliases, ok := document["aliases"].([]any); ok {
+		for _, alias := range aliases {
+			if s, ok := alias.(string); ok {
+				hit.Aliases = append(hit.Aliases, s)
+			}
+		}
+	}
+	hit.Description, _ = document["description"].(string)
+	hit.Position, _ = document["position"].(string)
+	hit.ForceType, _ = document["force_type"].(string)
+	hit.Difficulty, _ = document["difficulty"].(string)
+	hit.MovementType, _ = document["movement_type"].(string)
+	hit.MuscleGroup, _ = document["muscle_group"].(string)
+	hit.Equipment, _ = document["equipment"].(string)
+	hit.Bodypart, _ = document["bodypart"].(string)
+	hit.TrackingType, _ = document["tracking_type"].(string)
+	if hit.TrackingType == "" {
+		// Documents indexed before tracking types existed.
+		hit.TrackingType = model.ExerciseTrackingWeightReps
+	}
+
+	if createdAt, ok := document["created_at"].(float64); ok {
+		hit.CreatedAt = time.Unix(int64(createdAt), 0)
+	}
+	if updatedAt, ok := document["updated_at"].(float64); ok {
+		hit.UpdatedAt = time.Unix(int64(updatedAt), 0)
+	}
+	if deletedAt, ok := document["deleted_at"].(float64); ok && deletedAt > 0 {
+		t := time.Unix(int64(deletedAt), 0)
+		hit.DeletedAt = &t
+	}
+	return hit, nil
+}
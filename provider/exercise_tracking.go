@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// FetchExerciseTrackingTypes returns the tracking type of each of the given exercises that exists.
+// Workouts and logs use it to validate set values against model.ValidateSetMetrics.
+func FetchExerciseTrackingTypes(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	trackingTypes := make(map[uuid.UUID]string, len(exerciseIDs))
+	if len(exerciseIDs) == 0 {
+		return trackingTypes, nil
+	}
+	query, args, err := sq.Select("id", "tracking_type").
+		From("exercises").
+		Where(squirrel.Eq{"id": exerciseIDs}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise tracking type query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise tracking types: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id           uuid.UUID
+			trackingType string
+		)
+		if err := rows.Scan(&id, &trackingType); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise tracking type: %w", err)
+		}
+		trackingTypes[id] = trackingType
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise tracking type rows error: %w", err)
+	}
+	return trackingTypes, nil
+}
+
+// ExerciseHasTrackedValues reports whether the exercise has logged sets or is part of a workout
+// template. Their values were validated against its tracking type, so changing the type would
+// leave them meaningless (e.g. reps on a timed exercise).
+func ExerciseHasTrackedValues(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseID uuid.UUID) (bool, error) {
+	query, args, err := sq.Select().
+		Column(squirrel.Expr("EXISTS (SELECT 1 FROM exercise_sets WHERE exercise_id = ? AND deleted_at IS NULL)", exerciseID)).
+		Column(squirrel.Expr("EXISTS (SELECT 1 FROM workout_exercises WHERE exercise_id = ? AND deleted_at IS NULL)", exerciseID)).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build exercise usage query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to query exercise usage: %w", err)
+	}
+	defer rows.Close()
+
+	var logged, planned bool
+	if rows.Next() {
+		if err := rows.Scan(&logged, &planned); err != nil {
+			return false, fmt.Errorf("failed to scan exercise usage: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("exercise usage rows error: %w", err)
+	}
+	return logged || planned, nil
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ExerciseTranslationColumns are the exercise_translations columns read by
+// ScanExerciseTranslation, in scan order.
+var ExerciseTranslationColumns = []string{
+	"exercise_id", "locale", "name", "description", "steps", "form_cues", "common_mistakes", "created_at", "updated_at",
+}
+
+// ScanExerciseTranslation scans a row selected with ExerciseTranslationColumns.
+func ScanExerciseTranslation(row RowScanner) (model.ExerciseTranslation, error) {
+	var (
+		t           model.ExerciseTranslation
+		description sql.NullString
+	)
+	err := row.Scan(
+		&t.ExerciseID, &t.Locale, &t.Name, &description,
+		pq.Array(&t.Steps), pq.Array(&t.FormCues), pq.Array(&t.CommonMistakes), &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return t, err
+	}
+	t.Description = NullStringToStringPtr(description)
+	return t, nil
+}
+
+// FetchExerciseTranslations returns the translations of the given exercises keyed by exercise
+// id, ordered by locale. Passing locales restricts the result to them. Exercises without
+// translations are absent from the map.
+func FetchExerciseTranslations(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID, locales ...string) (map[uuid.UUID][]model.ExerciseTranslation, error) {
+	translations := make(map[uuid.UUID][]model.ExerciseTranslation)
+	if len(exerciseIDs) == 0 {
+		return translations, nil
+	}
+	where := squirrel.Eq{"exercise_id": exerciseIDs}
+	if len(locales) > 0 {
+		where["locale"] = locales
+	}
+	query, args, err := sq.Select(ExerciseTranslationColumns...).
+		From("exercise_translations").
+		Where(where).
+		OrderBy("exercise_id", "locale").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise translations query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise translations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := ScanExerciseTranslation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise translation: %w", err)
+		}
+		translations[t.ExerciseID] = append(translations[t.ExerciseID], t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise translations rows error: %w", err)
+	}
+	return translations, nil
+}
+
+// FetchLocalizedExercises returns the translations of the given exercises in locale, keyed by
+// exercise id. The map is empty for model.DefaultLocale, whose texts live on the exercises.
+func FetchLocalizedExercises(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID, locale string) (map[uuid.UUID]*model.ExerciseTranslation, error) {
+	localized := make(map[uuid.UUID]*model.ExerciseTranslation)
+	if locale == "" || locale == model.DefaultLocale {
+		return localized, nil
+	}
+	translations, err := FetchExerciseTranslations(ctx, q, sq, exerciseIDs, locale)
+	if err != nil {
+		return nil, err
+	}
+	for id, ts := range translations {
+		localized[id] = &ts[0]
+	}
+	return localized, nil
+}
+
+// FetchLocalizedExerciseNames returns the names of the given exercises translated to locale.
+// Exercises without a translation are absent from the map and keep their default name.
+func FetchLocalizedExerciseNames(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID, locale string) (map[uuid.UUID]string, error) {
+	localized, err := FetchLocalizedExercises(ctx, q, sq, exerciseIDs, locale)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(localized))
+	for id, t := range localized {
+		names[id] = t.Name
+	}
+	return names, nil
+}
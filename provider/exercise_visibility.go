@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// SQLQuerier is satisfied by both *sql.DB and *sql.Tx.
+type SQLQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ExerciseVisibleTo returns the condition matching exercises userID may use: the global
+// catalog plus the user's own custom exercises. prefix is the table alias with its dot
+// (e.g. "e.") or "" for an unaliased exercises table.
+func ExerciseVisibleTo(prefix string, userID uuid.UUID) squirrel.Sqlizer {
+	return squirrel.Or{
+		squirrel.Eq{prefix + "owner_id": nil},
+		squirrel.Eq{prefix + "owner_id": userID},
+	}
+}
+
+// InvisibleExerciseIDs returns the ids among exerciseIDs that don't exist or belong to
+// another user. Workouts and logs use it to reject exercises the user can't see.
+func InvisibleExerciseIDs(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, userID uuid.UUID, exerciseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(exerciseIDs) == 0 {
+		return nil, nil
+	}
+	query, args, err := sq.Select("id").
+		From("exercises").
+		Where(squirrel.And{squirrel.Eq{"id": exerciseIDs}, ExerciseVisibleTo("", userID)}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exercise visibility query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check exercise visibility: %w", err)
+	}
+	defer rows.Close()
+
+	visible := make(map[uuid.UUID]bool, len(exerciseIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise id: %w", err)
+		}
+		visible[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("exercise visibility rows error: %w", err)
+	}
+
+	var invisible []uuid.UUID
+	for _, id := range exerciseIDs {
+		if !visible[id] {
+			invisible = append(invisible, id)
+		}
+	}
+	return invisible, nil
+}
+
+// DeletedExerciseIDs returns the ids among exerciseIDs of soft-deleted exercises. Existing
+// workouts and logs keep showing them, but they can't be added anywhere new.
+func DeletedExerciseIDs(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, exerciseIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(exerciseIDs) == 0 {
+		return nil, nil
+	}
+	query, args, err := sq.Select("id").
+		From("exercises").
+		Where(squirrel.And{squirrel.Eq{"id": exerciseIDs}, squirrel.NotEq{"deleted_at": nil}}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build deleted exercises query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check deleted exercises: %w", err)
+	}
+	defer rows.Close()
+
+	var deleted []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise id: %w", err)
+		}
+		deleted = append(deleted, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("deleted exercises rows error: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func TestInvisibleExerciseIDs(t *testing.T) {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	userID := uuid.New()
+	catalog, own, foreign := uuid.New(), uuid.New(), uuid.New()
+
+	var gotQuery string
+	db := openFakeDB(t, func(query string, _ []driver.NamedValue) ([]string, [][]driver.Value) {
+		gotQuery = query
+		return []string{"id"}, [][]driver.Value{{catalog.String()}, {own.String()}}
+	})
+
+	invisible, err := InvisibleExerciseIDs(context.Background(), db, sq, userID, []uuid.UUID{catalog, foreign, own})
+	if err != nil {
+		t.Fatalf("InvisibleExerciseIDs() error = %v", err)
+	}
+	if !slices.Equal(invisible, []uuid.UUID{foreign}) {
+		t.Errorf("InvisibleExerciseIDs() = %v, want [%s]", invisible, foreign)
+	}
+	want := "WHERE (id IN ($1,$2,$3) AND (owner_id IS NULL OR owner_id = $4))"
+	if !strings.Contains(gotQuery, want) {
+		t.Errorf("visibility query %q doesn't contain %q", gotQuery, want)
+	}
+}
+
+func TestDeletedExerciseIDs(t *testing.T) {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	live, deleted := uuid.New(), uuid.New()
+
+	var gotQuery string
+	db := openFakeDB(t, func(query string, _ []driver.NamedValue) ([]string, [][]driver.Value) {
+		gotQuery = query
+		return []string{"id"}, [][]driver.Value{{deleted.String()}}
+	})
+
+	got, err := DeletedExerciseIDs(context.Background(), db, sq, []uuid.UUID{live, deleted})
+	if err != nil {
+		t.Fatalf("DeletedExerciseIDs() error = %v", err)
+	}
+	if !slices.Equal(got, []uuid.UUID{deleted}) {
+		t.Errorf("DeletedExerciseIDs() = %v, want [%s]", got, deleted)
+	}
+	want := "WHERE (id IN ($1,$2) AND deleted_at IS NOT NULL)"
+	if !strings.Contains(gotQuery, want) {
+		t.Errorf("deleted query %q doesn't contain %q", gotQuery, want)
+	}
+}
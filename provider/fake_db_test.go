@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeQueryFunc answers a query of the fake driver with column names and rows.
+type fakeQueryFunc func(query string, args []driver.NamedValue) (columns []string, rows [][]driver.Value)
+
+var (
+	fakeDriverOnce    sync.Once
+	fakeDriverMu      sync.Mutex
+	fakeDriverQueries = map[string]fakeQueryFunc{}
+)
+
+// openFakeDB returns a *sql.DB whose queries are answered by answer, so the queries built by the
+// provider functions can be inspected and their row handling exercised without PostgreSQL.
+func openFakeDB(t *testing.T, answer fakeQueryFunc) *sql.DB {
+	t.Helper()
+	fakeDriverOnce.Do(func() { sql.Register("provider-fake", fakeDriver{}) })
+
+	fakeDriverMu.Lock()
+	fakeDriverQueries[t.Name()] = answer
+	fakeDriverMu.Unlock()
+
+	db, err := sql.Open("provider-fake", t.Name())
+	if err != nil {
+		t.Fatalf("failed to open fake db: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		fakeDriverMu.Lock()
+		delete(fakeDriverQueries, t.Name())
+		fakeDriverMu.Unlock()
+	})
+	return db
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeDriverMu.Lock()
+	defer fakeDriverMu.Unlock()
+	answer, ok := fakeDriverQueries[name]
+	if !ok {
+		return nil, errors.New("no fake db registered for " + name)
+	}
+	return fakeConn{answer}, nil
+}
+
+type fakeConn struct{ answer fakeQueryFunc }
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	columns, rows := c.answer(query, args)
+	return &fakeRows{columns: columns, rows: rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// MaxImagePixels guards against decompression bombs: a tiny file can declare a huge canvas.
+const MaxImagePixels = 40_000_000
+
+// ErrUnsupportedImageType is returned for uploads that are not JPEG, PNG or WebP.
+var ErrUnsupportedImageType = errors.New("unsupported image type")
+
+// ErrImageTooLarge is returned when the image dimensions exceed MaxImagePixels.
+var ErrImageTooLarge = errors.New("image dimensions are too large")
+
+// DecodeImage sniffs the content type from the bytes (never trusting the client's header),
+// decodes JPEG, PNG or WebP, and applies the EXIF orientation of JPEGs so the pixels are upright.
+// The returned image carries no metadata, so re-encoding it strips EXIF (GPS, camera, etc.).
+func DecodeImage(data []byte) (image.Image, error) {
+	contentType := http.DetectContentType(data)
+
+	var (
+		decodeConfig func([]byte) (image.Config, error)
+		decode       func([]byte) (image.Image, error)
+	)
+	switch contentType {
+	case "image/jpeg":
+		decodeConfig = func(b []byte) (image.Config, error) { return jpeg.DecodeConfig(bytes.NewReader(b)) }
+		decode = func(b []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(b)) }
+	case "image/png":
+		decodeConfig = func(b []byte) (image.Config, error) { return png.DecodeConfig(bytes.NewReader(b)) }
+		decode = func(b []byte) (image.Image, error) { return png.Decode(bytes.NewReader(b)) }
+	case "image/webp":
+		decodeConfig = func(b []byte) (image.Config, error) { return webp.DecodeConfig(bytes.NewReader(b)) }
+		decode = func(b []byte) (image.Image, error) { return webp.Decode(bytes.NewReader(b)) }
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedImageType, contentType)
+	}
+
+	cfg, err := decodeConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	return img, nil
+}
+
+// ResizeSquare center-crops img to a square and scales it to size x size pixels.
+func ResizeSquare(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(
+		b.Min.X+(b.Dx()-side)/2,
+		b.Min.Y+(b.Dy()-side)/2,
+		b.Min.X+(b.Dx()-side)/2+side,
+		b.Min.Y+(b.Dy()-side)/2+side,
+	)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	return dst
+}
+
+// ResizeToFit scales img down, keeping its aspect ratio, so neither side exceeds maxSide
+// pixels. Smaller images are returned unchanged.
+func ResizeToFit(img image.Image, maxSide int) image.Image {
+	b := img.Bounds()
+	if b.Dx() <= maxSide && b.Dy() <= maxSide {
+		return img
+	}
+	w, h := maxSide, b.Dy()*maxSide/b.Dx()
+	if b.Dy() > b.Dx() {
+		w, h = b.Dx()*maxSide/b.Dy(), maxSide
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, max(w, 1), max(h, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// EncodeJPEG encodes img as a baseline JPEG with the given quality (1-100).
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// jpegOrientation returns the EXIF orientation tag (1-8) of a JPEG, or 1 when absent.
+// Only the APP1/Exif segment is parsed; everything else is skipped.
+func jpegOrientation(data []byte) int {
+	const defaultOrientation = 1
+
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return defaultOrientation
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return defaultOrientation
+		}
+		marker := data[pos+1]
+		segmentLen := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if marker == 0xDA || segmentLen < 2 || pos+2+segmentLen > len(data) {
+			// Start of scan (no more metadata) or a malformed segment.
+			return defaultOrientation
+		}
+
+		segment := data[pos+4 : pos+2+segmentLen]
+		if marker == 0xE1 && len(segment) > 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		pos += 2 + segmentLen
+	}
+	return defaultOrientation
+}
+
+// exifOrientation reads the orientation tag (0x0112) from IFD0 of a TIFF-structured EXIF block.
+func exifOrientation(tiff []byte) int {
+	const defaultOrientation = 1
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return defaultOrientation
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:8]))
+	if ifdOffset+2 > len(tiff) {
+		return defaultOrientation
+	}
+	entries := int(order.Uint16(tiff[ifdOffset : ifdOffset+2]))
+	for i := 0; i < entries; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return defaultOrientation
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return defaultOrientation
+		}
+	}
+	return defaultOrientation
+}
+
+// applyOrientation rotates/flips img according to an EXIF orientation value.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// Orientations 5-8 swap width and height.
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // Mirror horizontal
+				dx, dy = w-1-x, y
+			case 3: // Rotate 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // Mirror vertical
+				dx, dy = x, h-1-y
+			case 5: // Mirror horizontal and rotate 270 CW
+				dx, dy = y, x
+			case 6: // Rotate 90 CW
+				dx, dy = h-1-y, x
+			case 7: // Mirror horizontal and rotate 90 CW
+				dx, dy = h-1-y, w-1-x
+			case 8: // Rotate 270 CW
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
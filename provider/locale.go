@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+var localeMatcher = func() language.Matcher {
+	tags := make([]language.Tag, 0, len(model.SupportedLocales))
+	for _, locale := range model.SupportedLocales {
+		tags = append(tags, language.Make(locale))
+	}
+	return language.NewMatcher(tags)
+}()
+
+// MatchLocale returns the supported locale that best matches an Accept-Language header,
+// e.g. "pt-BR,pt;q=0.9,en;q=0.8" yields "pt". It returns model.DefaultLocale when nothing matches.
+func MatchLocale(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return model.DefaultLocale
+	}
+	_, index, confidence := localeMatcher.Match(tags...)
+	if confidence == language.No {
+		return model.DefaultLocale
+	}
+	return model.SupportedLocales[index]
+}
+
+// FetchProfileLocale returns the locale the user picked in their profile, or "" when they
+// didn't pick one or have no profile.
+func FetchProfileLocale(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, userID uuid.UUID) (string, error) {
+	query, args, err := sq.Select("locale").
+		From("profiles").
+		Where(squirrel.Eq{"user_id": userID, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("failed to build profile locale query: %w", err)
+	}
+	var locale sql.NullString
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&locale); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to load profile locale: %w", err)
+	}
+	return locale.String, nil
+}
@@ -3,13 +3,14 @@ package provider
 import (
 	"bytes"
 	"fmt"
+	"html/template"
 	"net/smtp"
-	"text/template"
 )
 
 // EmailSender interface for sending emails
 type EmailSender interface {
 	SendPasswordResetEmail(toEmail, resetLink string) error
+	SendBodyweightGoalReachedEmail(toEmail, name string, startWeight, targetWeight float64) error
 }
 
 // SMTPEmailSender implements EmailSender for SMTP
@@ -62,6 +63,46 @@ func (s *SMTPEmailSender) SendPasswordResetEmail(toEmail, resetLink string) erro
 	return nil
 }
 
+// SendBodyweightGoalReachedEmail congratulates the user once their bodyweight goal is reached
+func (s *SMTPEmailSender) SendBodyweightGoalReachedEmail(toEmail, name string, startWeight, targetWeight float64) error {
+	auth := smtp.PlainAuth("", s.Username, s.Password, s.Host)
+
+	t, err := template.New("bodyweight_goal_reached").Parse(bodyweightGoalReachedEmailTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	data := struct {
+		Name         string
+		StartWeight  string
+		TargetWeight string
+	}{
+		Name:         name,
+		StartWeight:  fmt.Sprintf("%.1f", startWeight),
+		TargetWeight: fmt.Sprintf("%.1f", targetWeight),
+	}
+
+	var body bytes.Buffer
+	if err := t.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to execute email template: %w", err)
+	}
+
+	msg := []byte(
+		"From: " + s.From + "\r\n" +
+			"To: " + toEmail + "\r\n" +
+			"Subject: You reached your bodyweight goal!\r\n" +
+			"MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";\r\n" +
+			"\r\n" +
+			body.String())
+
+	addr := fmt.Sprintf("%s:%s", s.Host, s.Port)
+	err = smtp.SendMail(addr, auth, s.From, []string{toEmail}, msg)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
 const passwordResetEmailTemplate = `
 <!DOCTYPE html>
 <html>
@@ -79,3 +120,20 @@ const passwordResetEmailTemplate = `
 </body>
 </html>
 `
+
+const bodyweightGoalReachedEmailTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+    <title>Goal Reached</title>
+</head>
+<body>
+    <p>Hello{{if .Name}} {{.Name}}{{end}},</p>
+    <p>Congratulations! You have reached your bodyweight goal.</p>
+    <p>You started at <strong>{{.StartWeight}}</strong> and hit your target of <strong>{{.TargetWeight}}</strong>.</p>
+    <p>Set a new goal in the app whenever you are ready.</p>
+    <p>Thanks,</p>
+    <p>Your Application Team</p>
+</body>
+</html>
+`
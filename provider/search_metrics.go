@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Exercise search metrics, exported on /metrics next to the HTTP metrics of echoprometheus.
+var (
+	exerciseSearchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "exercise_search_duration_seconds",
+		Help:    "Latency of exercise searches per backend.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"backend"})
+	exerciseSearchErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "exercise_search_errors_total",
+		Help: "Exercise searches that returned an error, per backend.",
+	}, []string{"backend"})
+	exerciseSearchFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "exercise_search_fallbacks_total",
+		Help: "Exercise searches served by the fallback backend after the primary failed.",
+	})
+	typesenseBreakerState = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "typesense_search_circuit_breaker_state",
+		Help: "State of the Typesense search circuit breaker: 0 closed, 1 half-open, 2 open.",
+	})
+)
+
+// instrumentedExerciseSearcher records the latency and errors of every search of Searcher.
+type instrumentedExerciseSearcher struct {
+	ExerciseSearcher
+}
+
+// Search implements ExerciseSearcher.
+func (s instrumentedExerciseSearcher) Search(ctx context.Context, q ExerciseSearchQuery) (*ExerciseSearchResult, error) {
+	started := time.Now()
+	res, err := s.ExerciseSearcher.Search(ctx, q)
+	backend := s.Backend()
+	exerciseSearchDuration.WithLabelValues(backend).Observe(time.Since(started).Seconds())
+	if err != nil {
+		exerciseSearchErrors.WithLabelValues(backend).Inc()
+	}
+	return res, err
+}
+
+// Healthy forwards to the wrapped searcher so FailoverExerciseSearcher still sees its health.
+func (s instrumentedExerciseSearcher) Healthy(ctx context.Context) bool {
+	if checker, ok := s.ExerciseSearcher.(SearchHealthChecker); ok {
+		return checker.Healthy(ctx)
+	}
+	return true
+}
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// Defaults for the search sync worker.
+const (
+	DefaultSearchSyncBatchSize    = 100
+	DefaultSearchSyncPollInterval = 2 * time.Second
+	DefaultSearchSyncMaxAttempts  = 10
+	DefaultSearchSyncRetention    = 7 * 24 * time.Hour
+	searchSyncMaxBackoff          = time.Hour
+	searchSyncPurgeInterval       = time.Hour
+	// searchSyncClaimTimeout is how long claimed rows are hidden from other workers. It must
+	// outlast a batch of index calls with their retries; rows of a crashed worker come back after it.
+	searchSyncClaimTimeout = 5 * time.Minute
+)
+
+// SQLExecer is satisfied by both *sql.DB and *sql.Tx, so outbox rows can be written
+// inside the caller's transaction.
+type SQLExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// EnqueueSearchSync records that the search document of an entity must be upserted or deleted.
+// Call it with the same transaction that changes the entity so the index can never miss a change.
+func EnqueueSearchSync(ctx context.Context, exec SQLExecer, sq squirrel.StatementBuilderType, entityType, operation string, entityIDs ...uuid.UUID) error {
+	if len(entityIDs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	builder := sq.Insert("search_outbox").Columns("entity_type", "entity_id", "operation", "available_at", "created_at")
+	for _, id := range entityIDs {
+		builder = builder.Values(entityType, id, operation, now, now)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build search outbox insert: %w", err)
+	}
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to write search outbox: %w", err)
+	}
+	return nil
+}
+
+// SearchSyncWorker applies pending search_outbox rows to Typesense. Rows are claimed with
+// FOR UPDATE SKIP LOCKED and pushed past searchSyncClaimTimeout in a single statement, so several
+// API instances can run the worker side by side without holding locks during the index calls.
+// Failed rows are retried with exponential backoff until MaxAttempts is reached.
+// Processed rows are deleted once they are older than Retention (0 keeps them forever);
+// rows that ran out of attempts are kept for inspection.
+type SearchSyncWorker struct {
+	DB           *sql.DB
+	Indexer      *ExerciseIndexer
+	BatchSize    int
+	PollInterval time.Duration
+	MaxAttempts  int
+	Retention    time.Duration
+	sq           squirrel.StatementBuilderType
+}
+
+// NewSearchSyncWorker creates a worker with the default batch size, poll interval, attempts and retention.
+func NewSearchSyncWorker(db *sql.DB, indexer *ExerciseIndexer) *SearchSyncWorker {
+	return &SearchSyncWorker{
+		DB:           db,
+		Indexer:      indexer,
+		BatchSize:    DefaultSearchSyncBatchSize,
+		PollInterval: DefaultSearchSyncPollInterval,
+		MaxAttempts:  DefaultSearchSyncMaxAttempts,
+		Retention:    DefaultSearchSyncRetention,
+		sq:           squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Run processes the outbox until ctx is cancelled. Full batches are followed immediately
+// by the next one; otherwise the worker sleeps for PollInterval. Processed rows are purged
+// about once an hour.
+func (w *SearchSyncWorker) Run(ctx context.Context) {
+	var purgedAt time.Time
+	for {
+		if w.Retention > 0 && time.Since(purgedAt) >= searchSyncPurgeInterval {
+			if _, err := w.PurgeProcessed(ctx); err != nil {
+				log.Printf("ERROR: Search outbox purge failed: %v", err)
+			}
+			purgedAt = time.Now()
+		}
+
+		processed, err := w.ProcessBatch(ctx)
+		if err != nil {
+			log.Printf("ERROR: Search sync batch failed: %v", err)
+		}
+		if processed == w.BatchSize && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.PollInterval):
+		}
+	}
+}
+
+// ProcessBatch claims up to BatchSize pending rows and applies them. It returns the number
+// of rows handled, successful or not.
+//
+// No transaction is open while Typesense is called: the claim commits on its own, and the
+// outcomes are written afterwards in a short transaction of their own.
+func (w *SearchSyncWorker) ProcessBatch(ctx context.Context) (int, error) {
+	entries, err := w.claimBatch(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	updates := make([]squirrel.UpdateBuilder, 0, len(entries))
+	for _, e := range entries {
+		update := w.sq.Update("search_outbox").Where(squirrel.Eq{"id": e.ID})
+		if applyErr := w.apply(ctx, e); applyErr != nil {
+			log.Printf("WARN: Search sync of %s %s (attempt %d) failed: %v", e.EntityType, e.EntityID, e.Attempts, applyErr)
+			update = update.
+				Set("last_error", applyErr.Error()).
+				Set("available_at", time.Now().Add(searchSyncBackoff(e.Attempts)))
+		} else {
+			update = update.Set("processed_at", time.Now()).Set("last_error", nil)
+		}
+		updates = append(updates, update)
+	}
+
+	// The claim timeout brings back any row whose outcome isn't written, so a failure
+	// here costs a retry rather than an update.
+	tx, err := w.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // No-op after a successful commit
+
+	for i, update := range updates {
+		updateQuery, updateArgs, err := update.ToSql()
+		if err != nil {
+			return 0, fmt.Errorf("failed to build outbox update: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, updateQuery, updateArgs...); err != nil {
+			return 0, fmt.Errorf("failed to update outbox entry %d: %w", entries[i].ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit outbox batch: %w", err)
+	}
+	return len(entries), nil
+}
+
+// claimBatch takes up to BatchSize pending rows: it counts the attempt and hides the rows from
+// other workers for searchSyncClaimTimeout. The returned entries carry the counted attempt.
+func (w *SearchSyncWorker) claimBatch(ctx context.Context) ([]model.SearchOutboxEntry, error) {
+	now := time.Now()
+	// The inner query uses "?" placeholders; the outer builder numbers them.
+	pending := squirrel.Select("id").
+		From("search_outbox").
+		Where(squirrel.And{
+			squirrel.Eq{"processed_at": nil},
+			squirrel.LtOrEq{"available_at": now},
+			squirrel.Lt{"attempts": w.MaxAttempts},
+		}).
+		OrderBy("available_at ASC", "id ASC").
+		Limit(uint64(w.BatchSize)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query, args, err := w.sq.Update("search_outbox").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("available_at", now.Add(searchSyncClaimTimeout)).
+		Where(squirrel.Expr("id IN (?)", pending)).
+		Suffix("RETURNING id, entity_type, entity_id, operation, attempts").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build outbox claim: %w", err)
+	}
+
+	rows, err := w.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]model.SearchOutboxEntry, 0)
+	for rows.Next() {
+		var e model.SearchOutboxEntry
+		if err := rows.Scan(&e.ID, &e.EntityType, &e.EntityID, &e.Operation, &e.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("outbox rows error: %w", err)
+	}
+	// RETURNING doesn't keep the order of the inner query.
+	slices.SortFunc(entries, func(a, b model.SearchOutboxEntry) int { return cmp.Compare(a.ID, b.ID) })
+	return entries, nil
+}
+
+// PurgeProcessed deletes the rows processed more than Retention ago and returns how many were removed.
+func (w *SearchSyncWorker) PurgeProcessed(ctx context.Context) (int64, error) {
+	query, args, err := w.sq.Delete("search_outbox").
+		Where(squirrel.Lt{"processed_at": time.Now().Add(-w.Retention)}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build outbox purge: %w", err)
+	}
+	res, err := w.DB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// apply pushes a single outbox entry to the index. Upserts re-read the current row, so
+// entries processed out of order still converge on the latest database state.
+func (w *SearchSyncWorker) apply(ctx context.Context, e model.SearchOutboxEntry) error {
+	if e.EntityType != model.SearchEntityExercise {
+		return fmt.Errorf("unknown entity type %q", e.EntityType)
+	}
+
+	if e.Operation == model.SearchOperationUpsert {
+		ex, err := FetchExerciseForIndex(ctx, w.DB, e.EntityID)
+		if err != nil {
+			return err
+		}
+		if ex != nil && ex.DeletedAt == nil {
+			return w.Indexer.Upsert(ctx, ex)
+		}
+		// The row is gone or soft-deleted by now: remove it from the index instead.
+	}
+	return w.Indexer.Delete(ctx, e.EntityID)
+}
+
+// searchSyncBackoff returns 2^attempt seconds, capped at searchSyncMaxBackoff.
+func searchSyncBackoff(attempt int) time.Duration {
+	backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
+	if backoff > searchSyncMaxBackoff || backoff <= 0 {
+		return searchSyncMaxBackoff
+	}
+	return backoff
+}
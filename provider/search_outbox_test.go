@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSearchSyncWorkerClaimBatch(t *testing.T) {
+	var gotQuery string
+	var gotArgs []driver.NamedValue
+	db := openFakeDB(t, func(query string, args []driver.NamedValue) ([]string, [][]driver.Value) {
+		gotQuery, gotArgs = query, args
+		columns := []string{"id", "entity_type", "entity_id", "operation", "attempts"}
+		return columns, [][]driver.Value{
+			{int64(9), "exercise", uuid.NewString(), "upsert", int64(1)},
+			{int64(4), "exercise", uuid.NewString(), "delete", int64(3)},
+		}
+	})
+	w := NewSearchSyncWorker(db, nil)
+
+	before := time.Now()
+	entries, err := w.claimBatch(context.Background())
+	if err != nil {
+		t.Fatalf("claimBatch() error = %v", err)
+	}
+
+	// Claiming counts the attempt and hides the rows in the same statement, so no lock or
+	// transaction outlives it.
+	for _, want := range []string{
+		"UPDATE search_outbox SET attempts = attempts + 1, available_at = $1 WHERE id IN (SELECT id FROM search_outbox",
+		"processed_at IS NULL AND available_at <= $2 AND attempts < $3",
+		"LIMIT 100 FOR UPDATE SKIP LOCKED)",
+		"RETURNING id, entity_type, entity_id, operation, attempts",
+	} {
+		if !strings.Contains(gotQuery, want) {
+			t.Errorf("claim query %q doesn't contain %q", gotQuery, want)
+		}
+	}
+	if claimedUntil, ok := gotArgs[0].Value.(time.Time); !ok || claimedUntil.Before(before.Add(searchSyncClaimTimeout)) {
+		t.Errorf("rows claimed until %v, want at least %v from now", gotArgs[0].Value, searchSyncClaimTimeout)
+	}
+	if gotArgs[2].Value != int64(DefaultSearchSyncMaxAttempts) {
+		t.Errorf("max attempts arg = %v, want %d", gotArgs[2].Value, DefaultSearchSyncMaxAttempts)
+	}
+
+	if len(entries) != 2 || entries[0].ID != 4 || entries[1].ID != 9 {
+		t.Fatalf("claimBatch() = %+v, want entries 4 and 9 in id order", entries)
+	}
+	if entries[0].Attempts != 3 {
+		t.Errorf("entry attempts = %d, want the counted attempt 3", entries[0].Attempts)
+	}
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"rtglabs-go/model"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// SetPrescriptionColumns are the set_prescriptions columns read by ScanSetPrescription, in scan order.
+var SetPrescriptionColumns = []string{
+	"id", "workout_exercise_id", "set_number", "set_type", "target_reps", "target_reps_max",
+	"target_weight", "target_percent_1rm", "target_rpe", "rest_seconds", "created_at", "updated_at",
+}
+
+// ScanSetPrescription scans a row selected with SetPrescriptionColumns.
+func ScanSetPrescription(row RowScanner) (model.SetPrescription, error) {
+	var p model.SetPrescription
+	err := row.Scan(
+		&p.ID, &p.WorkoutExerciseID, &p.SetNumber, &p.SetType, &p.TargetReps, &p.TargetRepsMax,
+		&p.TargetWeight, &p.TargetPercent1RM, &p.TargetRPE, &p.RestSeconds, &p.CreatedAt, &p.UpdatedAt,
+	)
+	return p, err
+}
+
+// FetchSetPrescriptions returns the set prescriptions of the given workout exercises keyed by
+// workout exercise id, in set order. Exercises without prescriptions are absent from the map.
+func FetchSetPrescriptions(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, workoutExerciseIDs []uuid.UUID) (map[uuid.UUID][]model.SetPrescription, error) {
+	prescriptions := make(map[uuid.UUID][]model.SetPrescription)
+	if len(workoutExerciseIDs) == 0 {
+		return prescriptions, nil
+	}
+	query, args, err := sq.Select(SetPrescriptionColumns...).
+		From("set_prescriptions").
+		Where(squirrel.Eq{"workout_exercise_id": workoutExerciseIDs}).
+		OrderBy("workout_exercise_id", "set_number").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build set prescriptions query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query set prescriptions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := ScanSetPrescription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan set prescription: %w", err)
+		}
+		prescriptions[p.WorkoutExerciseID] = append(prescriptions[p.WorkoutExerciseID], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("set prescriptions rows error: %w", err)
+	}
+	return prescriptions, nil
+}
+
+// FetchEstimatedOneRepMaxes estimates userID's one-rep max on each of the given exercises from their
+// completed sets of 1 to 12 reps (higher rep counts predict poorly). Exercises without such sets are
+// absent from the map.
+func FetchEstimatedOneRepMaxes(ctx context.Context, q SQLQuerier, sq squirrel.StatementBuilderType, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	oneRepMaxes := make(map[uuid.UUID]float64)
+	if len(exerciseIDs) == 0 {
+		return oneRepMaxes, nil
+	}
+	// The best rep count at each weight is enough to find the best estimate.
+	query, args, err := sq.Select("es.exercise_id", "es.weight", "MAX(es.reps)").
+		From("exercise_sets AS es").
+		Join("workout_logs AS wl ON wl.id = es.workout_log_id AND wl.deleted_at IS NULL").
+		Where(squirrel.Eq{
+			"wl.user_id":     userID,
+			"es.exercise_id": exerciseIDs,
+			"es.status":      model.ExerciseSetStatusCompleted,
+			"es.deleted_at":  nil,
+		}).
+		Where("es.weight > 0 AND es.reps BETWEEN 1 AND 12").
+		GroupBy("es.exercise_id", "es.weight").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build one-rep max query: %w", err)
+	}
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query one-rep maxes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var exerciseID uuid.UUID
+		var weight float64
+		var reps int
+		if err := rows.Scan(&exerciseID, &weight, &reps); err != nil {
+			return nil, fmt.Errorf("failed to scan one-rep max: %w", err)
+		}
+		if estimate := model.EstimateOneRepMax(weight, reps); estimate > oneRepMaxes[exerciseID] {
+			oneRepMaxes[exerciseID] = estimate
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("one-rep max rows error: %w", err)
+	}
+	return oneRepMaxes, nil
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func TestFetchEstimatedOneRepMaxes(t *testing.T) {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	userID := uuid.New()
+	bench, squat := uuid.New(), uuid.New()
+
+	var gotQuery string
+	db := openFakeDB(t, func(query string, _ []driver.NamedValue) ([]string, [][]driver.Value) {
+		gotQuery = query
+		// One row per exercise and weight with the best reps at that weight.
+		return []string{"exercise_id", "weight", "max"}, [][]driver.Value{
+			{bench.String(), 100.0, int64(1)},
+			{bench.String(), 90.0, int64(5)},  // 105, the best bench estimate
+			{bench.String(), 80.0, int64(6)},  // 96
+			{squat.String(), 140.0, int64(3)}, // 154
+		}
+	})
+
+	oneRepMaxes, err := FetchEstimatedOneRepMaxes(context.Background(), db, sq, userID, []uuid.UUID{bench, squat})
+	if err != nil {
+		t.Fatalf("FetchEstimatedOneRepMaxes() error = %v", err)
+	}
+	if got := oneRepMaxes[bench]; got != 105 {
+		t.Errorf("bench one-rep max = %v, want 105", got)
+	}
+	if got := oneRepMaxes[squat]; got != 154 {
+		t.Errorf("squat one-rep max = %v, want 154", got)
+	}
+
+	// Only completed, non-deleted sets of the user's logs in the rep range of the formula count.
+	for _, want := range []string{
+		"wl.deleted_at IS NULL", "wl.user_id = ", "es.status = ", "es.deleted_at IS NULL",
+		"es.reps BETWEEN 1 AND 12", "GROUP BY es.exercise_id, es.weight",
+	} {
+		if !strings.Contains(gotQuery, want) {
+			t.Errorf("one-rep max query %q doesn't contain %q", gotQuery, want)
+		}
+	}
+}
+
+func TestFetchEstimatedOneRepMaxesWithoutExercises(t *testing.T) {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	queried := false
+	db := openFakeDB(t, func(string, []driver.NamedValue) ([]string, [][]driver.Value) {
+		queried = true
+		return nil, nil
+	})
+
+	oneRepMaxes, err := FetchEstimatedOneRepMaxes(context.Background(), db, sq, uuid.New(), nil)
+	if err != nil || len(oneRepMaxes) != 0 {
+		t.Errorf("FetchEstimatedOneRepMaxes() = %v, %v, want an empty map", oneRepMaxes, err)
+	}
+	if queried {
+		t.Error("FetchEstimatedOneRepMaxes() queried the database without exercises")
+	}
+}
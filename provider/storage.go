@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidStorageKey is returned when a key would escape the storage root.
+var ErrInvalidStorageKey = errors.New("invalid storage key")
+
+// Storage abstracts where uploaded files live. Keys are slash-separated relative paths
+// such as "avatars/<user-id>/256.jpg"; implementations map them onto their backend.
+type Storage interface {
+	// Put stores the content under key, overwriting any existing object, and returns its public URL.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) (string, error)
+	// Delete removes the object stored under key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL for key without checking that it exists.
+	URL(key string) string
+	// KeyForURL is the inverse of URL. ok is false for URLs this storage didn't produce
+	// (e.g. a Google profile picture), which callers must never try to delete.
+	KeyForURL(url string) (key string, ok bool)
+}
+
+// LocalDiskStorage implements Storage on the local filesystem.
+// Files are written below BaseDir and served by the HTTP server under BaseURL.
+type LocalDiskStorage struct {
+	BaseDir string
+	BaseURL string
+}
+
+// NewLocalDiskStorage creates a LocalDiskStorage, creating baseDir if it doesn't exist.
+func NewLocalDiskStorage(baseDir, baseURL string) (*LocalDiskStorage, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalDiskStorage{
+		BaseDir: baseDir,
+		BaseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// Put writes the content to a temporary file first and renames it into place,
+// so readers never see a partially written file.
+func (s *LocalDiskStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) (string, error) {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once the rename succeeded
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to close file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return "", fmt.Errorf("failed to move file into place: %w", err)
+	}
+
+	return s.URL(key), nil
+}
+
+// Delete removes the file for key.
+func (s *LocalDiskStorage) Delete(ctx context.Context, key string) error {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// URL joins the base URL and the key.
+func (s *LocalDiskStorage) URL(key string) string {
+	return s.BaseURL + "/" + strings.TrimLeft(path.Clean("/"+key), "/")
+}
+
+// KeyForURL strips the base URL from url.
+func (s *LocalDiskStorage) KeyForURL(url string) (string, bool) {
+	prefix := s.BaseURL + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, prefix), true
+}
+
+// resolve maps a key onto a path inside BaseDir, rejecting anything that would escape it.
+func (s *LocalDiskStorage) resolve(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", ErrInvalidStorageKey
+	}
+	return filepath.Join(s.BaseDir, filepath.FromSlash(strings.TrimLeft(cleaned, "/"))), nil
+}
@@ -1,9 +1,11 @@
 package provider
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
@@ -72,9 +74,71 @@ func Float64PtrToFloat64(p *float64, defaultValue float64) float64 {
 	return defaultValue
 }
 
+// StringPtrToString converts an *string to string, returning "" if the pointer is nil.
+func StringPtrToString(p *string) string {
+	if p != nil {
+		return *p
+	}
+	return ""
+}
+
+// NullStringToStringPtr converts sql.NullString to *string.
+func NullStringToStringPtr(ns sql.NullString) *string {
+	if ns.Valid {
+		return &ns.String
+	}
+	return nil
+}
+
 func NullUUIDToUUIDPtr(nu uuid.NullUUID) *uuid.UUID {
 	if nu.Valid {
 		return &nu.UUID
 	}
 	return nil
 }
+
+// LoadLocationOrUTC resolves an IANA timezone name, falling back to UTC when it is empty or unknown.
+func LoadLocationOrUTC(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// UserLocation looks up the timezone stored on the user's profile.
+// Users without a profile (or with an invalid timezone) get UTC so stats still work.
+func UserLocation(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, userID uuid.UUID) *time.Location {
+	query, args, err := sq.Select("timezone").
+		From("profiles").
+		Where(squirrel.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return time.UTC
+	}
+	var name sql.NullString
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&name); err != nil {
+		return time.UTC
+	}
+	return LoadLocationOrUTC(name.String)
+}
+
+// StartOfDayIn returns midnight of t's calendar day in loc.
+func StartOfDayIn(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+// DayKeyIn returns t's calendar day in loc formatted as YYYY-MM-DD, used for day-bucketed stats.
+func DayKeyIn(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("2006-01-02")
+}
+
+// DateInLocation re-interprets a DATE value (scanned by the driver as midnight UTC) as the same
+// calendar day in loc.
+func DateInLocation(d time.Time, loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
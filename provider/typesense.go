@@ -1,19 +1,67 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"log"
+
+	"rtglabs-go/config"
+
+	"github.com/sony/gobreaker"
 	"github.com/typesense/typesense-go/v3/typesense"
 )
 
 type TypesenseClient struct {
 	Client *typesense.Client
+	Config *config.TypesenseConfig
+	// SearchBreaker guards exercise searches; while it is open they fail immediately and
+	// FailoverExerciseSearcher serves them from Postgres.
+	SearchBreaker *gobreaker.CircuitBreaker
 }
 
-func NewTypesenseClient(host, apiKey string) *TypesenseClient {
-	return &TypesenseClient{
-		Client: typesense.NewClient(
-			typesense.WithServer("http://"+host+":80"), // <-- Issue is likely here
-			typesense.WithAPIKey(apiKey),
-		),
+// NewTypesenseClient creates the client and the search circuit breaker. The nodes are always
+// passed with WithNodes, even when there is only one: WithServer leaves the node list empty,
+// which disables the client's retries and node health tracking.
+func NewTypesenseClient(cfg *config.TypesenseConfig) (*TypesenseClient, error) {
+	if len(cfg.Nodes) == 0 {
+		return nil, errors.New("no Typesense nodes configured (set TYPESENSE_NODES or TYPESENSE_HOST)")
+	}
+	opts := []typesense.ClientOption{
+		typesense.WithAPIKey(cfg.APIKey),
+		typesense.WithNodes(cfg.Nodes),
+		typesense.WithConnectionTimeout(cfg.ConnectionTimeout),
+		typesense.WithNumRetries(cfg.NumRetries),
+		typesense.WithRetryInterval(cfg.RetryInterval),
+		typesense.WithHealthcheckInterval(cfg.HealthCheckInterval),
 	}
+
+	breaker := gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:    "typesense_search",
+		Timeout: cfg.BreakerTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= cfg.BreakerFailures
+		},
+		// Requests Typesense rejects (bad filter, missing document) and requests the caller gave
+		// up on say nothing about its health.
+		IsSuccessful: func(err error) bool {
+			return err == nil || errors.Is(err, context.Canceled) || isTypesenseRequestError(err)
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			log.Printf("INFO: %s circuit breaker changed from %s to %s", name, from, to)
+			typesenseBreakerState.Set(float64(to))
+		},
+	})
+
+	return &TypesenseClient{
+		Client:        typesense.NewClient(opts...),
+		Config:        cfg,
+		SearchBreaker: breaker,
+	}, nil
 }
 
+// isTypesenseRequestError reports whether Typesense rejected the request itself with a 4xx, e.g.
+// an invalid filter_by or a missing document, rather than failing to serve it.
+func isTypesenseRequestError(err error) bool {
+	var httpErr *typesense.HTTPError
+	return errors.As(err, &httpErr) && httpErr.Status >= 400 && httpErr.Status < 500
+}
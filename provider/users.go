@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// IsAdmin reports whether userID is an active user with admin access.
+func IsAdmin(ctx context.Context, db *sql.DB, sq squirrel.StatementBuilderType, userID uuid.UUID) (bool, error) {
+	query, args, err := sq.Select("is_admin").
+		From("users").
+		Where(squirrel.Eq{"id": userID, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build admin query: %w", err)
+	}
+	var isAdmin bool
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&isAdmin); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to load user: %w", err)
+	}
+	return isAdmin, nil
+}